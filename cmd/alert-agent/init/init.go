@@ -54,19 +54,19 @@ func getDefaultMetrics() []MetricConfig {
 	return []MetricConfig{
 		{
 			Name:         "Mempool Size",
-			RestEndpoint: "http://localhost:2112/metrics",
+			RestEndpoint: roller.LocalEndpoint(consts.DefaultPorts.RollappMetrics) + "/metrics",
 			Metric:       "dymint_mempool_size",
 			Threshold:    20,
 		},
 		{
 			Name:         "Pending Submissions Skew",
-			RestEndpoint: "http://localhost:2112/metrics",
+			RestEndpoint: roller.LocalEndpoint(consts.DefaultPorts.RollappMetrics) + "/metrics",
 			Metric:       "rollapp_pending_submissions_skew_batches",
 			Threshold:    30,
 		},
 		{
 			Name:         "Failed DA Submissions",
-			RestEndpoint: "http://localhost:2112/metrics",
+			RestEndpoint: roller.LocalEndpoint(consts.DefaultPorts.RollappMetrics) + "/metrics",
 			Metric:       "rollapp_consecutive_failed_da_submissions",
 			Threshold:    30,
 		},
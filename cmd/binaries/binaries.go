@@ -3,7 +3,11 @@ package binaries
 import (
 	"github.com/spf13/cobra"
 
+	"github.com/dymensionxyz/roller/cmd/binaries/bundle"
 	"github.com/dymensionxyz/roller/cmd/binaries/install"
+	"github.com/dymensionxyz/roller/cmd/binaries/list"
+	"github.com/dymensionxyz/roller/cmd/binaries/rollback"
+	"github.com/dymensionxyz/roller/cmd/binaries/use"
 )
 
 func Cmd() *cobra.Command {
@@ -13,6 +17,10 @@ func Cmd() *cobra.Command {
 	}
 
 	cmd.AddCommand(install.Cmd())
+	cmd.AddCommand(bundle.Cmd())
+	cmd.AddCommand(list.Cmd())
+	cmd.AddCommand(use.Cmd())
+	cmd.AddCommand(rollback.Cmd())
 
 	return cmd
 }
@@ -0,0 +1,18 @@
+package bundle
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/dymensionxyz/roller/cmd/binaries/bundle/create"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bundle",
+		Short: "Commands to manage offline installation bundles",
+	}
+
+	cmd.AddCommand(create.Cmd())
+
+	return cmd
+}
@@ -0,0 +1,141 @@
+package create
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/bundle"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/output"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+const (
+	flagOutput        = "output-path"
+	flagEnv           = "env"
+	flagRollappID     = "rollapp-id"
+	flagSkipContracts = "skip-contracts"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Pack the installed binaries into a bundle for air-gapped hosts",
+		Long: `Pack the binaries installed on this host, the version manifest of the
+environment, the genesis file of the rollapp and the oracle contract artifacts
+into a bundle. Install it with 'roller binaries install --from-bundle' on a
+host of the same OS and arch without network access.
+
+Run it on a connected host after 'roller rollapp init' installed the binaries.
+The rollapp and the environment default to the ones of roller.toml.
+`,
+		Example: `  roller binaries bundle create --output-path ./bundle.tar.gz
+  roller binaries bundle create --env mainnet --rollapp-id myrollapp_1-1 --output-path ./bundle`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			format, err := initconfig.GetOutputFormat(cmd)
+			if err != nil {
+				return err
+			}
+			home, err := filesystem.ExpandHomePath(cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String())
+			if err != nil {
+				return err
+			}
+
+			out, _ := cmd.Flags().GetString(flagOutput)
+			out, err = filesystem.ExpandHomePath(out)
+			if err != nil {
+				return err
+			}
+			skipContracts, _ := cmd.Flags().GetBool(flagSkipContracts)
+			opts := bundle.CreateOptions{Contracts: !skipContracts}
+			opts.Env, _ = cmd.Flags().GetString(flagEnv)
+			opts.RollappID, _ = cmd.Flags().GetString(flagRollappID)
+
+			rollerData, err := roller.LoadConfig(home)
+			if err == nil {
+				if opts.Env == "" {
+					opts.Env = rollerData.Environment
+				}
+				if opts.RollappID == "" {
+					opts.RollappID = rollerData.RollappID
+				}
+				opts.Hub = rollerData.HubData
+			}
+			if opts.Env == "" {
+				opts.Env = consts.MainnetHubName
+			}
+			if opts.Hub.ID == "" {
+				hd, ok := consts.Hubs[opts.Env]
+				if !ok {
+					return fmt.Errorf("unknown environment %s", opts.Env)
+				}
+				opts.Hub = hd
+			}
+			if opts.Hub.ID == consts.MockHubID {
+				opts.RollappID = ""
+			}
+
+			dir := out
+			if bundle.IsArchive(out) {
+				dir, err = os.MkdirTemp("", "roller-bundle")
+				if err != nil {
+					return err
+				}
+				// nolint: errcheck
+				defer os.RemoveAll(dir)
+			} else if ok, _ := filesystem.DirNotEmpty(dir); ok {
+				return fmt.Errorf("%s is not empty", dir)
+			}
+
+			spinner, _ := pterm.DefaultSpinner.Start("creating bundle")
+			m, err := bundle.Create(dir, opts)
+			if err == nil && dir != out {
+				err = bundle.Archive(dir, out)
+			}
+			if err != nil {
+				spinner.Fail(err)
+				return err
+			}
+			spinner.Success(fmt.Sprintf("bundle created at %s", out))
+
+			if format.IsStructured() {
+				return output.Print(format, m)
+			}
+			render(m)
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagOutput, "", "bundle directory, or tarball when it ends with .tar.gz")
+	cmd.Flags().String(flagEnv, "", "environment to resolve the versions for, defaults to the one of roller.toml")
+	cmd.Flags().String(flagRollappID, "", "rollapp to pack the genesis file of, defaults to the one of roller.toml")
+	cmd.Flags().Bool(flagSkipContracts, false, "don't pack the oracle contract artifacts")
+	_ = cmd.MarkFlagRequired(flagOutput)
+
+	return cmd
+}
+
+func render(m *bundle.Manifest) {
+	data := pterm.TableData{{"ARTIFACT", "VERSION", "SHA256"}}
+	for _, a := range m.Binaries {
+		data = append(data, []string{a.Path, a.Version, a.SHA256[:12]})
+	}
+	if m.Genesis != nil {
+		data = append(data, []string{m.Genesis.Path, m.RollappID, m.Genesis.SHA256[:12]})
+	}
+	for _, a := range m.Contracts {
+		data = append(data, []string{a.Path, "", a.SHA256[:12]})
+	}
+	_ = pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+
+	if m.Versions.DrsVersion != "" {
+		fmt.Printf("drs version: %s, rollapp commit: %s\n", m.Versions.DrsVersion, m.Versions.RollappCommit)
+	}
+}
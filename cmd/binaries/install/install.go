@@ -1,18 +1,34 @@
 package install
 
 import (
+	"fmt"
+
 	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
 	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/bundle"
+	"github.com/dymensionxyz/roller/utils/filesystem"
 )
 
+const flagFromBundle = "from-bundle"
+
 func Cmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "install <rollapp-id>",
 		Short: "Install necessary binaries for operating a RollApp node",
 		Args:  cobra.MaximumNArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			if from, _ := cmd.Flags().GetString(flagFromBundle); from != "" {
+				if err := installFromBundle(cmd, from); err != nil {
+					return fmt.Errorf("failed to install from bundle: %w", err)
+				}
+				return nil
+			}
+
 			pterm.Info.Println("not implemented")
 			// home := cmd.Flag(utils.FlagNames.Home).Value.String()
 			//
@@ -95,11 +111,64 @@ func Cmd() *cobra.Command {
 			// }
 			// elapsed := time.Since(start)
 			// fmt.Println("time elapsed: ", elapsed)
+			return nil
 		},
 	}
 
 	cmd.Flags().String("node", consts.PlaygroundHubData.RpcUrl, "hub rpc endpoint")
 	cmd.Flags().String("chain-id", consts.PlaygroundHubData.ID, "hub chain id")
+	cmd.Flags().String(flagFromBundle, "", "install from a bundle directory or tarball created with 'roller binaries bundle create'")
+	initconfig.AddDryRunFlag(cmd)
 
 	return cmd
 }
+
+func installFromBundle(cmd *cobra.Command, from string) error {
+	home, err := filesystem.ExpandHomePath(cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String())
+	if err != nil {
+		return err
+	}
+	from, err = filesystem.ExpandHomePath(from)
+	if err != nil {
+		return err
+	}
+
+	dir, cleanup, err := bundle.Open(from)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	m, err := bundle.LoadManifest(dir)
+	if err != nil {
+		return err
+	}
+	format, err := initconfig.GetOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	if !format.IsStructured() {
+		pterm.Info.Printf(
+			"bundle created at %s for %s (%s/%s)\n",
+			m.CreatedAt.Format("2006-01-02 15:04:05"),
+			m.Env,
+			m.OS,
+			m.Arch,
+		)
+	}
+
+	err = bundle.Install(dir, m, home)
+	if err != nil || initconfig.IsDryRun(cmd) {
+		return err
+	}
+	pterm.Success.Printf("installed %d binaries from the bundle\n", len(m.Binaries))
+
+	if m.Genesis != nil {
+		pterm.Info.Println("initialize the rollapp with the bundled genesis file:")
+		fmt.Printf(
+			"  ROLLER_RA_GENESIS=%s roller rollapp init --skip-binary-installation\n",
+			bundle.GenesisPath(home),
+		)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package list
+
+import (
+	"strings"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/utils/binstore"
+	"github.com/dymensionxyz/roller/utils/output"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list [binary]",
+		Short: "Show the active and installed versions of the binaries",
+		Long: `Show the active version of every binary installed on this host, the
+version 'roller binaries rollback' restores and the other installed versions.
+
+Binaries installed before the binary store was used are marked as unmanaged,
+they are moved into the store on their next install or version switch.`,
+		Args:         cobra.MaximumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := initconfig.GetOutputFormat(cmd)
+			if err != nil {
+				return err
+			}
+
+			var binaries []binstore.Binary
+			if len(args) == 1 {
+				b, err := binstore.Get(args[0])
+				if err != nil {
+					return err
+				}
+				binaries = append(binaries, *b)
+			} else {
+				binaries, err = binstore.List()
+				if err != nil {
+					return err
+				}
+			}
+
+			if format.IsStructured() {
+				return output.Print(format, binaries)
+			}
+			if len(binaries) == 0 {
+				pterm.Info.Println("no binaries are installed")
+				return nil
+			}
+			render(binaries)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func render(binaries []binstore.Binary) {
+	data := pterm.TableData{{"BINARY", "ACTIVE", "PREVIOUS", "INSTALLED"}}
+	for _, b := range binaries {
+		active := b.Active
+		switch {
+		case active == "":
+			active = "-"
+		case !b.Managed:
+			active += " (unmanaged)"
+		}
+
+		previous := b.Previous
+		if previous == "" {
+			previous = "-"
+		}
+
+		var installed []string
+		for _, v := range b.Versions {
+			installed = append(installed, v.ID)
+		}
+		data = append(data, []string{b.Name, active, previous, strings.Join(installed, ", ")})
+	}
+	_ = pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+}
@@ -0,0 +1,37 @@
+package rollback
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/dymensionxyz/roller/cmd/binaries/use"
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/utils/binstore"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rollback <binary>",
+		Short: "Switch a binary back to its previous version",
+		Long: `Switch a binary back to the version that was active before the last
+install, upgrade or switch. Rolling back twice restores the version that was
+active initially.
+
+Config changes made by the upgrade are not reverted, restore them with
+'roller config rollback' when needed.`,
+		Example:      `  roller binaries rollback rollappd`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			id, err := binstore.Rollback(name)
+			if err != nil {
+				return err
+			}
+			return use.Switched(cmd, name, id)
+		},
+	}
+
+	initconfig.AddDryRunFlag(cmd)
+
+	return cmd
+}
@@ -0,0 +1,86 @@
+package use
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/binstore"
+	"github.com/dymensionxyz/roller/utils/config/tomlconfig"
+	"github.com/dymensionxyz/roller/utils/dependencies"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/plan"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "use <binary> <version>",
+		Short: "Switch a binary to one of its installed versions",
+		Long: `Switch a binary to one of its installed versions, the version that was
+active is recorded as the previous one. Run 'roller binaries list' for the
+installed versions.`,
+		Example:      `  roller binaries use rollappd v2.2.1`,
+		Args:         cobra.ExactArgs(2),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name, id := args[0], args[1]
+			if err := binstore.Use(name, id); err != nil {
+				return err
+			}
+			return Switched(cmd, name, id)
+		},
+	}
+
+	initconfig.AddDryRunFlag(cmd)
+
+	return cmd
+}
+
+// Switched reports the switch of the binary to version id. The rollapp binary
+// version of roller.toml follows the rollapp binary
+func Switched(cmd *cobra.Command, name, id string) error {
+	if plan.Enabled() {
+		return nil
+	}
+
+	if name == filepath.Base(consts.Executables.RollappEVM) {
+		if err := updateRollappBinaryVersion(cmd); err != nil {
+			return err
+		}
+	}
+
+	pterm.Success.Printf("%s %s is active\n", name, id)
+	pterm.Info.Printf(
+		"restart the running services for the change to take effect, e.g. %s\n",
+		pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
+			Sprintf("roller rollapp services restart"),
+	)
+	return nil
+}
+
+func updateRollappBinaryVersion(cmd *cobra.Command) error {
+	home, err := filesystem.ExpandHomePath(cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String())
+	if err != nil {
+		return err
+	}
+	if _, err := roller.LoadConfig(home); err != nil {
+		return nil
+	}
+
+	commit, err := dependencies.ExtractCommitFromBinaryVersion(consts.Executables.RollappEVM)
+	if err != nil || commit == "" {
+		pterm.Warning.Println("failed to read the commit of the rollapp binary, rollapp_binary_version of roller.toml is unchanged")
+		return nil
+	}
+
+	err = tomlconfig.UpdateFieldInFile(roller.GetConfigPath(home), "rollapp_binary_version", commit)
+	if err != nil {
+		return fmt.Errorf("failed to update rollapp binary version in config: %w", err)
+	}
+	return nil
+}
@@ -3,7 +3,10 @@ package config
 import (
 	"github.com/spf13/cobra"
 
+	"github.com/dymensionxyz/roller/cmd/config/history"
+	"github.com/dymensionxyz/roller/cmd/config/rollback"
 	"github.com/dymensionxyz/roller/cmd/config/set"
+	"github.com/dymensionxyz/roller/cmd/config/show"
 )
 
 func Cmd() *cobra.Command {
@@ -12,8 +15,10 @@ func Cmd() *cobra.Command {
 		Short: "Commands for setting up and managing rollapp configuration files.",
 	}
 
-	// cmd.AddCommand(show.Cmd())
+	cmd.AddCommand(show.Cmd())
 	cmd.AddCommand(set.Cmd())
+	cmd.AddCommand(history.Cmd())
+	cmd.AddCommand(rollback.Cmd())
 	// cmd.AddCommand(export.Cmd())
 	return cmd
 }
@@ -0,0 +1,74 @@
+package history
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/utils/config/backup"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/output"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Show the backup generations of the config files",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				return fmt.Errorf("failed to expand home directory: %w", err)
+			}
+
+			format, err := initconfig.GetOutputFormat(cmd)
+			if err != nil {
+				return err
+			}
+
+			gens, err := backup.List(home)
+			if err != nil {
+				return fmt.Errorf("failed to load backup generations: %w", err)
+			}
+
+			if format.IsStructured() {
+				if gens == nil {
+					gens = []backup.Generation{}
+				}
+				return output.Print(format, gens)
+			}
+
+			if len(gens) == 0 {
+				pterm.Info.Println("no config changes recorded")
+				return nil
+			}
+
+			data := pterm.TableData{{"GENERATION", "TIME", "FILES", "COMMAND"}}
+			for _, g := range gens {
+				data = append(data, []string{
+					fmt.Sprint(g.ID),
+					g.CreatedAt.Local().Format(time.DateTime),
+					fmt.Sprint(len(g.Files)),
+					g.Command,
+				})
+			}
+
+			// nolint: errcheck
+			pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+			pterm.Info.Printf(
+				"run %s to restore the config files as they were before a generation\n",
+				pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
+					Sprintf("roller config rollback <generation>"),
+			)
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -3,6 +3,8 @@ package initconfig
 import (
 	"github.com/spf13/cobra"
 
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/output"
 	"github.com/dymensionxyz/roller/utils/roller"
 )
 
@@ -11,10 +13,92 @@ func AddGlobalFlags(command *cobra.Command) {
 
 	command.PersistentFlags().StringP(
 		GlobalFlagNames.Home, "", home, "The directory of the roller config files")
+	command.PersistentFlags().StringP(
+		GlobalFlagNames.Output, "o", string(output.FormatText),
+		"Output format (text|json|yaml)",
+	)
+	command.PersistentFlags().String(
+		GlobalFlagNames.Rollapp, "",
+		"The rollapp profile to use, defaults to the current context",
+	)
+}
+
+// SkipProfileAnnotation marks commands that work on the roller home itself
+// rather than on a rollapp profile
+const SkipProfileAnnotation = "roller.skip-profile"
+
+// ApplyProfile points the home flag to the home of the selected rollapp
+// profile, which is either the one passed with the rollapp flag or the
+// current context. An explicit home flag selects the profile it points to,
+// so that services started with the default home don't follow the context
+func ApplyProfile(cmd *cobra.Command) error {
+	for c := cmd; c != nil; c = c.Parent() {
+		if _, ok := c.Annotations[SkipProfileAnnotation]; ok {
+			return nil
+		}
+	}
+
+	homeFlag := cmd.Flag(GlobalFlagNames.Home)
+	if homeFlag == nil {
+		return nil
+	}
+
+	home, err := filesystem.ExpandHomePath(homeFlag.Value.String())
+	if err != nil {
+		return err
+	}
+
+	var profile string
+	if f := cmd.Flag(GlobalFlagNames.Rollapp); f != nil {
+		profile = f.Value.String()
+	}
+
+	baseHome, homeProfile := roller.SplitProfileHome(home)
+	if profile == "" && (homeFlag.Changed || homeProfile != roller.DefaultProfile) {
+		profile = homeProfile
+	}
+
+	profileHome, err := roller.ResolveProfile(baseHome, profile)
+	if err != nil {
+		return err
+	}
+
+	return homeFlag.Value.Set(profileHome)
+}
+
+// AddDryRunFlag adds the dry-run flag to a command that mutates config files,
+// keys, the chain or system services
+func AddDryRunFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool(
+		GlobalFlagNames.DryRun, false,
+		"Print the planned file changes and actions without applying them",
+	)
+}
+
+// IsDryRun returns whether the command was called with the dry-run flag
+func IsDryRun(cmd *cobra.Command) bool {
+	f := cmd.Flags().Lookup(GlobalFlagNames.DryRun)
+	return f != nil && f.Value.String() == "true"
+}
+
+// GetOutputFormat returns the output format requested with the global output flag
+func GetOutputFormat(cmd *cobra.Command) (output.Format, error) {
+	f := cmd.Flag(GlobalFlagNames.Output)
+	if f == nil {
+		return output.FormatText, nil
+	}
+
+	return output.ParseFormat(f.Value.String())
 }
 
 var GlobalFlagNames = struct {
-	Home string
+	Home    string
+	Output  string
+	Rollapp string
+	DryRun  string
 }{
-	Home: "home",
+	Home:    "home",
+	Output:  "output",
+	Rollapp: "rollapp",
+	DryRun:  "dry-run",
 }
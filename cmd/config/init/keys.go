@@ -13,11 +13,14 @@ func FormatAddresses(
 	raCfg roller.RollappConfig,
 	addresses []keys.KeyInfo,
 ) []keys.KeyInfo {
-	damanager := datalayer.NewDAManager(raCfg.DA.Backend, raCfg.Home, raCfg.KeyringBackend, raCfg.NodeType)
 	requireFundingKeys := map[string]string{
 		consts.KeysIds.HubSequencer: fmt.Sprintf("Sequencer, %s Hub", raCfg.HubData.ID),
 		consts.KeysIds.HubRelayer:   fmt.Sprintf("Relayer, %s Hub", raCfg.HubData.ID),
-		damanager.GetKeyName():      fmt.Sprintf("DA, %s Network", damanager.GetNetworkName()), // Todo: Need to get NetworkName from rollappConfig
+	}
+	damanager, err := datalayer.NewDAManager(raCfg.DA.Backend, raCfg.Home, raCfg.KeyringBackend, raCfg.NodeType)
+	if err == nil {
+		// Todo: Need to get NetworkName from rollappConfig
+		requireFundingKeys[damanager.GetKeyName()] = fmt.Sprintf("DA, %s Network", damanager.GetNetworkName())
 	}
 	filteredAddresses := make([]keys.KeyInfo, 0)
 	for _, address := range addresses {
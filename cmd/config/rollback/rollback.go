@@ -0,0 +1,89 @@
+package rollback
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/utils/config/backup"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/plan"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rollback <generation>",
+		Short: "Restore the config files as they were before a backup generation was created",
+		Long: `Restore the config files as they were before a backup generation was created.
+
+Every file changed by the generation or by any later one is restored, files that
+did not exist at that point are removed. The rollback itself is recorded as a new
+generation, so it can be reverted as well.`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				return err
+			}
+
+			id, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid generation %s", args[0])
+			}
+
+			states, err := backup.StateBefore(home, id)
+			if err != nil {
+				return err
+			}
+
+			autoAccept, _ := cmd.Flags().GetBool("yes")
+			if !autoAccept && !plan.Enabled() {
+				pterm.Info.Printf("%d files will be restored:\n", len(states))
+				for _, s := range states {
+					fmt.Println(" ", s.Path)
+				}
+
+				ok, _ := pterm.DefaultInteractiveConfirm.WithDefaultText(
+					"Would you like to continue?",
+				).Show()
+				if !ok {
+					return fmt.Errorf("rollback cancelled")
+				}
+			}
+
+			for _, s := range states {
+				if !s.Exists {
+					err = plan.Remove(s.Path)
+				} else {
+					err = plan.WriteFile(s.Path, s.Content, 0o644)
+				}
+				if err != nil {
+					return fmt.Errorf("failed to restore %s: %w", s.Path, err)
+				}
+			}
+
+			if plan.Enabled() {
+				return nil
+			}
+
+			pterm.Success.Printf("💈 Restored the config files from before generation %d\n", id)
+			pterm.Info.Printf(
+				"restart the running services for the changes to take effect, e.g. %s\n",
+				pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
+					Sprintf("roller rollapp services restart"),
+			)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolP("yes", "y", false, "automatically accept prompts")
+	initconfig.AddDryRunFlag(cmd)
+
+	return cmd
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/dymensionxyz/roller/cmd/consts"
 	"github.com/dymensionxyz/roller/cmd/utils"
 	datalayer "github.com/dymensionxyz/roller/data_layer"
+	"github.com/dymensionxyz/roller/data_layer/generic"
 	"github.com/dymensionxyz/roller/sequencer"
 	"github.com/dymensionxyz/roller/utils/filesystem"
 	"github.com/dymensionxyz/roller/utils/keys"
@@ -53,6 +54,13 @@ func setDARPC(rlpCfg roller.RollappConfig, value string) error {
 		if err := sequencer.UpdateDymintDANodeConfig(rlpCfg, "endpoint"); err != nil {
 			return err
 		}
+	case consts.Generic:
+		if err := generic.SetRPCEndpoint(rlpCfg.Home, value); err != nil {
+			return err
+		}
+		if err := sequencer.UpdateDymintDANodeConfig(rlpCfg, "base_url"); err != nil {
+			return err
+		}
 	default:
 		return fmt.Errorf("your current da doesn't use this config value")
 	}
@@ -127,7 +135,10 @@ func updateDaConfig(rlpCfg roller.RollappConfig, newDa consts.DAType) error {
 		return err
 	}
 
-	daManager := datalayer.NewDAManager(newDa, rlpCfg.Home, rlpCfg.KeyringBackend, rlpCfg.NodeType)
+	daManager, err := datalayer.NewDAManager(newDa, rlpCfg.Home, rlpCfg.KeyringBackend, rlpCfg.NodeType)
+	if err != nil {
+		return err
+	}
 	_, err = daManager.InitializeLightNodeConfig()
 	if err != nil {
 		return err
@@ -145,7 +156,10 @@ func updateDaConfig(rlpCfg roller.RollappConfig, newDa consts.DAType) error {
 	fmt.Printf("💈 RollApp DA has been successfully set to '%s'\n\n", newDa)
 	if newDa != consts.Local {
 		addresses := make([]keys.KeyInfo, 0)
-		damanager := datalayer.NewDAManager(newDa, rlpCfg.Home, rlpCfg.KeyringBackend, rlpCfg.NodeType)
+		damanager, err := datalayer.NewDAManager(newDa, rlpCfg.Home, rlpCfg.KeyringBackend, rlpCfg.NodeType)
+		if err != nil {
+			return err
+		}
 		daAddress, err := damanager.GetDAAccountAddress()
 		if err != nil {
 			return err
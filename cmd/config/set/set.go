@@ -36,6 +36,8 @@ func Cmd() *cobra.Command {
 			)
 		},
 	}
+	initconfig.AddDryRunFlag(cmd)
+
 	return cmd
 }
 
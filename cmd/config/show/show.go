@@ -5,22 +5,33 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/BurntSushi/toml"
 	"github.com/spf13/cobra"
 
 	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
 	"github.com/dymensionxyz/roller/cmd/consts"
-	"github.com/dymensionxyz/roller/utils/errorhandling"
+	"github.com/dymensionxyz/roller/utils/output"
 )
 
 func Cmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "show",
 		Short: "Show the configuration of the rollapp on the local machine.",
-		Run: func(cmd *cobra.Command, args []string) {
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
 			home := cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String()
-			errorhandling.PrettifyErrorIfExists(
-				printFileContent(filepath.Join(home, consts.RollerConfigFileName)),
-			)
+			format, err := initconfig.GetOutputFormat(cmd)
+			if err != nil {
+				return err
+			}
+
+			rollerConfigPath := filepath.Join(home, consts.RollerConfigFileName)
+			if format.IsStructured() {
+				return printStructuredContent(rollerConfigPath, format)
+			}
+
+			return printFileContent(rollerConfigPath)
 		},
 	}
 	return cmd
@@ -34,3 +45,12 @@ func printFileContent(path string) error {
 	fmt.Println(string(content))
 	return nil
 }
+
+func printStructuredContent(path string, format output.Format) error {
+	var content map[string]any
+	if _, err := toml.DecodeFile(path, &content); err != nil {
+		return err
+	}
+
+	return output.Print(format, content)
+}
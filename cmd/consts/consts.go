@@ -93,6 +93,32 @@ var Oracles = struct {
 	Price: "price",
 }
 
+// OracleContracts are the contract artifacts deployed by the oracles
+var OracleContracts = struct {
+	Context                string
+	Ownable                string
+	PriceOracle            string
+	PriceOracleWasm        string
+	RngEventManager        string
+	RngRandomnessGenerator string
+}{
+	Context:                "https://storage.googleapis.com/dymension-roller/Context.sol",
+	Ownable:                "https://storage.googleapis.com/dymension-roller/Ownable.sol",
+	PriceOracle:            "https://storage.googleapis.com/dymension-roller/price_oracle_contract.sol",
+	PriceOracleWasm:        "https://storage.googleapis.com/dymension-roller/price_oracle_contract.wasm",
+	RngEventManager:        "https://storage.googleapis.com/dymension-roller/rng_EventManager.sol",
+	RngRandomnessGenerator: "https://storage.googleapis.com/dymension-roller/rng_RandomnessGenerator.sol",
+}
+
+var OracleContractURLs = []string{
+	OracleContracts.Context,
+	OracleContracts.Ownable,
+	OracleContracts.PriceOracle,
+	OracleContracts.PriceOracleWasm,
+	OracleContracts.RngEventManager,
+	OracleContracts.RngRandomnessGenerator,
+}
+
 var AddressPrefixes = struct {
 	Hub string
 }{
@@ -111,6 +137,7 @@ var ConfigDirName = struct {
 	Oracle               string
 	Snapshots            string
 	AlertAgent           string
+	Contracts            string
 }{
 	Rollapp:              "rollapp",
 	Relayer:              "relayer",
@@ -123,6 +150,7 @@ var ConfigDirName = struct {
 	Oracle:               "oracle",
 	Snapshots:            "snapshots",
 	AlertAgent:           "alert-agent",
+	Contracts:            "contracts",
 }
 
 var Denoms = struct {
@@ -156,9 +184,40 @@ var Denoms = struct {
 const (
 	KeysDirName        = "keys"
 	DefaultRelayerPath = "hub-rollapp"
-	DefaultRollappRPC  = "http://localhost:26657"
+	// ProfilesDirName is the directory in the roller home that holds the
+	// named rollapp profiles, ContextsFileName lists them
+	ProfilesDirName  = "profiles"
+	ContextsFileName = "contexts.toml"
+	// BackupsDirName holds the backup generations of the config files
+	BackupsDirName = "backups"
 )
 
+// DefaultPorts are the local ports of the default profile, named profiles
+// shift them by their port offset
+var DefaultPorts = struct {
+	RollappRPC       int
+	RollappP2P       int
+	RollappAPI       int
+	RollappGRPC      int
+	RollappJsonRPC   int
+	RollappJsonRPCWS int
+	RollappMetrics   int
+	DARPC            int
+	DAGateway        int
+	RollerExporter   int
+}{
+	RollappRPC:       26657,
+	RollappP2P:       26656,
+	RollappAPI:       1317,
+	RollappGRPC:      9090,
+	RollappJsonRPC:   8545,
+	RollappJsonRPCWS: 8546,
+	RollappMetrics:   2112,
+	DARPC:            26658,
+	DAGateway:        26659,
+	RollerExporter:   2113,
+}
+
 var SpinnerMsgs = struct {
 	UniqueIdVerification string
 	BalancesVerification string
@@ -33,6 +33,8 @@ const (
 	Solana      DAType = "solana"
 	Ethereum    DAType = "ethereum"
 	Kaspa       DAType = "kaspa"
+	// Generic is any DA reachable through the celestia-node compatible DA JSON-RPC
+	Generic DAType = "generic"
 )
 
 type DaNetwork string
@@ -60,6 +62,7 @@ const (
 	EthereumMainnet DaNetwork = "eth-mainnet"
 	KaspaTestnet    DaNetwork = "kaspa-testnet"
 	KaspaMainnet    DaNetwork = "kaspa-mainnet"
+	GenericDA       DaNetwork = "generic"
 )
 
 var DaNetworks = map[string]DaData{
@@ -263,4 +266,13 @@ var DaNetworks = map[string]DaData{
 		StateNodes:       []string{},
 		GasPrice:         "",
 	},
+	string(GenericDA): {
+		Backend:          Generic,
+		ApiUrl:           "",
+		ID:               GenericDA,
+		RpcUrl:           "",
+		CurrentStateNode: "",
+		StateNodes:       []string{},
+		GasPrice:         "",
+	},
 }
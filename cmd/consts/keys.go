@@ -10,12 +10,23 @@ func (s SupportedKeyringBackend) String() string {
 	return string(s)
 }
 
+// RequiresPassphrase returns whether the keyring prompts for a passphrase
+func (s SupportedKeyringBackend) RequiresPassphrase() bool {
+	return s == SupportedKeyringBackends.OS || s == SupportedKeyringBackends.File
+}
+
 var SupportedKeyringBackends = struct {
 	OS   SupportedKeyringBackend
 	Test SupportedKeyringBackend
+	File SupportedKeyringBackend
+	// Remote is the backend of the keys held by the remote signer, it is not a
+	// local keyring
+	Remote SupportedKeyringBackend
 }{
-	OS:   "os",
-	Test: "test",
+	OS:     "os",
+	Test:   "test",
+	File:   "file",
+	Remote: "remote",
 }
 
 type OsKeyringPwdFileName string
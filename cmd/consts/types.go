@@ -26,5 +26,7 @@ type DaData = struct {
 	// TODO: combine CurrentStateNode and StateNodes
 	CurrentStateNode string   `toml:"current_state_node"`
 	StateNodes       []string `toml:"state_nodes"`
-	GasPrice         string   `toml:"gas_price"`
+	// PinnedStateNode disables the automatic state node failover when set
+	PinnedStateNode string `toml:"pinned_state_node,omitempty"`
+	GasPrice        string `toml:"gas_price"`
 }
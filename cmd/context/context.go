@@ -0,0 +1,33 @@
+package context
+
+import (
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/cmd/context/create"
+	"github.com/dymensionxyz/roller/cmd/context/current"
+	"github.com/dymensionxyz/roller/cmd/context/list"
+	"github.com/dymensionxyz/roller/cmd/context/use"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "context",
+		Short: "Commands to manage the rollapp profiles of the roller home",
+		Long: `Commands to manage the rollapp profiles of the roller home.
+
+Every profile is a separate rollapp with its own config directory, keys,
+system services and ports. The profile that lives directly in the roller home
+is called 'default'. Any command can target a profile with the --rollapp flag,
+otherwise the current context is used.
+`,
+		Annotations: map[string]string{initconfig.SkipProfileAnnotation: ""},
+	}
+
+	cmd.AddCommand(create.Cmd())
+	cmd.AddCommand(use.Cmd())
+	cmd.AddCommand(list.Cmd())
+	cmd.AddCommand(current.Cmd())
+
+	return cmd
+}
@@ -0,0 +1,56 @@
+package create
+
+import (
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create a new rollapp profile",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+			baseHome, _ := roller.SplitProfileHome(home)
+
+			name := args[0]
+			p, err := roller.CreateProfile(baseHome, name)
+			if err != nil {
+				pterm.Error.Println("failed to create profile:", err)
+				return
+			}
+
+			pterm.Success.Printf(
+				"💈 Profile %s created in %s (port offset %d)\n",
+				name,
+				roller.ProfileHome(baseHome, name),
+				p.PortOffset,
+			)
+
+			pterm.Info.Println("next steps:")
+			pterm.Info.Printf(
+				"run %s to initialize the rollapp of the profile\n",
+				pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
+					Sprintf("roller rollapp init --rollapp %s", name),
+			)
+			pterm.Info.Printf(
+				"run %s to make it the default for all commands\n",
+				pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
+					Sprintf("roller context use %s", name),
+			)
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,39 @@
+package current
+
+import (
+	"fmt"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "current",
+		Short: "Show the rollapp profile of the current context",
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+			baseHome, _ := roller.SplitProfileHome(home)
+
+			c, err := roller.LoadContexts(baseHome)
+			if err != nil {
+				pterm.Error.Println("failed to load contexts:", err)
+				return
+			}
+
+			fmt.Println(c.CurrentProfile())
+		},
+	}
+
+	return cmd
+}
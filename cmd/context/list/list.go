@@ -0,0 +1,86 @@
+package list
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/output"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+type profileOutput struct {
+	Name        string `json:"name" yaml:"name"`
+	Home        string `json:"home" yaml:"home"`
+	PortOffset  int    `json:"port_offset" yaml:"port_offset"`
+	Current     bool   `json:"current" yaml:"current"`
+	Initialized bool   `json:"initialized" yaml:"initialized"`
+}
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the rollapp profiles of the roller home",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				return fmt.Errorf("failed to expand home directory: %w", err)
+			}
+			baseHome, _ := roller.SplitProfileHome(home)
+
+			format, err := initconfig.GetOutputFormat(cmd)
+			if err != nil {
+				return err
+			}
+
+			c, err := roller.LoadContexts(baseHome)
+			if err != nil {
+				return fmt.Errorf("failed to load contexts: %w", err)
+			}
+
+			profiles := make([]profileOutput, 0, len(c.Profiles)+1)
+			for _, name := range c.ProfileNames() {
+				profileHome := roller.ProfileHome(baseHome, name)
+				_, err := os.Stat(roller.GetConfigPath(profileHome))
+				profiles = append(profiles, profileOutput{
+					Name:        name,
+					Home:        profileHome,
+					PortOffset:  c.Profiles[name].PortOffset,
+					Current:     name == c.CurrentProfile(),
+					Initialized: err == nil,
+				})
+			}
+
+			if format.IsStructured() {
+				return output.Print(format, profiles)
+			}
+
+			data := pterm.TableData{{"", "NAME", "HOME", "PORT OFFSET", "INITIALIZED"}}
+			for _, p := range profiles {
+				marker := ""
+				if p.Current {
+					marker = "*"
+				}
+				data = append(data, []string{
+					marker,
+					p.Name,
+					p.Home,
+					fmt.Sprint(p.PortOffset),
+					fmt.Sprint(p.Initialized),
+				})
+			}
+
+			return pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,58 @@
+package use
+
+import (
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "use <name>",
+		Short: "Set the rollapp profile used when no --rollapp flag is provided",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+			baseHome, _ := roller.SplitProfileHome(home)
+
+			c, err := roller.LoadContexts(baseHome)
+			if err != nil {
+				pterm.Error.Println("failed to load contexts:", err)
+				return
+			}
+
+			name := args[0]
+			if _, ok := c.Profiles[name]; !ok && name != roller.DefaultProfile {
+				pterm.Error.Printf(
+					"profile %s does not exist. Available profiles: %v\n",
+					name,
+					c.ProfileNames(),
+				)
+				return
+			}
+
+			c.Current = name
+			if name == roller.DefaultProfile {
+				c.Current = ""
+			}
+
+			if err := roller.WriteContexts(baseHome, c); err != nil {
+				pterm.Error.Println("failed to update contexts:", err)
+				return
+			}
+
+			pterm.Success.Printf("💈 Switched to profile %s\n", name)
+		},
+	}
+
+	return cmd
+}
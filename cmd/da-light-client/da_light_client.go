@@ -3,6 +3,7 @@ package da_light_client
 import (
 	"github.com/spf13/cobra"
 
+	"github.com/dymensionxyz/roller/cmd/da-light-client/nodes"
 	da_start "github.com/dymensionxyz/roller/cmd/da-light-client/start"
 	"github.com/dymensionxyz/roller/cmd/da-light-client/update"
 )
@@ -14,6 +15,7 @@ func DALightClientCmd() *cobra.Command {
 	}
 	cmd.AddCommand(da_start.Cmd())
 	cmd.AddCommand(update.Cmd())
+	cmd.AddCommand(nodes.Cmd())
 
 	return cmd
 }
@@ -0,0 +1,56 @@
+package history
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/utils/output"
+	"github.com/dymensionxyz/roller/utils/statenode"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Show the state node switches and their reasons",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			home := cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String()
+			format, err := initconfig.GetOutputFormat(cmd)
+			if err != nil {
+				return err
+			}
+
+			events, err := statenode.LoadHistory(home)
+			if err != nil {
+				return fmt.Errorf("failed to load state node history: %w", err)
+			}
+
+			if format.IsStructured() {
+				return output.Print(format, events)
+			}
+
+			if len(events) == 0 {
+				pterm.Info.Println("no state node switches recorded")
+				return nil
+			}
+
+			for _, e := range events {
+				fmt.Printf(
+					"%s  %s -> %s  %s\n",
+					e.Time.Local().Format(time.DateTime),
+					e.From,
+					e.To,
+					e.Reason,
+				)
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
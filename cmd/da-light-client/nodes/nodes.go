@@ -0,0 +1,130 @@
+package nodes
+
+import (
+	"fmt"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/cmd/da-light-client/nodes/history"
+	"github.com/dymensionxyz/roller/cmd/da-light-client/nodes/pin"
+	"github.com/dymensionxyz/roller/cmd/da-light-client/nodes/unpin"
+	"github.com/dymensionxyz/roller/utils/output"
+	"github.com/dymensionxyz/roller/utils/plan"
+	"github.com/dymensionxyz/roller/utils/roller"
+	"github.com/dymensionxyz/roller/utils/statenode"
+)
+
+type nodeOutput struct {
+	statenode.Score `yaml:",inline"`
+	Current         bool `json:"current" yaml:"current"`
+	Pinned          bool `json:"pinned" yaml:"pinned"`
+}
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "nodes",
+		Short: "List the DA state nodes and their health scores",
+		Long: `List the DA state nodes and their health scores.
+
+Every configured state node is probed for latency, head height and errors.
+The scores are kept across runs and drive the automatic state node failover
+of the health agent, unless a node is pinned.
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			home := cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String()
+			format, err := initconfig.GetOutputFormat(cmd)
+			if err != nil {
+				return err
+			}
+
+			rollerData, err := roller.LoadConfig(home)
+			if err != nil {
+				return fmt.Errorf("failed to load roller config file: %w", err)
+			}
+
+			if len(statenode.Candidates(rollerData.DA)) == 0 {
+				pterm.Info.Printf("no state nodes are configured for %s\n", rollerData.DA.Backend)
+				return nil
+			}
+
+			offline, _ := cmd.Flags().GetBool("offline")
+			var scores map[string]statenode.Score
+			if offline {
+				scores, err = statenode.LoadScores(home)
+			} else {
+				scores, err = statenode.Refresh(home, rollerData.DA)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to score state nodes: %w", err)
+			}
+
+			var nodes []nodeOutput
+			for _, s := range statenode.Sorted(scores) {
+				nodes = append(nodes, nodeOutput{
+					Score:   s,
+					Current: s.Node == rollerData.DA.CurrentStateNode,
+					Pinned:  s.Node == rollerData.DA.PinnedStateNode,
+				})
+			}
+
+			if format.IsStructured() {
+				return output.Print(format, nodes)
+			}
+
+			printNodes(nodes)
+			if rollerData.DA.PinnedStateNode != "" {
+				pterm.Info.Printf(
+					"state node is pinned to %s, automatic failover is disabled\n",
+					rollerData.DA.PinnedStateNode,
+				)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool("offline", false, "show the last known scores without probing the nodes")
+
+	cmd.AddCommand(pin.Cmd())
+	cmd.AddCommand(unpin.Cmd())
+	cmd.AddCommand(history.Cmd())
+
+	return cmd
+}
+
+func printNodes(nodes []nodeOutput) {
+	data := pterm.TableData{
+		{"", "NODE", "SCORE", "LATENCY", "HEIGHT", "LAG", "ERROR RATE", "LAST ERROR"},
+	}
+	for _, n := range nodes {
+		marker := ""
+		switch {
+		case n.Current && n.Pinned:
+			marker = "*!"
+		case n.Current:
+			marker = "*"
+		case n.Pinned:
+			marker = "!"
+		}
+
+		data = append(data, []string{
+			marker,
+			n.Node,
+			fmt.Sprintf("%.2f", n.Score.Score),
+			fmt.Sprintf("%.0fms", n.LatencyMs),
+			fmt.Sprint(n.Height),
+			fmt.Sprint(n.HeightLag),
+			fmt.Sprintf("%.2f", n.ErrorRate),
+			n.LastError,
+		})
+	}
+
+	err := pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+	if err != nil {
+		pterm.Error.Println(err)
+		plan.Exit(1)
+	}
+}
@@ -0,0 +1,62 @@
+package pin
+
+import (
+	"slices"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/utils/config/tomlconfig"
+	"github.com/dymensionxyz/roller/utils/roller"
+	"github.com/dymensionxyz/roller/utils/statenode"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pin <node>",
+		Short: "Pin the DA light client to a state node and disable the automatic failover",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			home := cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String()
+			rollerData, err := roller.LoadConfig(home)
+			if err != nil {
+				pterm.Error.Println("failed to load roller config file", err)
+				return
+			}
+
+			node := args[0]
+			candidates := statenode.Candidates(rollerData.DA)
+			if !slices.Contains(candidates, node) {
+				pterm.Error.Printf(
+					"%s is not a configured state node. Available nodes: %v\n",
+					node,
+					candidates,
+				)
+				return
+			}
+
+			err = tomlconfig.UpdateFieldInFile(
+				roller.GetConfigPath(home),
+				"DA.pinned_state_node",
+				node,
+			)
+			if err != nil {
+				pterm.Error.Println("failed to pin state node:", err)
+				return
+			}
+
+			if node != rollerData.DA.CurrentStateNode {
+				err = statenode.Switch(rollerData, node, "pinned manually", 0)
+				if err != nil {
+					pterm.Error.Println("failed to switch state node:", err)
+					return
+				}
+			}
+
+			pterm.Success.Printf("💈 State node pinned to %s\n", node)
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,46 @@
+package unpin
+
+import (
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/utils/config/tomlconfig"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unpin",
+		Short: "Remove the state node pin and re-enable the automatic failover",
+		Run: func(cmd *cobra.Command, args []string) {
+			home := cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String()
+			rollerData, err := roller.LoadConfig(home)
+			if err != nil {
+				pterm.Error.Println("failed to load roller config file", err)
+				return
+			}
+
+			if rollerData.DA.PinnedStateNode == "" {
+				pterm.Info.Println("no state node is pinned")
+				return
+			}
+
+			err = tomlconfig.RemoveFieldFromFile(
+				roller.GetConfigPath(home),
+				"DA.pinned_state_node",
+			)
+			if err != nil {
+				pterm.Error.Println("failed to unpin state node:", err)
+				return
+			}
+
+			pterm.Success.Printf(
+				"💈 State node %s unpinned, automatic failover is enabled\n",
+				rollerData.DA.PinnedStateNode,
+			)
+		},
+	}
+
+	return cmd
+}
@@ -4,8 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
-	"path/filepath"
 
 	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
@@ -15,8 +13,8 @@ import (
 	datalayer "github.com/dymensionxyz/roller/data_layer"
 	"github.com/dymensionxyz/roller/utils/bash"
 	"github.com/dymensionxyz/roller/utils/errorhandling"
-	"github.com/dymensionxyz/roller/utils/filesystem"
 	"github.com/dymensionxyz/roller/utils/keys"
+	"github.com/dymensionxyz/roller/utils/plan"
 	"github.com/dymensionxyz/roller/utils/roller"
 )
 
@@ -44,12 +42,15 @@ func Cmd() *cobra.Command {
 					errors.New("metrics endpoint can only be set for celestia"),
 				)
 			}
-			damanager := datalayer.NewDAManager(
+			damanager, err := datalayer.NewDAManager(
 				rollerData.DA.Backend,
 				rollerData.Home,
 				rollerData.KeyringBackend,
 				rollerData.NodeType,
 			)
+			if err != nil {
+				errorhandling.PrettifyErrorIfExists(err)
+			}
 
 			if rollerData.NodeType == "sequencer" {
 				pterm.Info.Println("checking for da address balance")
@@ -78,17 +79,10 @@ func Cmd() *cobra.Command {
 
 			fmt.Println(startDALCCmd.String())
 			done := make(chan error, 1)
-			if rollerData.KeyringBackend == consts.SupportedKeyringBackends.OS {
-				pswFileName, err := filesystem.GetOsKeyringPswFileName(consts.Executables.Celestia)
-				if err != nil {
-					pterm.Error.Println("failed to get os keyring password file name: ", err)
-					return
-				}
-
-				fp := filepath.Join(home, string(pswFileName))
-				psw, err := filesystem.ReadFromFile(fp)
+			if rollerData.KeyringBackend.RequiresPassphrase() {
+				psw, err := keys.ReadKeyringPassphrase(home, consts.Executables.Celestia)
 				if err != nil {
-					pterm.Error.Println("failed to read os keyring password file: ", err)
+					pterm.Error.Println("failed to read keyring passphrase: ", err)
 					return
 				}
 
@@ -115,7 +109,7 @@ func Cmd() *cobra.Command {
 				case err := <-done:
 					if err != nil {
 						pterm.Error.Println("da process returned an error: ", err)
-						os.Exit(1)
+						plan.Exit(1)
 					}
 				case <-ctx.Done():
 					pterm.Error.Println("context cancelled, terminating command")
@@ -140,7 +134,7 @@ func Cmd() *cobra.Command {
 				case err := <-done:
 					if err != nil {
 						pterm.Error.Println("da process returned an error: ", err)
-						os.Exit(1)
+						plan.Exit(1)
 					}
 				case <-ctx.Done():
 					pterm.Error.Println("context cancelled, terminating command")
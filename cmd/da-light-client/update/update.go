@@ -7,9 +7,9 @@ import (
 	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
 	"github.com/dymensionxyz/roller/utils/dependencies"
 	"github.com/dymensionxyz/roller/utils/filesystem"
-	"github.com/dymensionxyz/roller/utils/firebase"
 	"github.com/dymensionxyz/roller/utils/roller"
 	servicemanager "github.com/dymensionxyz/roller/utils/service_manager"
+	"github.com/dymensionxyz/roller/utils/versions"
 )
 
 const (
@@ -44,7 +44,7 @@ func Cmd() *cobra.Command {
 				return
 			}
 
-			bvi, err := firebase.GetDependencyVersions(localRollerConfig.HubData.Environment)
+			bvi, err := versions.GetDependencyVersions(localRollerConfig.HubData.Environment)
 			if err != nil {
 				pterm.Error.Println("failed to get dependency versions: ", err)
 				return
@@ -0,0 +1,412 @@
+package doctor
+
+import (
+	"fmt"
+	"maps"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	datalayer "github.com/dymensionxyz/roller/data_layer"
+	"github.com/dymensionxyz/roller/sequencer"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	genesisutils "github.com/dymensionxyz/roller/utils/genesis"
+	"github.com/dymensionxyz/roller/utils/keys"
+	"github.com/dymensionxyz/roller/utils/migrations"
+	"github.com/dymensionxyz/roller/utils/roller"
+	sequencerutils "github.com/dymensionxyz/roller/utils/sequencer"
+	servicemanager "github.com/dymensionxyz/roller/utils/service_manager"
+)
+
+const (
+	gib = 1 << 30
+	// below minFreeDiskFail the node is about to run out of space, below
+	// minFreeDiskWarn it will soon
+	minFreeDiskFail = 10 * gib
+	minFreeDiskWarn = 50 * gib
+
+	endpointTimeout = 5 * time.Second
+)
+
+// check is a single diagnostic, it can report more than one result, e.g.
+// one per port
+type check struct {
+	name string
+	// remote checks need network access and are skipped with --offline
+	remote bool
+	run    func(rlpCfg roller.RollappConfig) []Result
+}
+
+func checks() []check {
+	return []check{
+		{name: "config version", run: checkConfigVersion},
+		{name: "binaries", run: checkBinaries},
+		{name: "services", run: checkServices},
+		{name: "ports", run: checkPorts},
+		{name: "disk space", run: checkDiskSpace},
+		{name: "genesis", remote: true, run: checkGenesis},
+		{name: "balances", remote: true, run: checkBalances},
+		{name: "endpoints", remote: true, run: checkEndpoints},
+	}
+}
+
+func pass(name, msg string) Result {
+	return Result{Check: name, Status: StatusPass, Message: msg}
+}
+
+func warn(name, msg, fix string) Result {
+	return Result{Check: name, Status: StatusWarn, Message: msg, Fix: fix}
+}
+
+func fail(name, msg, fix string) Result {
+	return Result{Check: name, Status: StatusFail, Message: msg, Fix: fix}
+}
+
+func checkConfig(home string) (roller.RollappConfig, Result) {
+	rlpCfg, err := roller.LoadConfig(home)
+	if err != nil {
+		return rlpCfg, fail(
+			"config",
+			fmt.Sprintf("failed to load %s: %v", roller.GetConfigPath(home), err),
+			"initialize the rollapp with 'roller rollapp init' or pass the right --home",
+		)
+	}
+
+	if err := rlpCfg.ValidateConfig(); err != nil {
+		return rlpCfg, fail(
+			"config",
+			fmt.Sprintf("invalid roller config: %v", err),
+			"fix the value in roller.toml with 'roller config set' or re-run 'roller rollapp init'",
+		)
+	}
+
+	return rlpCfg, pass("config", roller.GetConfigPath(home))
+}
+
+func checkConfigVersion(rlpCfg roller.RollappConfig) []Result {
+	if err := migrations.CheckRollerVersion(rlpCfg); err != nil {
+		return []Result{
+			fail("config version", err.Error(), "run 'roller rollapp migrate'"),
+		}
+	}
+
+	return []Result{pass("config version", rlpCfg.RollerVersion)}
+}
+
+func requiredBinaries(rlpCfg roller.RollappConfig) []string {
+	bins := []string{consts.Executables.RollappEVM}
+	if rlpCfg.HubData.ID != consts.MockHubID {
+		bins = append(bins, consts.Executables.Dymension)
+	}
+
+	if rlpCfg.DA.Backend == consts.Celestia {
+		bins = append(
+			bins,
+			consts.Executables.Celestia,
+			consts.Executables.CelKey,
+			consts.Executables.CelestiaApp,
+		)
+	}
+
+	if dirExists(filepath.Join(rlpCfg.Home, consts.ConfigDirName.Relayer)) {
+		bins = append(bins, consts.Executables.Relayer)
+	}
+
+	return bins
+}
+
+func checkBinaries(rlpCfg roller.RollappConfig) []Result {
+	var missing []string
+	for _, bin := range requiredBinaries(rlpCfg) {
+		if !filesystem.IsAvailable(bin) {
+			missing = append(missing, bin)
+		}
+	}
+
+	if len(missing) > 0 {
+		return []Result{
+			fail(
+				"binaries",
+				fmt.Sprintf("missing binaries: %s", strings.Join(missing, ", ")),
+				fmt.Sprintf("install them with 'roller binaries install %s'", rlpCfg.RollappID),
+			),
+		}
+	}
+
+	return []Result{pass("binaries", "all required binaries are installed")}
+}
+
+func expectedServices(rlpCfg roller.RollappConfig) []string {
+	services := slices.Clone(consts.RollappSystemdServices)
+	if rlpCfg.DA.Backend == consts.Celestia {
+		services = slices.Clone(consts.RollappWithCelesSystemdServices)
+	}
+
+	if dirExists(filepath.Join(rlpCfg.Home, consts.ConfigDirName.Relayer)) {
+		services = append(services, consts.RelayerSystemdServices...)
+	}
+
+	return services
+}
+
+func checkServices(rlpCfg roller.RollappConfig) []Result {
+	var results []Result
+	for _, svc := range expectedServices(rlpCfg) {
+		name := fmt.Sprintf("service %s", svc)
+		loadCmd := "roller rollapp services load"
+		if svc == "relayer" {
+			loadCmd = "roller relayer services load"
+		}
+
+		loaded, err := servicemanager.IsServiceLoaded(svc)
+		if err != nil {
+			results = append(results, warn(name, err.Error(), ""))
+			continue
+		}
+
+		if !loaded {
+			results = append(
+				results,
+				warn(name, "service is not loaded", fmt.Sprintf("run '%s'", loadCmd)),
+			)
+			continue
+		}
+
+		if !servicemanager.IsServiceActive(svc) {
+			results = append(
+				results,
+				warn(name, "service is loaded but not running", "start it with 'roller rollapp services start'"),
+			)
+			continue
+		}
+
+		results = append(results, pass(name, "loaded and running"))
+	}
+
+	return results
+}
+
+func checkPorts(rlpCfg roller.RollappConfig) []Result {
+	seq := &sequencer.Sequencer{RlpCfg: rlpCfg}
+	if err := seq.ReadPorts(); err != nil {
+		return []Result{
+			fail(
+				"ports",
+				fmt.Sprintf("failed to read the rollapp ports: %v", err),
+				"re-run 'roller rollapp init' to regenerate the rollapp config",
+			),
+		}
+	}
+
+	ports := map[string]string{
+		"rollapp rpc": seq.RPCPort,
+		"rollapp api": seq.APIPort,
+	}
+	if rlpCfg.RollappVMType == consts.EVM_ROLLAPP {
+		ports["rollapp json-rpc"] = seq.JsonRPCPort
+	}
+
+	// the ports are expected to be taken while the rollapp is running
+	rollappRunning := servicemanager.IsServiceActive("rollapp")
+
+	var results []Result
+	for _, name := range slices.Sorted(maps.Keys(ports)) {
+		port := ports[name]
+		checkName := fmt.Sprintf("port %s", name)
+
+		if isPortFree(port) {
+			results = append(results, pass(checkName, fmt.Sprintf("%s is available", port)))
+			continue
+		}
+
+		if rollappRunning {
+			results = append(results, pass(checkName, fmt.Sprintf("%s is used by the running rollapp", port)))
+			continue
+		}
+
+		results = append(
+			results,
+			fail(
+				checkName,
+				fmt.Sprintf("%s is already in use by another process", port),
+				fmt.Sprintf("stop the process listening on %s or change the port in the rollapp config.toml and app.toml", port),
+			),
+		)
+	}
+
+	return results
+}
+
+func checkDiskSpace(rlpCfg roller.RollappConfig) []Result {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(rlpCfg.Home, &stat); err != nil {
+		return []Result{warn("disk space", fmt.Sprintf("failed to check disk space: %v", err), "")}
+	}
+
+	// nolint:unconvert
+	free := uint64(stat.Bavail) * uint64(stat.Bsize)
+	msg := fmt.Sprintf("%.1f GiB free on %s", float64(free)/gib, rlpCfg.Home)
+	fix := "free up disk space or move the roller home to a larger volume"
+
+	switch {
+	case free < minFreeDiskFail:
+		return []Result{fail("disk space", msg, fix)}
+	case free < minFreeDiskWarn:
+		return []Result{warn("disk space", msg, fix)}
+	default:
+		return []Result{pass("disk space", msg)}
+	}
+}
+
+func checkGenesis(rlpCfg roller.RollappConfig) []Result {
+	if rlpCfg.HubData.ID == consts.MockHubID {
+		return []Result{pass("genesis", "skipped for the mock hub")}
+	}
+
+	err := genesisutils.ValidateGenesis(rlpCfg, rlpCfg.RollappID, rlpCfg.HubData)
+	if err != nil {
+		return []Result{
+			fail(
+				"genesis",
+				err.Error(),
+				"download the registered genesis by re-running 'roller rollapp init'",
+			),
+		}
+	}
+
+	return []Result{pass("genesis", "genesis matches the registered rollapp")}
+}
+
+func checkBalances(rlpCfg roller.RollappConfig) []Result {
+	if rlpCfg.HubData.ID == consts.MockHubID {
+		return []Result{pass("balances", "skipped for the mock hub")}
+	}
+
+	var notFunded []keys.NotFundedAddressData
+	var results []Result
+
+	if rlpCfg.NodeType == consts.NodeType.Sequencer {
+		seqNotFunded, err := sequencerutils.GetInsufficientBalances(rlpCfg)
+		if err != nil {
+			results = append(
+				results,
+				fail("balance sequencer", fmt.Sprintf("failed to query the sequencer balance: %v", err), "check the hub RPC endpoint"),
+			)
+		}
+		notFunded = append(notFunded, seqNotFunded...)
+	}
+
+	if rlpCfg.DA.Backend != consts.Local {
+		daManager, err := datalayer.NewDAManager(rlpCfg.DA.Backend, rlpCfg.Home, rlpCfg.KeyringBackend, rlpCfg.NodeType)
+		if err != nil {
+			return append(results, fail("balance da", err.Error(), "set a supported DA backend in roller.toml"))
+		}
+
+		daNotFunded, err := daManager.CheckDABalance()
+		if err != nil {
+			results = append(
+				results,
+				fail("balance da", fmt.Sprintf("failed to query the DA balance: %v", err), "check the DA RPC endpoint"),
+			)
+		}
+		notFunded = append(notFunded, daNotFunded...)
+	}
+
+	for _, nf := range notFunded {
+		results = append(
+			results,
+			fail(
+				fmt.Sprintf("balance %s", nf.KeyName),
+				fmt.Sprintf(
+					"%s has %s%s, %s%s is required",
+					nf.Address,
+					nf.CurrentBalance.String(),
+					nf.Denom,
+					nf.RequiredBalance.String(),
+					nf.Denom,
+				),
+				fmt.Sprintf("fund %s on %s", nf.Address, nf.Network),
+			),
+		)
+	}
+
+	if len(results) == 0 {
+		return []Result{pass("balances", "all addresses are funded")}
+	}
+
+	return results
+}
+
+func checkEndpoints(rlpCfg roller.RollappConfig) []Result {
+	var results []Result
+
+	if rlpCfg.HubData.ID != consts.MockHubID {
+		results = append(
+			results,
+			checkEndpoint("hub rpc", rlpCfg.HubData.RpcUrl, true, "set a working hub RPC with 'roller config set hub-rpc-endpoint <url>'"),
+		)
+	}
+
+	if rlpCfg.DA.Backend != consts.Local && rlpCfg.DA.RpcUrl != "" {
+		results = append(
+			results,
+			checkEndpoint("da rpc", rlpCfg.DA.RpcUrl, true, "set a working DA RPC with 'roller config set da-rpc <url>'"),
+		)
+	}
+
+	seq := &sequencer.Sequencer{RlpCfg: rlpCfg}
+	if err := seq.ReadPorts(); err == nil {
+		// the local rollapp is only expected to answer while it is running
+		results = append(
+			results,
+			checkEndpoint("rollapp rpc", seq.GetRPCEndpoint(), false, "start the rollapp with 'roller rollapp services start'"),
+		)
+	}
+
+	return results
+}
+
+func checkEndpoint(name, endpoint string, required bool, fix string) Result {
+	checkName := fmt.Sprintf("endpoint %s", name)
+	if endpoint == "" {
+		return fail(checkName, "no endpoint configured", fix)
+	}
+
+	client := http.Client{Timeout: endpointTimeout}
+	// nolint:gosec
+	resp, err := client.Get(endpoint)
+	if err != nil {
+		msg := fmt.Sprintf("%s is unreachable: %v", endpoint, err)
+		if !required {
+			return warn(checkName, msg, fix)
+		}
+		return fail(checkName, msg, fix)
+	}
+	// nolint:errcheck
+	defer resp.Body.Close()
+
+	// any HTTP answer means the endpoint is reachable, JSON-RPC endpoints
+	// reject plain GET requests
+	return pass(checkName, fmt.Sprintf("%s is reachable", endpoint))
+}
+
+func isPortFree(port string) bool {
+	l, err := net.Listen("tcp", net.JoinHostPort("", port))
+	if err != nil {
+		return false
+	}
+	// nolint:errcheck
+	l.Close()
+	return true
+}
+
+func dirExists(path string) bool {
+	fi, err := os.Stat(path)
+	return err == nil && fi.IsDir()
+}
@@ -0,0 +1,145 @@
+package doctor
+
+import (
+	"fmt"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/output"
+)
+
+type Status string
+
+const (
+	StatusPass Status = "pass"
+	StatusWarn Status = "warn"
+	StatusFail Status = "fail"
+)
+
+type Result struct {
+	Check   string `json:"check" yaml:"check"`
+	Status  Status `json:"status" yaml:"status"`
+	Message string `json:"message" yaml:"message"`
+	Fix     string `json:"fix,omitempty" yaml:"fix,omitempty"`
+}
+
+type Report struct {
+	Results []Result `json:"results" yaml:"results"`
+	Passed  int      `json:"passed" yaml:"passed"`
+	Warned  int      `json:"warned" yaml:"warned"`
+	Failed  int      `json:"failed" yaml:"failed"`
+}
+
+func (r *Report) add(results ...Result) {
+	for _, res := range results {
+		switch res.Status {
+		case StatusPass:
+			r.Passed++
+		case StatusWarn:
+			r.Warned++
+		case StatusFail:
+			r.Failed++
+		}
+		r.Results = append(r.Results, res)
+	}
+}
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Run preflight diagnostics for the node on the local machine",
+		Long: `Run preflight diagnostics for the node on the local machine.
+
+Checks the roller config and its version, the installed binaries, the system
+services, port conflicts, disk space, the genesis, the address balances and the
+reachability of the hub, DA and rollapp RPC endpoints. Every check reports
+pass, warn or fail with a suggested fix.
+
+The command exits with a non-zero code when any check fails.
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				return fmt.Errorf("failed to expand home directory: %w", err)
+			}
+
+			format, err := initconfig.GetOutputFormat(cmd)
+			if err != nil {
+				return err
+			}
+
+			offline, _ := cmd.Flags().GetBool("offline")
+			report := run(home, offline)
+
+			if format.IsStructured() {
+				if err := output.Print(format, report); err != nil {
+					return err
+				}
+			} else {
+				printReport(report)
+			}
+
+			if report.Failed > 0 {
+				return fmt.Errorf("%d checks failed", report.Failed)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool("offline", false, "skip the checks that need network access")
+
+	return cmd
+}
+
+func run(home string, offline bool) Report {
+	var report Report
+
+	rlpCfg, res := checkConfig(home)
+	report.add(res)
+	if res.Status == StatusFail {
+		return report
+	}
+
+	for _, c := range checks() {
+		if offline && c.remote {
+			continue
+		}
+		report.add(c.run(rlpCfg)...)
+	}
+
+	return report
+}
+
+func printReport(report Report) {
+	for _, res := range report.Results {
+		var status string
+		switch res.Status {
+		case StatusPass:
+			status = pterm.Green("PASS")
+		case StatusWarn:
+			status = pterm.Yellow("WARN")
+		case StatusFail:
+			status = pterm.Red("FAIL")
+		}
+
+		fmt.Printf("%s  %-24s %s\n", status, res.Check, res.Message)
+		if res.Fix != "" && res.Status != StatusPass {
+			fmt.Printf("      %-24s fix: %s\n", "", res.Fix)
+		}
+	}
+
+	fmt.Println()
+	fmt.Printf(
+		"💈 %d passed, %d warnings, %d failed\n",
+		report.Passed,
+		report.Warned,
+		report.Failed,
+	)
+}
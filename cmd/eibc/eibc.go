@@ -5,6 +5,9 @@ import (
 
 	"github.com/dymensionxyz/roller/cmd/eibc/fulfill"
 	eibcinit "github.com/dymensionxyz/roller/cmd/eibc/init"
+	"github.com/dymensionxyz/roller/cmd/eibc/orders"
+	"github.com/dymensionxyz/roller/cmd/eibc/policy"
+	"github.com/dymensionxyz/roller/cmd/eibc/report"
 	"github.com/dymensionxyz/roller/cmd/eibc/scale"
 	"github.com/dymensionxyz/roller/cmd/eibc/start"
 	"github.com/dymensionxyz/roller/cmd/eibc/update"
@@ -26,6 +29,9 @@ func Cmd() *cobra.Command {
 	cmd.AddCommand(update.Cmd())
 	cmd.AddCommand(scale.Cmd())
 	cmd.AddCommand(fulfill.Cmd())
+	cmd.AddCommand(orders.Cmd())
+	cmd.AddCommand(policy.Cmd())
+	cmd.AddCommand(report.Cmd())
 
 	sl := []string{"eibc"}
 	cmd.AddCommand(
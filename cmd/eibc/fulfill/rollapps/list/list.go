@@ -4,43 +4,70 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 
-	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
 	"github.com/dymensionxyz/roller/cmd/consts"
 	eibcutils "github.com/dymensionxyz/roller/utils/eibc"
 	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/output"
 )
 
+type rollappOutput struct {
+	RollappID        string   `json:"rollapp_id" yaml:"rollapp_id"`
+	MinConfirmations string   `json:"min_confirmations" yaml:"min_confirmations"`
+	FullNodes        []string `json:"full_nodes" yaml:"full_nodes"`
+}
+
 func Cmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "Commands to manage the whitelist of RollApps to fulfill eibc orders for",
-		Run: func(cmd *cobra.Command, args []string) {
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			format, err := initconfig.GetOutputFormat(cmd)
+			if err != nil {
+				return err
+			}
+
 			home, err := os.UserHomeDir()
 			if err != nil {
-				pterm.Error.Println("failed to get user home dir", err)
-				return
+				return fmt.Errorf("failed to get user home dir: %w", err)
 			}
 
 			eibcHome := filepath.Join(home, consts.ConfigDirName.Eibc)
 			eibcConfigPath := filepath.Join(eibcHome, "config.yaml")
 			isEibcClientInitialized, err := filesystem.DirNotEmpty(eibcHome)
 			if err != nil {
-				pterm.Error.Println("failed to check eibc client initialized", err)
-				return
+				return fmt.Errorf("failed to check eibc client initialized: %w", err)
 			}
 
 			if !isEibcClientInitialized {
-				pterm.Error.Println("eibc client not initialized")
-				return
+				return fmt.Errorf("eibc client not initialized")
 			}
 
 			config, err := eibcutils.ReadConfig(eibcConfigPath)
 			if err != nil {
-				pterm.Error.Println("failed to read eibc config", err)
-				return
+				return fmt.Errorf("failed to read eibc config: %w", err)
+			}
+
+			if format.IsStructured() {
+				rollapps := make([]rollappOutput, 0, len(config.Rollapps))
+				for k, v := range config.Rollapps {
+					rollapps = append(rollapps, rollappOutput{
+						RollappID:        k,
+						MinConfirmations: v.MinConfirmations,
+						FullNodes:        v.FullNodes,
+					})
+				}
+				sort.Slice(rollapps, func(i, j int) bool {
+					return rollapps[i].RollappID < rollapps[j].RollappID
+				})
+
+				return output.Print(format, rollapps)
 			}
 
 			for k, v := range config.Rollapps {
@@ -49,6 +76,7 @@ func Cmd() *cobra.Command {
 					fmt.Printf("\t%s\n", v)
 				}
 			}
+			return nil
 		},
 	}
 
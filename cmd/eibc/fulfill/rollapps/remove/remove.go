@@ -12,6 +12,7 @@ import (
 	"github.com/dymensionxyz/roller/utils/eibc"
 	eibcutils "github.com/dymensionxyz/roller/utils/eibc"
 	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/plan"
 )
 
 func Cmd() *cobra.Command {
@@ -39,7 +40,7 @@ func Cmd() *cobra.Command {
 			}
 
 			eibcConfigPath := filepath.Join(eibcHome, "config.yaml")
-			data, err := os.ReadFile(eibcConfigPath)
+			data, err := plan.ReadFile(eibcConfigPath)
 			if err != nil {
 				pterm.Error.Printf("Error reading file: %v\n", err)
 				return
@@ -63,7 +64,7 @@ func Cmd() *cobra.Command {
 				return
 			}
 
-			err = os.WriteFile(eibcConfigPath, updatedData, 0o644)
+			err = plan.WriteFile(eibcConfigPath, updatedData, 0o644)
 			if err != nil {
 				pterm.Error.Printf("Error reading file: %v\n", err)
 				return
@@ -14,7 +14,6 @@ import (
 
 	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 	"gopkg.in/yaml.v3"
 
 	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
@@ -26,6 +25,7 @@ import (
 	eibcutils "github.com/dymensionxyz/roller/utils/eibc"
 	"github.com/dymensionxyz/roller/utils/filesystem"
 	"github.com/dymensionxyz/roller/utils/keys"
+	"github.com/dymensionxyz/roller/utils/plan"
 	"github.com/dymensionxyz/roller/utils/rollapp"
 	"github.com/dymensionxyz/roller/utils/roller"
 	sequencerutils "github.com/dymensionxyz/roller/utils/sequencer"
@@ -173,6 +173,10 @@ func Cmd() *cobra.Command {
 					}
 
 					if !balance.Amount.IsPositive() {
+						if plan.Enabled() {
+							plan.Record(plan.KindCommand, "fund the eibc operator %s", ki.Address)
+							break
+						}
 						pterm.Info.Println(
 							"please fund the addresses below to run the eibc client. this address will be the operator address of the client.",
 						)
@@ -283,6 +287,8 @@ func Cmd() *cobra.Command {
 			}()
 		},
 	}
+	initconfig.AddDryRunFlag(cmd)
+
 	return cmd
 }
 
@@ -452,7 +458,7 @@ func initializeEibcForEnvironment() (consts.HubData, error) {
 		hd = rollerConfig.HubData
 
 		rollerCfgDir := roller.GetRootDir()
-		err := os.MkdirAll(rollerCfgDir, 0o755)
+		err := plan.MkdirAll(rollerCfgDir, 0o755)
 		if err != nil {
 			pterm.Error.Println("failed to create roller config dir", err)
 			return consts.HubData{}, err
@@ -493,7 +499,7 @@ func removeDefaultEibcChain(
 		return err
 	}
 
-	err = os.WriteFile(eibcConfigPath, updatedData, 0o644)
+	err = plan.WriteFile(eibcConfigPath, updatedData, 0o644)
 	if err != nil {
 		pterm.Error.Println("failed to write eibc config file: ", err)
 		return err
@@ -665,7 +671,7 @@ func createPolicyFile(eibcHome string) error {
 		return err
 	}
 
-	err = os.WriteFile(tmplDst, t, 0o644)
+	err = plan.WriteFile(tmplDst, t, 0o644)
 	if err != nil {
 		pterm.Error.Printfln("failed to export template")
 		return err
@@ -703,21 +709,16 @@ func printPolicyAddress(policyAddr string) {
 }
 
 func createHubDataConfigFile(hdConfigPath string, hd consts.HubData) error {
-	_, err := os.Create(hdConfigPath)
+	b, err := yaml.Marshal(map[string]string{
+		"rpc_url": hd.RpcUrl,
+		"id":      hd.ID,
+	})
 	if err != nil {
 		return err
 	}
 
-	v := viper.New()
-
-	v.Set("rpc_url", hd.RpcUrl)
-	v.Set("id", hd.ID)
-
-	v.SetConfigFile(hdConfigPath)
-	v.SetConfigType("yaml")
-
-	err = v.WriteConfig()
-	if err != nil {
+	// nolint:gofumpt
+	if err := plan.WriteFile(hdConfigPath, b, 0o644); err != nil {
 		pterm.Error.Println("failed to write config", err)
 		return err
 	}
@@ -0,0 +1,262 @@
+// Package book holds the order book helpers shared by the eibc orders
+// commands
+package book
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/bash"
+	eibcutils "github.com/dymensionxyz/roller/utils/eibc"
+	"github.com/dymensionxyz/roller/utils/tx"
+)
+
+const (
+	flagRollapp    = "rollapp-id"
+	flagDenom      = "denom"
+	flagMinFee     = "min-fee"
+	flagMinFeeRate = "min-fee-rate"
+	flagMinAge     = "min-age"
+	flagMaxAge     = "max-age"
+	flagSource     = "source"
+
+	sourceAll = "all"
+)
+
+// Book is the eibc client configuration and hub the orders are fetched from
+type Book struct {
+	Home   string
+	Config eibcutils.Config
+	Hub    consts.HubData
+	Filter eibcutils.OrderFilter
+	Source string
+}
+
+// Order is a demand order along with the profit of fulfilling it
+type Order struct {
+	eibcutils.DemandOrder `yaml:",inline"`
+	FeeRate               float64               `json:"fee_rate" yaml:"fee_rate"`
+	Profit                eibcutils.OrderProfit `json:"profit" yaml:"profit"`
+}
+
+func AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSlice(flagRollapp, nil, "only show the orders of these rollapps")
+	cmd.Flags().StringSlice(flagDenom, nil, "only show the orders of these denoms")
+	cmd.Flags().String(flagMinFee, "", "minimum fee, in base denom")
+	cmd.Flags().Float64(flagMinFeeRate, 0, "minimum fee relative to the order amount, e.g. 0.001")
+	cmd.Flags().Duration(flagMinAge, 0, "only show orders older than this")
+	cmd.Flags().Duration(flagMaxAge, 0, "only show orders younger than this")
+	cmd.Flags().String(
+		flagSource,
+		sourceAll,
+		fmt.Sprintf("where to fetch the orders from, one of %s, %s or %s",
+			eibcutils.OrderSourceHub, eibcutils.OrderSourceIndexer, sourceAll),
+	)
+}
+
+// Load reads the eibc client configuration and the order filter flags
+func Load(cmd *cobra.Command) (*Book, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+
+	b := &Book{Home: home}
+	eibcConfigPath := filepath.Join(home, consts.ConfigDirName.Eibc, "config.yaml")
+	hd, err := b.Config.HubDataFromHubRpc(eibcConfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the eibc client config, run 'roller eibc init' first: %w", err)
+	}
+	b.Hub = *hd
+
+	b.Filter.Rollapps, _ = cmd.Flags().GetStringSlice(flagRollapp)
+	b.Filter.Denoms, _ = cmd.Flags().GetStringSlice(flagDenom)
+	b.Filter.MinFeeRate, _ = cmd.Flags().GetFloat64(flagMinFeeRate)
+	b.Filter.MinAge, _ = cmd.Flags().GetDuration(flagMinAge)
+	b.Filter.MaxAge, _ = cmd.Flags().GetDuration(flagMaxAge)
+	if minFee, _ := cmd.Flags().GetString(flagMinFee); minFee != "" {
+		v, ok := new(big.Int).SetString(minFee, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid minimum fee %q", minFee)
+		}
+		b.Filter.MinFee = v
+	}
+
+	b.Source, _ = cmd.Flags().GetString(flagSource)
+	switch b.Source {
+	case sourceAll, eibcutils.OrderSourceHub:
+	case eibcutils.OrderSourceIndexer:
+		if b.Config.OrderPolling.IndexerURL == "" {
+			return nil, fmt.Errorf("no indexer url is set in order_polling.indexer_url of the eibc client config")
+		}
+	default:
+		return nil, fmt.Errorf("invalid source %q", b.Source)
+	}
+
+	return b, nil
+}
+
+// Fetch returns the pending orders that match the filter, sorted by
+// descending liquidity provider profit. A failing source is reported as a
+// warning as long as another one succeeded. When the hub was queried, the
+// orders only the indexer lists are dropped, as they are no longer pending
+func (b *Book) Fetch() ([]Order, error) {
+	var lists [][]eibcutils.DemandOrder
+	var errs []string
+	hubQueried := false
+
+	if b.Source != eibcutils.OrderSourceIndexer {
+		orders, err := eibcutils.QueryHubDemandOrders(b.Hub)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("hub: %v", err))
+		} else {
+			lists = append(lists, orders)
+			hubQueried = true
+		}
+	}
+	if b.Source != eibcutils.OrderSourceHub && b.Config.OrderPolling.IndexerURL != "" {
+		orders, err := eibcutils.QueryIndexerDemandOrders(b.Config.OrderPolling.IndexerURL, b.Hub.ID)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("indexer: %v", err))
+		} else {
+			lists = append(lists, orders)
+		}
+	}
+
+	if len(lists) == 0 {
+		return nil, fmt.Errorf("failed to fetch demand orders: %s", strings.Join(errs, "; "))
+	}
+	for _, e := range errs {
+		pterm.Warning.Println("failed to fetch demand orders from", e)
+	}
+
+	orders := eibcutils.MergeDemandOrders(lists...)
+	if hubQueried {
+		orders = slices.DeleteFunc(orders, func(o eibcutils.DemandOrder) bool {
+			return !o.OnHub()
+		})
+	}
+	if b.Filter.MinAge > 0 || b.Filter.MaxAge > 0 {
+		if err := eibcutils.EstimateCreationTimes(orders, b.Hub); err != nil {
+			pterm.Warning.Println("failed to estimate the age of the orders:", err)
+		}
+	}
+
+	now := time.Now()
+	minFeeShare := b.Config.OperatorConfig.MinFeeShare
+	eibcutils.SortByLPProfit(orders, minFeeShare)
+
+	matched := []Order{}
+	for _, o := range orders {
+		if !b.Filter.Match(o, now) {
+			continue
+		}
+		matched = append(matched, Order{DemandOrder: o, FeeRate: o.FeeRate(), Profit: o.Profit(minFeeShare)})
+	}
+
+	return matched, nil
+}
+
+// Render prints the orders as a table
+func (b *Book) Render(orders []Order) {
+	if len(orders) == 0 {
+		pterm.Info.Println("no pending orders match the filter")
+		return
+	}
+
+	data := pterm.TableData{
+		{"ID", "ROLLAPP", "DENOM", "AMOUNT", "FEE", "FEE %", "OPERATOR", "LP PROFIT", "AGE", "SOURCES"},
+	}
+	for _, o := range orders {
+		data = append(data, []string{
+			o.ID,
+			o.RollappID,
+			o.Denom,
+			o.Price.String(),
+			o.Fee.String(),
+			fmt.Sprintf("%.3f", o.FeeRate*100),
+			o.Profit.Operator.String(),
+			o.Profit.LP.String(),
+			Age(o.DemandOrder),
+			strings.Join(o.Sources, ","),
+		})
+	}
+
+	// nolint: errcheck
+	pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+	fmt.Printf(
+		"operator min fee share: %.2f%%, LP profit is the fee left after the operator share\n",
+		b.Config.OperatorConfig.MinFeeShare*100,
+	)
+}
+
+func Age(o eibcutils.DemandOrder) string {
+	if o.CreatedAt.IsZero() {
+		return "-"
+	}
+	return time.Since(o.CreatedAt).Round(time.Second).String()
+}
+
+// FulfillResult is the outcome of the fulfillment of an order
+type FulfillResult struct {
+	ID     string `json:"id" yaml:"id"`
+	TxHash string `json:"tx_hash,omitempty" yaml:"tx_hash,omitempty"`
+	Error  string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// Fulfill fulfills the orders one by one with the eibc client account, a
+// failed order does not stop the batch. Orders the hub did not list are not
+// fulfilled
+func (b *Book) Fulfill(orders []Order) []FulfillResult {
+	results := make([]FulfillResult, 0, len(orders))
+
+	for i, o := range orders {
+		pterm.Info.Printfln("[%d/%d] fulfilling order %s", i+1, len(orders), o.ID)
+		r := FulfillResult{ID: o.ID}
+
+		var txHash string
+		err := fmt.Errorf("order is not listed by the hub, it may no longer be pending")
+		if o.OnHub() {
+			txHash, err = b.fulfill(o)
+		}
+		r.TxHash = txHash
+		if err != nil {
+			r.Error = err.Error()
+			pterm.Error.Printfln("[%d/%d] failed to fulfill order %s: %v", i+1, len(orders), o.ID, err)
+		} else {
+			pterm.Success.Printfln("[%d/%d] order %s fulfilled in %s", i+1, len(orders), o.ID, txHash)
+		}
+		results = append(results, r)
+	}
+
+	return results
+}
+
+func (b *Book) fulfill(o Order) (string, error) {
+	gCmd, err := eibcutils.GetFulfillOrderCmd(o.ID, o.Fee.String(), b.Hub)
+	if err != nil {
+		return "", err
+	}
+	gCmd.Args = append(gCmd.Args, "--yes")
+
+	out, err := bash.ExecCommandWithStdout(gCmd)
+	if err != nil {
+		return "", err
+	}
+
+	txHash, err := bash.ExtractTxHash(out.String())
+	if err != nil {
+		return "", err
+	}
+
+	return txHash, tx.MonitorTransaction(b.Hub.RpcUrl, txHash)
+}
@@ -0,0 +1,131 @@
+package list
+
+import (
+	"fmt"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/cmd/eibc/orders/book"
+	"github.com/dymensionxyz/roller/utils/output"
+)
+
+const (
+	flagFulfill = "fulfill"
+	flagYes     = "yes"
+	flagLimit   = "limit"
+)
+
+type result struct {
+	Orders    []book.Order         `json:"orders" yaml:"orders"`
+	Fulfilled []book.FulfillResult `json:"fulfilled,omitempty" yaml:"fulfilled,omitempty"`
+}
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the pending demand orders, optionally fulfilling a selection of them",
+		Long: `List the pending demand orders from the hub and from the indexer set in the
+eibc client order polling config, sorted by the profit left to the liquidity
+provider after the operator min fee share.
+
+With --fulfill, the orders to fulfill are selected interactively, or all the
+listed orders are fulfilled when --yes is set.
+`,
+		Example: `  roller eibc orders list --rollapp-id myrollapp_1234-1 --min-fee-rate 0.002
+  roller eibc orders list --denom adym --max-age 1h --fulfill`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			format, err := initconfig.GetOutputFormat(cmd)
+			if err != nil {
+				return err
+			}
+
+			b, err := book.Load(cmd)
+			if err != nil {
+				return err
+			}
+
+			orders, err := b.Fetch()
+			if err != nil {
+				return err
+			}
+			if limit, _ := cmd.Flags().GetInt(flagLimit); limit > 0 && len(orders) > limit {
+				orders = orders[:limit]
+			}
+
+			res := result{Orders: orders}
+			if !format.IsStructured() {
+				b.Render(orders)
+			}
+
+			fulfill, _ := cmd.Flags().GetBool(flagFulfill)
+			if fulfill && len(orders) > 0 {
+				yes, _ := cmd.Flags().GetBool(flagYes)
+				selected := orders
+				if !yes {
+					if format.IsStructured() {
+						return fmt.Errorf("--yes is required to fulfill orders with structured output")
+					}
+					selected = selectOrders(orders)
+				}
+
+				if len(selected) > 0 {
+					res.Fulfilled = b.Fulfill(selected)
+				}
+			}
+
+			if format.IsStructured() {
+				return output.Print(format, res)
+			}
+
+			failed := 0
+			for _, r := range res.Fulfilled {
+				if r.Error != "" {
+					failed++
+				}
+			}
+			if len(res.Fulfilled) > 0 {
+				pterm.Info.Printfln("%d of %d orders fulfilled", len(res.Fulfilled)-failed, len(res.Fulfilled))
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d orders failed to fulfill", failed)
+			}
+			return nil
+		},
+	}
+
+	book.AddFlags(cmd)
+	cmd.Flags().Bool(flagFulfill, false, "fulfill a selection of the listed orders")
+	cmd.Flags().BoolP(flagYes, "y", false, "fulfill all the listed orders without prompting")
+	cmd.Flags().Int(flagLimit, 0, "maximum number of orders to list")
+
+	return cmd
+}
+
+func selectOrders(orders []book.Order) []book.Order {
+	options := make([]string, 0, len(orders))
+	byOption := map[string]book.Order{}
+	for _, o := range orders {
+		opt := fmt.Sprintf(
+			"%s  %s %s%s fee %s (%.3f%%)",
+			o.ID, o.RollappID, o.Price, o.Denom, o.Fee, o.FeeRate*100,
+		)
+		options = append(options, opt)
+		byOption[opt] = o
+	}
+
+	chosen, _ := pterm.DefaultInteractiveMultiselect.
+		WithDefaultText("select the orders to fulfill").
+		WithOptions(options).
+		WithMaxHeight(15).
+		Show()
+
+	selected := make([]book.Order, 0, len(chosen))
+	for _, c := range chosen {
+		selected = append(selected, byOption[c])
+	}
+	return selected
+}
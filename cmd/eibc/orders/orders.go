@@ -0,0 +1,20 @@
+package orders
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/dymensionxyz/roller/cmd/eibc/orders/list"
+	"github.com/dymensionxyz/roller/cmd/eibc/orders/watch"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "orders",
+		Short: "Commands to browse and fulfill pending eibc demand orders",
+	}
+
+	cmd.AddCommand(list.Cmd())
+	cmd.AddCommand(watch.Cmd())
+
+	return cmd
+}
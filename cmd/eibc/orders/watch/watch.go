@@ -0,0 +1,146 @@
+package watch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/cmd/eibc/orders/book"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/notify"
+	"github.com/dymensionxyz/roller/utils/output"
+)
+
+const (
+	flagInterval = "interval"
+	flagNotify   = "notify"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Watch the pending demand orders and print the new ones that match the filter",
+		Long: `Watch the pending demand orders and print the new ones that match the filter.
+
+With --output json, every new order is printed as a single line JSON object.
+With --notify, the new orders found after the first poll are also sent to the
+notification channels of roller.toml with the eibc source and info severity.
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			format, err := initconfig.GetOutputFormat(cmd)
+			if err != nil {
+				return err
+			}
+			if format == output.FormatYAML {
+				return fmt.Errorf("watch supports the text and json output formats only")
+			}
+			interval, _ := cmd.Flags().GetDuration(flagInterval)
+			if interval <= 0 {
+				return fmt.Errorf("interval must be positive")
+			}
+
+			b, err := book.Load(cmd)
+			if err != nil {
+				return err
+			}
+
+			var notifier *notify.Dispatcher
+			if n, _ := cmd.Flags().GetBool(flagNotify); n {
+				home, err := filesystem.ExpandHomePath(cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String())
+				if err != nil {
+					return err
+				}
+				notifier, err = notify.Load(home)
+				if err != nil {
+					return err
+				}
+			}
+
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer cancel()
+
+			seen := map[string]bool{}
+			first := true
+			for {
+				orders, err := b.Fetch()
+				if err != nil {
+					pterm.Warning.Println(err)
+				}
+
+				var fresh []book.Order
+				for _, o := range orders {
+					if !seen[o.ID] {
+						seen[o.ID] = true
+						fresh = append(fresh, o)
+					}
+				}
+
+				switch {
+				case format == output.FormatJSON:
+					for _, o := range fresh {
+						line, err := json.Marshal(o)
+						if err != nil {
+							return err
+						}
+						fmt.Println(string(line))
+					}
+				case first:
+					b.Render(fresh)
+					pterm.Info.Printfln("watching for new orders every %s, press Ctrl-C to stop", interval)
+				default:
+					for _, o := range fresh {
+						pterm.Info.Printfln(
+							"new order %s on %s: %s%s, fee %s (%.3f%%), LP profit %s",
+							o.ID, o.RollappID, o.Price, o.Denom, o.Fee, o.FeeRate*100, o.Profit.LP,
+						)
+					}
+				}
+				if !first {
+					notifyOrders(notifier, fresh)
+				}
+				first = false
+
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-time.After(interval):
+				}
+			}
+		},
+	}
+
+	book.AddFlags(cmd)
+	cmd.Flags().Duration(flagInterval, 10*time.Second, "time between two polls")
+	cmd.Flags().Bool(flagNotify, false, "send the new orders to the notification channels")
+
+	return cmd
+}
+
+func notifyOrders(notifier *notify.Dispatcher, orders []book.Order) {
+	for _, o := range orders {
+		err := notifier.Emit(notify.Event{
+			Source:   notify.SourceEibc,
+			Check:    "order/" + o.ID,
+			Severity: notify.SeverityInfo,
+			Title:    fmt.Sprintf("new eibc order %s on %s", o.ID, o.RollappID),
+			Message:  fmt.Sprintf("%s%s, fee %s (%.3f%%), LP profit %s", o.Price, o.Denom, o.Fee, o.FeeRate*100, o.Profit.LP),
+			Fields: map[string]string{
+				"order_id":   o.ID,
+				"rollapp_id": o.RollappID,
+			},
+		})
+		if err != nil {
+			pterm.Warning.Println("failed to send notification:", err)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package apply
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/cmd/eibc/policy/plan"
+	eibcutils "github.com/dymensionxyz/roller/utils/eibc"
+	"github.com/dymensionxyz/roller/utils/output"
+)
+
+const flagYes = "yes"
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Reconcile the chain with the policy file",
+		Long: `Plan the changes like 'roller eibc policy plan' and apply them. Grants and
+revocations can only be signed by the liquidity provider, they are signed with
+the key set for the lp in the policy file. Without a key, the unsigned
+transaction is written to the policy directory of the eibc client home for the
+liquidity provider to sign and broadcast.
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			format, err := initconfig.GetOutputFormat(cmd)
+			if err != nil {
+				return err
+			}
+
+			c, err := plan.Build(cmd)
+			if err != nil {
+				return err
+			}
+
+			if len(c.Plan.Actions) == 0 {
+				if format.IsStructured() {
+					return output.Print(format, []eibcutils.PolicyApplyResult{})
+				}
+				plan.Render(c.Plan)
+				return nil
+			}
+
+			yes, _ := cmd.Flags().GetBool(flagYes)
+			if !format.IsStructured() {
+				plan.Render(c.Plan)
+			}
+			if !yes {
+				if format.IsStructured() {
+					return fmt.Errorf("--yes is required to apply the policy with structured output")
+				}
+				proceed, _ := pterm.DefaultInteractiveConfirm.WithDefaultText("apply these changes?").Show()
+				if !proceed {
+					return nil
+				}
+			}
+
+			results, err := eibcutils.ApplyPolicyPlan(c.Plan, c.Hub, filepath.Join(c.EibcHome, "policy"))
+			if err != nil {
+				return err
+			}
+
+			if format.IsStructured() {
+				return output.Print(format, results)
+			}
+
+			failed := 0
+			for _, r := range results {
+				switch {
+				case r.Error != "":
+					failed++
+					pterm.Error.Printfln("%s %s: %s", r.Kind, r.Name, r.Error)
+				case r.TxHash != "":
+					pterm.Success.Printfln("%s %s: %s", r.Kind, r.Name, r.TxHash)
+				default:
+					pterm.Warning.Printfln("%s %s: sign and broadcast %s", r.Kind, r.Name, r.UnsignedTx)
+				}
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d of %d changes failed", failed, len(results))
+			}
+			return nil
+		},
+	}
+
+	plan.AddFlags(cmd)
+	cmd.Flags().BoolP(flagYes, "y", false, "apply without confirmation")
+
+	return cmd
+}
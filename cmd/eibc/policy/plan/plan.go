@@ -0,0 +1,136 @@
+package plan
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/cmd/consts"
+	eibcutils "github.com/dymensionxyz/roller/utils/eibc"
+	"github.com/dymensionxyz/roller/utils/output"
+)
+
+const (
+	flagFile             = "file"
+	flagResetSpendLimits = "reset-spend-limits"
+)
+
+// Context is what is needed to plan and apply a policy file
+type Context struct {
+	EibcHome string
+	Hub      consts.HubData
+	Plan     *eibcutils.PolicyPlan
+}
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "plan",
+		Short: "Show the changes needed to reconcile the chain with the policy file",
+		Long: `Compare the policy file with the fulfillment grants given to the policy
+address of the eibc client config and with the decision policy of the group
+policy. Grants of liquidity providers missing from the file are revoked.
+
+The spend limits applied by 'roller eibc policy apply' are recorded in the
+eibc client home. While the spend limit of a rollapp in the file is the one
+last applied, the part consumed by fulfillments is not reported as a change
+unless --reset-spend-limits is set.
+`,
+		Example: `  roller eibc policy plan
+  roller eibc policy plan --file ./lps.yaml -o json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			format, err := initconfig.GetOutputFormat(cmd)
+			if err != nil {
+				return err
+			}
+
+			c, err := Build(cmd)
+			if err != nil {
+				return err
+			}
+
+			if format.IsStructured() {
+				return output.Print(format, c.Plan)
+			}
+
+			Render(c.Plan)
+			if len(c.Plan.Actions) > 0 {
+				pterm.Info.Println("run 'roller eibc policy apply' to apply these changes")
+			}
+			return nil
+		},
+	}
+
+	AddFlags(cmd)
+
+	return cmd
+}
+
+func AddFlags(cmd *cobra.Command) {
+	cmd.Flags().String(flagFile, "", "path to the policy file, defaults to policy.yaml in the eibc client home")
+	cmd.Flags().Bool(flagResetSpendLimits, false, "restore the spend limits consumed by fulfillments")
+}
+
+// Build loads the policy file and the eibc client config and plans the
+// changes against the chain
+func Build(cmd *cobra.Command) (*Context, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Context{EibcHome: filepath.Join(home, consts.ConfigDirName.Eibc)}
+
+	var cfg eibcutils.Config
+	hd, err := cfg.HubDataFromHubRpc(filepath.Join(c.EibcHome, "config.yaml"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the eibc client config, run 'roller eibc init' first: %w", err)
+	}
+	c.Hub = *hd
+
+	path, _ := cmd.Flags().GetString(flagFile)
+	if path == "" {
+		path = filepath.Join(c.EibcHome, eibcutils.PolicyFileName)
+	}
+	pf, err := eibcutils.LoadPolicyFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	reset, _ := cmd.Flags().GetBool(flagResetSpendLimits)
+	c.Plan, err = eibcutils.PlanPolicy(pf, cfg, c.EibcHome, c.Hub, reset)
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func Render(p *eibcutils.PolicyPlan) {
+	if len(p.Actions) == 0 {
+		pterm.Success.Printfln("the grants of %s match the policy file", p.PolicyAddress)
+		return
+	}
+
+	pterm.DefaultSection.Printfln("%d changes for %s", len(p.Actions), p.PolicyAddress)
+	for _, a := range p.Actions {
+		signer := "signed with key " + a.Key
+		if a.Key == "" {
+			signer = "exported unsigned for " + a.Signer
+		}
+		pterm.Printfln(
+			"%s %s (%s)",
+			pterm.FgYellow.Sprint(a.Kind),
+			pterm.Bold.Sprint(a.Name),
+			signer,
+		)
+		for _, ch := range a.Changes {
+			pterm.Println("    " + ch)
+		}
+	}
+}
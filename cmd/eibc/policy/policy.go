@@ -0,0 +1,20 @@
+package policy
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/dymensionxyz/roller/cmd/eibc/policy/apply"
+	"github.com/dymensionxyz/roller/cmd/eibc/policy/plan"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "policy",
+		Short: "Commands to manage the liquidity providers of the operator policy from a policy file",
+	}
+
+	cmd.AddCommand(plan.Cmd())
+	cmd.AddCommand(apply.Cmd())
+
+	return cmd
+}
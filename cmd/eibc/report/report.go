@@ -0,0 +1,200 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/cmd/consts"
+	eibcutils "github.com/dymensionxyz/roller/utils/eibc"
+	"github.com/dymensionxyz/roller/utils/output"
+)
+
+const (
+	flagFrom = "from"
+	flagTo   = "to"
+	flagCSV  = "csv"
+	flagJSON = "json"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Report the orders fulfilled through the operator policy for treasury reconciliation",
+		Long: `Reconstruct the orders fulfilled through the policy address of the eibc
+client config from the events of the hub, and aggregate them per fulfiller and
+per rollapp: fulfilled orders, fees earned by the liquidity providers, operator
+fee share paid, capital still locked and time to finalization.
+
+The period defaults to the last 30 days. Dates are given as YYYY-MM-DD or
+RFC3339, the end of the period is excluded.
+`,
+		Example: `  roller eibc report --from 2024-09-01 --to 2024-10-01 --csv orders.csv
+  roller eibc report --from 2024-09-01 --json report.json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			format, err := initconfig.GetOutputFormat(cmd)
+			if err != nil {
+				return err
+			}
+
+			from, to, err := period(cmd)
+			if err != nil {
+				return err
+			}
+
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return err
+			}
+
+			var cfg eibcutils.Config
+			eibcConfigPath := filepath.Join(home, consts.ConfigDirName.Eibc, "config.yaml")
+			hd, err := cfg.HubDataFromHubRpc(eibcConfigPath)
+			if err != nil {
+				return fmt.Errorf("failed to load the eibc client config, run 'roller eibc init' first: %w", err)
+			}
+			if cfg.Fulfillers.PolicyAddress == "" {
+				return fmt.Errorf("no policy address is set in fulfillers.policy_address of the eibc client config")
+			}
+
+			spinner, _ := pterm.DefaultSpinner.Start("reconstructing fulfilled orders from hub events")
+			r, err := eibcutils.BuildReport(*hd, cfg.Fulfillers.PolicyAddress, from, to)
+			if err != nil {
+				spinner.Fail()
+				return err
+			}
+			spinner.Success(fmt.Sprintf("found %d fulfilled orders", len(r.Orders)))
+			if r.FinalizationError != "" {
+				pterm.Warning.Println("finalization of the orders is unknown:", r.FinalizationError)
+			}
+
+			if path, _ := cmd.Flags().GetString(flagCSV); path != "" {
+				if err := exportCSV(r, path); err != nil {
+					return err
+				}
+				pterm.Info.Println("orders exported to", path)
+			}
+			if path, _ := cmd.Flags().GetString(flagJSON); path != "" {
+				if err := exportJSON(r, path); err != nil {
+					return err
+				}
+				pterm.Info.Println("report exported to", path)
+			}
+
+			if format.IsStructured() {
+				return output.Print(format, r)
+			}
+
+			printReport(r)
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagFrom, "", "start of the period (YYYY-MM-DD or RFC3339), defaults to 30 days ago")
+	cmd.Flags().String(flagTo, "", "end of the period, excluded (YYYY-MM-DD or RFC3339), defaults to now")
+	cmd.Flags().String(flagCSV, "", "export the fulfilled orders as CSV to the given file")
+	cmd.Flags().String(flagJSON, "", "export the full report as JSON to the given file")
+
+	return cmd
+}
+
+func period(cmd *cobra.Command) (time.Time, time.Time, error) {
+	to := time.Now().UTC()
+	if s, _ := cmd.Flags().GetString(flagTo); s != "" {
+		t, err := eibcutils.ParseReportTime(s)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		to = t
+	}
+
+	from := to.AddDate(0, 0, -30)
+	if s, _ := cmd.Flags().GetString(flagFrom); s != "" {
+		t, err := eibcutils.ParseReportTime(s)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		from = t
+	}
+
+	if !from.Before(to) {
+		return time.Time{}, time.Time{}, fmt.Errorf("--%s must be before --%s", flagFrom, flagTo)
+	}
+	return from, to, nil
+}
+
+func exportCSV(r *eibcutils.Report, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	defer f.Close()
+
+	if err := r.WriteCSV(f); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+func exportJSON(r *eibcutils.Report, path string) error {
+	b, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	// nolint:gofumpt
+	return os.WriteFile(path, b, 0o644)
+}
+
+func printReport(r *eibcutils.Report) {
+	pterm.DefaultSection.Printf(
+		"Orders fulfilled through %s from %s to %s",
+		r.PolicyAddress, r.From.Format(time.RFC3339), r.To.Format(time.RFC3339),
+	)
+	if len(r.Orders) == 0 {
+		pterm.Info.Println("no orders were fulfilled in this period")
+		return
+	}
+
+	pterm.DefaultSection.WithLevel(2).Println("Per fulfiller")
+	printTotals("Fulfiller", r.ByFulfiller)
+	pterm.DefaultSection.WithLevel(2).Println("Per rollapp")
+	printTotals("Rollapp", r.ByRollapp)
+}
+
+func printTotals(keyHeader string, totals []eibcutils.ReportTotals) {
+	data := pterm.TableData{
+		{keyHeader, "Denom", "Orders", "Volume", "Fees earned", "Operator fee", "Capital locked", "Avg finalization"},
+	}
+	for _, t := range totals {
+		avg := t.AvgTimeToFinalization
+		if avg == "" {
+			avg = "-"
+		}
+		data = append(data, []string{
+			t.Key,
+			t.Denom,
+			describeOrders(t),
+			t.Volume.String(),
+			t.FeesEarned.String(),
+			t.OperatorFee.String(),
+			t.CapitalLocked.String(),
+			avg,
+		})
+	}
+	_ = pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+}
+
+func describeOrders(t eibcutils.ReportTotals) string {
+	if t.Unknown > 0 {
+		return fmt.Sprintf("%d (%d finalized, %d unknown)", t.Orders, t.Finalized, t.Unknown)
+	}
+	return fmt.Sprintf("%d (%d finalized)", t.Orders, t.Finalized)
+}
@@ -0,0 +1,18 @@
+package notify
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/dymensionxyz/roller/cmd/notify/test"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "notify",
+		Short: "Commands related to the notification channels configured in roller.toml",
+	}
+
+	cmd.AddCommand(test.Cmd())
+
+	return cmd
+}
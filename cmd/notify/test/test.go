@@ -0,0 +1,121 @@
+package test
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/notify"
+	"github.com/dymensionxyz/roller/utils/output"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+const (
+	flagChannel  = "channel"
+	flagSeverity = "severity"
+)
+
+type result struct {
+	Channel string `json:"channel" yaml:"channel"`
+	Error   string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "test",
+		Short: "Send a test alert to the notification channels",
+		Long: `Send a test alert to every notification channel configured in the
+[[Notify.channels]] entries of roller.toml, or to a single one with --channel.
+The test alert ignores the severity and source routing of the channels.
+`,
+		Example: `  roller notify test
+  roller notify test --channel oncall --severity critical`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			format, err := initconfig.GetOutputFormat(cmd)
+			if err != nil {
+				return err
+			}
+
+			home, err := filesystem.ExpandHomePath(cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String())
+			if err != nil {
+				return err
+			}
+
+			rollerData, err := roller.LoadConfig(home)
+			if err != nil {
+				return fmt.Errorf("failed to load roller config: %w", err)
+			}
+			d, err := notify.New(rollerData.Notify)
+			if err != nil {
+				return err
+			}
+			if len(d.Channels()) == 0 {
+				return fmt.Errorf("no notification channels are configured in roller.toml")
+			}
+
+			channel, _ := cmd.Flags().GetString(flagChannel)
+			s, _ := cmd.Flags().GetString(flagSeverity)
+			severity, err := notify.ParseSeverity(s)
+			if err != nil {
+				return err
+			}
+
+			results := d.Test(channel, notify.Event{
+				Source:   notify.SourceTest,
+				Severity: severity,
+				Title:    "roller test notification",
+				Message:  fmt.Sprintf("sent by 'roller notify test' for %s", rollerData.RollappID),
+			})
+			if len(results) == 0 {
+				return fmt.Errorf("channel %s is not configured, available channels: %v", channel, d.Channels())
+			}
+
+			names := make([]string, 0, len(results))
+			for name := range results {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			var res []result
+			failed := 0
+			for _, name := range names {
+				r := result{Channel: name}
+				if err := results[name]; err != nil {
+					r.Error = err.Error()
+					failed++
+				}
+				res = append(res, r)
+			}
+
+			if format.IsStructured() {
+				if err := output.Print(format, res); err != nil {
+					return err
+				}
+			} else {
+				for _, r := range res {
+					if r.Error != "" {
+						pterm.Error.Printfln("%s: %s", r.Channel, r.Error)
+					} else {
+						pterm.Success.Printfln("%s: test alert sent", r.Channel)
+					}
+				}
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("%d of %d channels failed", failed, len(res))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagChannel, "", "name of the channel to test, all channels when empty")
+	cmd.Flags().String(flagSeverity, string(notify.SeverityWarning), "severity of the test alert")
+
+	return cmd
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/dymensionxyz/roller/cmd/observability/export"
 	"github.com/dymensionxyz/roller/cmd/observability/query"
+	"github.com/dymensionxyz/roller/cmd/observability/serve"
 )
 
 func Cmd() *cobra.Command {
@@ -17,6 +18,7 @@ func Cmd() *cobra.Command {
 
 	cmd.AddCommand(export.Cmd())
 	cmd.AddCommand(query.Cmd())
+	cmd.AddCommand(serve.Cmd())
 
 	return cmd
 }
@@ -2,31 +2,77 @@ package query
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/spf13/cobra"
 
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/cmd/consts"
 	"github.com/dymensionxyz/roller/utils/healthagent"
+	"github.com/dymensionxyz/roller/utils/output"
+	"github.com/dymensionxyz/roller/utils/roller"
 )
 
+type metricOutput struct {
+	Name  string `json:"name" yaml:"name"`
+	Value int    `json:"value" yaml:"value"`
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
 func Cmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "query",
 		Short: "Show the status of the sequencer on the local machine.",
-		Run: func(cmd *cobra.Command, args []string) {
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			format, err := initconfig.GetOutputFormat(cmd)
+			if err != nil {
+				return err
+			}
+
 			metrics := []string{
 				"dymint_mempool_size",
 				"rollapp_pending_submissions_skew_batches",
 				"rollapp_hub_height",
 				"rollapp_consecutive_failed_da_submissions",
 			}
+			results := make([]metricOutput, 0, len(metrics))
+			failed := 0
 			for _, metric := range metrics {
-				value, err := healthagent.QueryPromMetric("localhost", "2112", metric)
+				value, err := healthagent.QueryPromMetric(
+					"localhost",
+					strconv.Itoa(roller.ProfilePort(consts.DefaultPorts.RollappMetrics)),
+					metric,
+				)
+				if err != nil {
+					failed++
+				}
+				if format.IsStructured() {
+					r := metricOutput{Name: metric, Value: value}
+					if err != nil {
+						r.Error = err.Error()
+					}
+					results = append(results, r)
+					continue
+				}
+
 				if err != nil {
 					fmt.Printf("%s: failed to query metric: %s\n", metric, err)
 				} else {
 					fmt.Printf("%s: %d\n", metric, value)
 				}
 			}
+
+			if format.IsStructured() {
+				if err := output.Print(format, results); err != nil {
+					return err
+				}
+			}
+			if failed > 0 {
+				return fmt.Errorf("failed to query %d of %d metrics", failed, len(metrics))
+			}
+			return nil
 		},
 	}
 	return cmd
@@ -0,0 +1,79 @@
+package serve
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/exporter"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/logging"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Expose the state of the roller components on this machine as prometheus metrics",
+		Long: `Expose the state of the roller components on this machine as prometheus metrics.
+
+The exporter covers the balances of the roller managed keys, the sequencer bond,
+the relayer channels, the eibc client, the DA light client and state nodes, and
+the height lag between the rollapp and the hub. The metrics are collected in the
+background every interval and served on /metrics.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				return err
+			}
+
+			listen, _ := cmd.Flags().GetString("listen")
+			if listen == "" {
+				listen = fmt.Sprintf(":%d", roller.ProfilePort(consts.DefaultPorts.RollerExporter))
+			}
+			interval, _ := cmd.Flags().GetDuration("interval")
+			if interval <= 0 {
+				return errors.New("interval must be positive")
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			e := exporter.New(home, logging.GetRollerLogger(home))
+			go e.Run(ctx, interval)
+
+			mux := http.NewServeMux()
+			mux.Handle("/metrics", e.Handler())
+			srv := &http.Server{Addr: listen, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+			go func() {
+				<-ctx.Done()
+				// nolint: errcheck
+				srv.Shutdown(context.Background())
+			}()
+
+			pterm.Info.Printf("serving roller metrics on %s/metrics\n", listen)
+			if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String("listen", "", "address to serve the metrics on, defaults to the roller exporter port of the rollapp profile")
+	cmd.Flags().Duration("interval", time.Minute, "interval between two collections of the metrics")
+
+	return cmd
+}
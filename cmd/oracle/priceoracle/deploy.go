@@ -25,6 +25,10 @@ import (
 //go:embed configs/*
 var configFiles embed.FS
 
+// oracleGRPCPort is the grpc port of the price oracle client of the default
+// profile
+const oracleGRPCPort = 9093
+
 func DeployCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "deploy",
@@ -64,9 +68,9 @@ func DeployCmd() *cobra.Command {
 					pterm.Error.Printf("failed to create evm deployer: %v\n", err)
 					return
 				}
-				ownableContractUrl := "https://storage.googleapis.com/dymension-roller/Ownable.sol"
-				contextContractUrl := "https://storage.googleapis.com/dymension-roller/Context.sol"
-				contractUrl = "https://storage.googleapis.com/dymension-roller/price_oracle_contract.sol"
+				ownableContractUrl := consts.OracleContracts.Ownable
+				contextContractUrl := consts.OracleContracts.Context
+				contractUrl = consts.OracleContracts.PriceOracle
 
 				err := dependencies.InstallSolidityDependencies()
 				if err != nil {
@@ -111,7 +115,7 @@ func DeployCmd() *cobra.Command {
 					pterm.Error.Printf("failed to create wasm deployer: %v\n", err)
 					return
 				}
-				contractUrl = "https://storage.googleapis.com/dymension-roller/price_oracle_contract.wasm"
+				contractUrl = consts.OracleContracts.PriceOracleWasm
 
 				err = deployer.DownloadContract(
 					contractUrl,
@@ -224,11 +228,11 @@ func DeployCmd() *cobra.Command {
 				}
 
 				updates = map[string]any{
-					"chainClient.rpcEndpoint":     "http://127.0.0.1:8545/",
+					"chainClient.rpcEndpoint":     roller.LocalEndpoint(consts.DefaultPorts.RollappJsonRPC) + "/",
 					"chainClient.chainId":         networkID,
 					"chainClient.privateKey":      deployer.PrivateKey(),
 					"chainClient.contractAddress": contractAddr,
-					"grpc_port":                   9093,
+					"grpc_port":                   roller.ProfilePort(oracleGRPCPort),
 					// gasLimit: 250000
 					// maxGasPrice: "100000000000"
 				}
@@ -245,8 +249,8 @@ func DeployCmd() *cobra.Command {
 					"chainClient.chainId":       raData.Rollapp.RollappId,
 					"chainClient.privateKey":    deployer.PrivateKey(),
 					"chainClient.ssl":           false,
-					"chainClient.chainGrpcHost": "localhost:9090",
-					"grpc_port":                 9093,
+					"chainClient.chainGrpcHost": fmt.Sprintf("localhost:%d", roller.ProfilePort(consts.DefaultPorts.RollappGRPC)),
+					"grpc_port":                 roller.ProfilePort(oracleGRPCPort),
 				}
 			default:
 				pterm.Error.Printf("unsupported rollapp type: %s\n", rollerData.RollappVMType)
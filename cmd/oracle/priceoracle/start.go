@@ -2,7 +2,6 @@ package priceoracle
 
 import (
 	"context"
-	"os"
 	"os/exec"
 	"path/filepath"
 
@@ -13,6 +12,7 @@ import (
 	"github.com/dymensionxyz/roller/cmd/consts"
 	"github.com/dymensionxyz/roller/utils/bash"
 	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/plan"
 	"github.com/dymensionxyz/roller/utils/roller"
 )
 
@@ -61,7 +61,7 @@ func StartCmd() *cobra.Command {
 			case err := <-done:
 				if err != nil {
 					pterm.Error.Println("rollapp's process returned an error: ", err)
-					os.Exit(1)
+					plan.Exit(1)
 				}
 			case <-ctx.Done():
 				pterm.Error.Println("context cancelled, terminating command")
@@ -18,6 +18,7 @@ import (
 	"github.com/dymensionxyz/roller/utils/dependencies"
 	"github.com/dymensionxyz/roller/utils/filesystem"
 	"github.com/dymensionxyz/roller/utils/roller"
+	"github.com/dymensionxyz/roller/utils/versions"
 )
 
 //go:embed configs/*
@@ -108,11 +109,11 @@ func DeployCmd() *cobra.Command {
 			}{
 				{
 					Name: "EventManager.sol",
-					Url:  "https://storage.googleapis.com/dymension-roller/rng_EventManager.sol",
+					Url:  consts.OracleContracts.RngEventManager,
 				},
 				{
 					Name: "RandomnessGenerator.sol",
-					Url:  "https://storage.googleapis.com/dymension-roller/rng_RandomnessGenerator.sol",
+					Url:  consts.OracleContracts.RngRandomnessGenerator,
 				},
 			}
 
@@ -168,7 +169,7 @@ func DeployCmd() *cobra.Command {
 func initRngOracleClient(
 	rollerData roller.RollappConfig,
 	contractAddr, mnemonic string,
-	obvi *dependencies.OracleBinaryVersionInfo,
+	obvi *versions.OracleVersionInfo,
 ) error {
 	var v string
 	switch rollerData.RollappVMType {
@@ -211,7 +212,7 @@ func initRngOracleClient(
 		return fmt.Errorf("failed to parse config file: %v", err)
 	}
 
-	config.Contract.NodeURL = "http://127.0.0.1:8545"
+	config.Contract.NodeURL = roller.LocalEndpoint(consts.DefaultPorts.RollappJsonRPC)
 	config.Contract.ContractAddress = contractAddr
 	config.Contract.Mnemonic = mnemonic
 	config.DB.DBPath = filepath.Join(oracleConfigDir, "db")
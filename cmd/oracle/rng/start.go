@@ -3,7 +3,6 @@ package rngoracle
 import (
 	"context"
 	"fmt"
-	"os"
 	"os/exec"
 	"path/filepath"
 
@@ -14,6 +13,7 @@ import (
 	"github.com/dymensionxyz/roller/cmd/consts"
 	"github.com/dymensionxyz/roller/utils/bash"
 	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/plan"
 	"github.com/dymensionxyz/roller/utils/roller"
 )
 
@@ -72,7 +72,7 @@ func StartCmd() *cobra.Command {
 			case err := <-done:
 				if err != nil {
 					pterm.Error.Println("rollapp's process returned an error: ", err)
-					os.Exit(1)
+					plan.Exit(1)
 				}
 			case <-ctx.Done():
 				pterm.Error.Println("context cancelled, terminating command")
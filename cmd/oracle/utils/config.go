@@ -168,7 +168,7 @@ func (o *OracleConfig) StoreWasmContract(
 		balance, err := keys.QueryBalance(
 			keys.ChainQueryConfig{
 				Denom:  balanceDenom,
-				RPC:    "http://localhost:26657",
+				RPC:    roller.LocalRollappRPC(),
 				Binary: consts.Executables.RollappEVM,
 			}, o.KeyAddress,
 		)
@@ -355,7 +355,7 @@ func (o *OracleConfig) ListContracts(rollerData roller.RollappConfig) ([]string,
 		consts.Executables.RollappEVM,
 		"query", "wasm", "list-contracts-by-creator",
 		o.KeyAddress,
-		"--node", "http://localhost:26657",
+		"--node", roller.LocalRollappRPC(),
 		"--chain-id", rollerData.RollappID,
 		"--output", "json",
 	)
@@ -2,6 +2,14 @@ package oracleutils
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
 )
 
 // ContractDeployer defines the interface for deploying contracts on different chains
@@ -35,3 +43,30 @@ type ContractDeployer interface {
 	// ClientConfigPath returns the filepath to the client config file
 	ClientConfigPath() string
 }
+
+// fetchContract returns the contract code at url. Contracts installed from a
+// binaries bundle are used instead of downloading them
+func fetchContract(home, url string) ([]byte, error) {
+	local := filepath.Join(home, consts.ConfigDirName.Contracts, path.Base(url))
+	if contractCode, err := os.ReadFile(local); err == nil {
+		return contractCode, nil
+	}
+
+	// nolint: gosec
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download contract: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download contract, status: %s", resp.Status)
+	}
+
+	// Read the contract bytes
+	contractCode, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read contract code: %w", err)
+	}
+	return contractCode, nil
+}
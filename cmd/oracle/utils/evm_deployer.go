@@ -7,9 +7,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
-	"io"
 	"math/big"
-	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -166,21 +164,9 @@ func (e *EVMDeployer) DownloadContract(
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
-	// nolint: gosec
-	resp, err := http.Get(url)
+	contractCode, err := fetchContract(e.rollerData.Home, url)
 	if err != nil {
-		return fmt.Errorf("failed to download contract: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to download contract, status: %s", resp.Status)
-	}
-
-	// Read the contract bytes
-	contractCode, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to read contract code: %w", err)
+		return err
 	}
 
 	// Save the contract file
@@ -292,7 +278,7 @@ func ensureBalance(raResp *rollapp.ShowRollappResponse, e *EVMDeployer) error {
 		balance, err := keys.QueryBalance(
 			keys.ChainQueryConfig{
 				Denom:  balanceDenom,
-				RPC:    "http://localhost:26657",
+				RPC:    roller.LocalRollappRPC(),
 				Binary: consts.Executables.RollappEVM,
 			}, e.KeyData.Address,
 		)
@@ -358,7 +344,7 @@ func deployPriceOracleContract(
 	contractABI string,
 ) (*goethcommon.Address, error) {
 	pterm.Info.Println("deploying Oracle contract")
-	ethClient8545, err := ethclient.Dial("http://127.0.0.1:8545")
+	ethClient8545, err := ethclient.Dial(roller.LocalEndpoint(consts.DefaultPorts.RollappJsonRPC))
 	if err != nil {
 		return nil, fmt.Errorf("failed to dial eth client: %w", err)
 	}
@@ -448,7 +434,7 @@ func deployRngOracleContract(
 	contractABI string,
 ) (*goethcommon.Address, error) {
 	pterm.Info.Println("deploying RandomnessGenerator contract")
-	ethClient8545, err := ethclient.Dial("http://127.0.0.1:8545")
+	ethClient8545, err := ethclient.Dial(roller.LocalEndpoint(consts.DefaultPorts.RollappJsonRPC))
 	if err != nil {
 		return nil, fmt.Errorf("failed to dial eth client: %w", err)
 	}
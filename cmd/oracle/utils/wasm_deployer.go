@@ -4,8 +4,6 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
-	"io"
-	"net/http"
 	"os"
 	"path/filepath"
 	"time"
@@ -123,22 +121,9 @@ func (w *WasmDeployer) DownloadContract(url string, outputName string, oracleTyp
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
-	// Download the contract
-	// nolint: gosec
-	resp, err := http.Get(url)
+	contractCode, err := fetchContract(w.rollerData.Home, url)
 	if err != nil {
-		return fmt.Errorf("failed to download contract: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to download contract, status: %s", resp.Status)
-	}
-
-	// Read the contract bytes
-	contractCode, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to read contract code: %w", err)
+		return err
 	}
 
 	// Save the contract file
@@ -7,18 +7,58 @@ import (
 	relayerutils "github.com/dymensionxyz/roller/utils/relayer"
 )
 
+const (
+	flagPackets   = "packets"
+	flagChannel   = "channel"
+	flagSequences = "sequences"
+)
+
 func Cmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "flush",
 		Short: "Flush the relayer",
+		Long: `Flush the relayer.
+
+By default the blocks of both chains are scanned range by range for stuck
+packets, starting from the heights saved in roller-relayer-helper.toml.
+
+With --packets, the unrelayed packets and acknowledgements of the channel are
+queried first and only the heights they were written at are flushed. Progress
+is reported per sequence and saved to flush_progress.json in the relayer home,
+so an interrupted flush can be resumed by running the command again.
+--channel and --sequences imply --packets.
+`,
+		Example: `  roller relayer flush --packets
+  roller relayer flush --channel channel-12 --sequences 4,10-20`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			home := cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String()
 
-			relayerutils.Flush(home)
+			packets, _ := cmd.Flags().GetBool(flagPackets)
+			channel, _ := cmd.Flags().GetString(flagChannel)
+			sequences, _ := cmd.Flags().GetString(flagSequences)
 
-			return nil
+			if !packets && channel == "" && sequences == "" {
+				relayerutils.Flush(home)
+				return nil
+			}
+
+			opts := relayerutils.PacketFlushOptions{Channel: channel}
+			if sequences != "" {
+				seqs, err := relayerutils.ParseSequences(sequences)
+				if err != nil {
+					return err
+				}
+				opts.Sequences = seqs
+			}
+
+			cmd.SilenceUsage = true
+			return relayerutils.FlushPackets(home, opts)
 		},
 	}
 
+	cmd.Flags().Bool(flagPackets, false, "flush only the heights of the unrelayed packets and acknowledgements")
+	cmd.Flags().String(flagChannel, "", "hub side channel to flush, defaults to the active channel")
+	cmd.Flags().String(flagSequences, "", "sequences to flush, e.g. 4,10-20")
+
 	return cmd
 }
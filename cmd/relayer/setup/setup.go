@@ -3,7 +3,6 @@ package setup
 import (
 	"errors"
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/pterm/pterm"
@@ -16,13 +15,20 @@ import (
 	dymintutils "github.com/dymensionxyz/roller/utils/dymint"
 	"github.com/dymensionxyz/roller/utils/errorhandling"
 	"github.com/dymensionxyz/roller/utils/filesystem"
-	firebaseutils "github.com/dymensionxyz/roller/utils/firebase"
 	"github.com/dymensionxyz/roller/utils/logging"
+	"github.com/dymensionxyz/roller/utils/plan"
 	relayerutils "github.com/dymensionxyz/roller/utils/relayer"
 	"github.com/dymensionxyz/roller/utils/rollapp"
 	rollapputils "github.com/dymensionxyz/roller/utils/rollapp"
+	"github.com/dymensionxyz/roller/utils/roller"
 	sequencerutils "github.com/dymensionxyz/roller/utils/sequencer"
 	servicemanager "github.com/dymensionxyz/roller/utils/service_manager"
+	"github.com/dymensionxyz/roller/utils/versions"
+)
+
+const (
+	flagPath     = "path"
+	flagChannels = "channels"
 )
 
 // TODO: cleanup required, a lot of duplicate code in this cmd
@@ -30,12 +36,23 @@ func Cmd() *cobra.Command {
 	relayerStartCmd := &cobra.Command{
 		Use:   "setup",
 		Short: "Setup IBC connection between the Dymension hub and the RollApp.",
+		Long: `Setup IBC connection between the Dymension hub and the RollApp.
+
+The relayer can relay several paths, each one set up separately with --path,
+e.g. for a second RollApp or for a specific channel of the same RollApp. A path
+can be restricted to a set of hub channels with --channels.
+`,
+		Example: `  roller relayer setup
+  roller relayer setup --path hub-rollapp-ica --channels channel-42`,
 		Run: func(cmd *cobra.Command, args []string) {
 			// TODO: there are too many things set here, might be worth to refactor
 			home, _ := filesystem.ExpandHomePath(
 				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
 			)
 
+			pathName, _ := cmd.Flags().GetString(flagPath)
+			channels, _ := cmd.Flags().GetStringSlice(flagChannels)
+
 			err := servicemanager.StopSystemServices(consts.RelayerSystemdServices)
 			if err != nil {
 				pterm.Error.Println("failed to stop system services: ", err)
@@ -55,6 +72,8 @@ func Cmd() *cobra.Command {
 			relayerLogFilePath := logging.GetRelayerLogPath(home)
 			relayerLogger := logging.GetLogger(relayerLogFilePath)
 			rly.SetLogger(relayerLogger)
+			rly.Path = pathName
+			rly.ChannelFilter = channels
 
 			rlpCfg, err := rollapp.PopulateRollerConfigWithRaMetadataFromChain(
 				home,
@@ -88,7 +107,7 @@ func Cmd() *cobra.Command {
 			}
 
 			if !dirExist {
-				err = os.MkdirAll(rly.RelayerHome, 0o755)
+				err = plan.MkdirAll(rly.RelayerHome, 0o755)
 				if err != nil {
 					pterm.Error.Printf("failed to create %s: %v\n", rly.RelayerHome, err)
 					return
@@ -102,6 +121,12 @@ func Cmd() *cobra.Command {
 				return
 			}
 
+			err = relayerutils.EnsureRollappChain(home, *rlpCfg, raData.RpcUrl)
+			if err != nil {
+				pterm.Error.Printf("failed to add %s to the relayer config: %v\n", raData.ID, err)
+				return
+			}
+
 			var rlyCfg relayer.Config
 			err = rlyCfg.Load(rly.ConfigFilePath)
 			if err != nil {
@@ -110,16 +135,25 @@ func Cmd() *cobra.Command {
 			}
 
 			pterm.Info.Println("verifying path in relayer config")
-			if rlyCfg.GetPath() == nil {
-				pterm.Info.Println(
-					"no existing path, roller will create a new IBC path and set it up",
+			if rlyCfg.GetPath(pathName) == nil {
+				pterm.Info.Printfln(
+					"no existing path %s, roller will create a new IBC path and set it up",
+					pathName,
 				)
-				if err := rlyCfg.CreatePath(*rlpCfg); err != nil {
+				if err := rlyCfg.CreatePath(*rlpCfg, pathName); err != nil {
 					pterm.Error.Printf("failed to create relayer IBC path: %v\n", err)
 					return
 				}
 			}
 
+			if len(channels) > 0 {
+				pterm.Info.Printfln("restricting path %s to hub channels %s", pathName, strings.Join(channels, ", "))
+				if err := relayer.SetChannelFilter(rly.RelayerHome, pathName, channels); err != nil {
+					pterm.Error.Printf("failed to set the path channel filter: %v\n", err)
+					return
+				}
+			}
+
 			if err := rly.UpdateConfigWithDefaultValues(*rlpCfg); err != nil {
 				pterm.Error.Printf("failed to update relayer config file: %v\n", err)
 				return
@@ -138,6 +172,14 @@ func Cmd() *cobra.Command {
 			logFileOption := logging.WithLoggerLogging(relayerLogger)
 			err = rly.LoadActiveChannel(*raData, *hd)
 			if err != nil {
+				if errors.Is(err, relayer.ErrNoOpenChannel) && len(channels) > 0 {
+					pterm.Error.Printfln(
+						"none of the hub channels %s is open for %s",
+						strings.Join(channels, ", "),
+						rlpCfg.RollappID,
+					)
+					return
+				}
 				if errors.Is(err, relayer.ErrNoOpenChannel) {
 
 					pterm.Warning.Println("No open channel found")
@@ -175,7 +217,7 @@ func Cmd() *cobra.Command {
 					}
 
 					pterm.Info.Println("creating ibc connection")
-					dymintutils.WaitForHealthyRollApp("http://localhost:26657/health")
+					dymintutils.WaitForHealthyRollApp(roller.LocalRollappRPC() + "/health")
 					err = rly.HandleWhitelisting(
 						relKeys[consts.KeysIds.RollappRelayer].Address,
 						rlpCfg,
@@ -203,13 +245,14 @@ func Cmd() *cobra.Command {
 					return
 				}
 
-				err = rly.UpdateDefaultPath()
+				err = rly.UpdatePath()
 				if err != nil {
 					pterm.Error.Println("failed to update relayer config: ", err)
 					return
 				}
 
 				pterm.Info.Println("IBC connection information:")
+				pterm.Info.Println("Path: ", rly.Path)
 				pterm.Info.Println("Hub channel: ", rly.SrcChannel)
 				pterm.Info.Println("Hub connection: ", rly.SrcConnectionID)
 				pterm.Info.Println("Hub client: ", rly.SrcClientID)
@@ -229,6 +272,10 @@ func Cmd() *cobra.Command {
 		},
 	}
 
+	initconfig.AddDryRunFlag(relayerStartCmd)
+	relayerStartCmd.Flags().String(flagPath, consts.DefaultRelayerPath, "name of the relayer path to set up")
+	relayerStartCmd.Flags().StringSlice(flagChannels, nil, "hub channels the path is restricted to")
+
 	return relayerStartCmd
 }
 
@@ -291,7 +338,7 @@ func installRelayerDependencies(
 		return err
 	}
 
-	drsInfo, err := firebaseutils.GetLatestDrsVersionCommit(drsVersion, hd.Environment)
+	drsInfo, err := versions.GetLatestDrsVersionCommit(drsVersion, hd.Environment)
 	if err != nil {
 		pterm.Error.Println("failed to retrieve latest DRS version: ", err)
 		return err
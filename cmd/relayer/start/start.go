@@ -3,7 +3,9 @@ package start
 import (
 	"context"
 	"fmt"
+	"log"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/pterm/pterm"
@@ -15,7 +17,9 @@ import (
 	"github.com/dymensionxyz/roller/utils/bash"
 	"github.com/dymensionxyz/roller/utils/errorhandling"
 	"github.com/dymensionxyz/roller/utils/logging"
+	"github.com/dymensionxyz/roller/utils/notify"
 	"github.com/dymensionxyz/roller/utils/rollapp"
+	"github.com/dymensionxyz/roller/utils/roller"
 )
 
 const (
@@ -46,8 +50,16 @@ Consider using 'services' if you want to run a 'systemd' service instead.
 				return
 			}
 
-			raData := rlyCfg.RaDataFromRelayerConfig()
-			hd := rlyCfg.HubDataFromRelayerConfig()
+			raData, err := rlyCfg.RaDataFromRelayerConfig()
+			if err != nil {
+				pterm.Error.Println("failed to load relayer config: ", err)
+				return
+			}
+			hd, err := rlyCfg.HubDataFromRelayerConfig()
+			if err != nil {
+				pterm.Error.Println("failed to load relayer config: ", err)
+				return
+			}
 
 			maxRetries := 5
 			for i := 0; i < maxRetries; i++ {
@@ -67,6 +79,17 @@ Consider using 'services' if you want to run a 'systemd' service instead.
 			err = relayer.VerifyRelayerBalances(home, *hd)
 			if err != nil {
 				pterm.Error.Println("failed to check balances", err)
+				notifier, _ := notify.Load(home)
+				nerr := notifier.Emit(notify.Event{
+					Source:   notify.SourceBalance,
+					Check:    "relayer",
+					Severity: notify.SeverityCritical,
+					Title:    "relayer failed to start, its hub account balance is insufficient",
+					Message:  err.Error(),
+				})
+				if nerr != nil {
+					pterm.Warning.Println("failed to send notification:", nerr)
+				}
 				return
 			}
 			relayerLogFilePath := logging.GetRelayerLogPath(home)
@@ -104,14 +127,26 @@ Consider using 'services' if you want to run a 'systemd' service instead.
 			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
 
+			var extraPaths []string
+			for _, name := range rlyCfg.PathNames() {
+				if name != rly.Path {
+					extraPaths = append(extraPaths, name)
+				}
+			}
+			if len(extraPaths) > 0 {
+				fmt.Println("💈 Relaying additional paths:", strings.Join(extraPaths, ", "))
+			}
+
 			go bash.RunCmdAsync(
 				ctx,
-				rly.GetStartCmd(),
+				rly.GetStartCmd(extraPaths...),
 				func() {},
 				func(errMessage string) string { return errMessage },
 				logFileOption,
 			)
 
+			startClientWatchdogs(ctx, home, rlyCfg, rly, logger)
+
 			fmt.Printf(
 				"💈 The relayer is running successfully on you local machine!\nChannels:\nRollApp: %s\n<->\nHub: %s\n",
 				rly.DstChannel,
@@ -127,3 +162,57 @@ Consider using 'services' if you want to run a 'systemd' service instead.
 		BoolP(flagOverride, "", false, "override the existing relayer clients and channels")
 	return relayerStartCmd
 }
+
+// startClientWatchdogs runs a light client expiry watchdog for every relayed
+// path in the background, unless it is disabled in roller.toml
+func startClientWatchdogs(
+	ctx context.Context,
+	home string,
+	rlyCfg relayer.Config,
+	rly *relayer.Relayer,
+	logger *log.Logger,
+) {
+	var cfg roller.ClientWatchdogConfig
+	rollerData, err := roller.LoadConfig(home)
+	if err != nil {
+		pterm.Warning.Println("failed to load roller config, starting the client watchdog with defaults:", err)
+	} else {
+		cfg = rollerData.ClientWatchdog
+	}
+
+	if cfg.Disabled {
+		return
+	}
+
+	notifier, err := notify.Load(home)
+	if err != nil {
+		pterm.Warning.Println("failed to load notification channels, watchdog alerts are only logged:", err)
+	}
+
+	var watched []string
+	for _, name := range rlyCfg.PathNames() {
+		pathRly := rly
+		if name != rly.Path {
+			pathRly, err = rlyCfg.NewRelayerForPath(home, name)
+			if err != nil {
+				pterm.Error.Printf("failed to start the client watchdog of path %s: %v\n", name, err)
+				continue
+			}
+			pathRly.SetLogger(logger)
+		}
+
+		w, err := relayer.NewWatchdog(pathRly, cfg)
+		if err != nil {
+			pterm.Error.Printf("failed to start the client watchdog of path %s: %v\n", name, err)
+			continue
+		}
+		w.WithNotifier(notifier)
+
+		go w.Run(ctx)
+		watched = append(watched, name)
+	}
+
+	if len(watched) > 0 {
+		fmt.Println("💈 The IBC client expiry watchdog is running for", strings.Join(watched, ", "))
+	}
+}
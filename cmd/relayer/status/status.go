@@ -3,8 +3,11 @@ package status
 import (
 	"errors"
 	"fmt"
+	"math/big"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
@@ -12,18 +15,57 @@ import (
 	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
 	"github.com/dymensionxyz/roller/cmd/consts"
 	"github.com/dymensionxyz/roller/relayer"
-	"github.com/dymensionxyz/roller/utils/errorhandling"
 	"github.com/dymensionxyz/roller/utils/keys"
 	"github.com/dymensionxyz/roller/utils/logging"
+	"github.com/dymensionxyz/roller/utils/output"
 	"github.com/dymensionxyz/roller/utils/rollapp"
+	"github.com/dymensionxyz/roller/utils/runway"
 )
 
+// runwayWarning is the wallet runway below which the relayer is reported as
+// running out of funds
+const runwayWarning = 3 * 24 * time.Hour
+
+const flagPath = "path"
+
+// watchdogStale is the age of the last watchdog check after which the
+// watchdog is reported as not running
+const watchdogStale = time.Hour
+
+type Status struct {
+	// Path is the relayer path the status is shown for, out of Paths
+	Path  string   `json:"path" yaml:"path"`
+	Paths []string `json:"paths" yaml:"paths"`
+	// Status is the last status written by the relayer process
+	Status      string               `json:"status" yaml:"status"`
+	LogFilePath string               `json:"log_file_path" yaml:"log_file_path"`
+	Address     string               `json:"address,omitempty" yaml:"address,omitempty"`
+	Balance     string               `json:"balance,omitempty" yaml:"balance,omitempty"`
+	Runway      *runway.Forecast     `json:"runway,omitempty" yaml:"runway,omitempty"`
+	Chain       *relayer.ChainStatus `json:"chain" yaml:"chain"`
+	// Watchdog is the last state saved by the client expiry watchdog
+	Watchdog *relayer.WatchdogState `json:"watchdog,omitempty" yaml:"watchdog,omitempty"`
+}
+
 func Cmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "status",
 		Short: "Show the status of the relayer on the local machine.",
-		Run: func(cmd *cobra.Command, args []string) {
+		Long: `Show the status of the relayer on the local machine.
+
+The status is queried from both chains: the IBC clients with their latest
+heights and expiry, the connection and channel states, the packets and
+acknowledgements waiting to be relayed in each direction, the time of the last
+client update and the runway of the relayer wallet.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
 			home := cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String()
+			format, err := initconfig.GetOutputFormat(cmd)
+			if err != nil {
+				return err
+			}
+
 			rlyConfigPath := filepath.Join(
 				home,
 				consts.ConfigDirName.Relayer,
@@ -33,38 +75,47 @@ func Cmd() *cobra.Command {
 			relayerLogFilePath := logging.GetRelayerLogPath(home)
 
 			var rlyCfg relayer.Config
-			err := rlyCfg.Load(rlyConfigPath)
+			err = rlyCfg.Load(rlyConfigPath)
 			if err != nil {
-				pterm.Error.Println("failed to load relayer config: ", err)
-				return
+				return fmt.Errorf("failed to load relayer config: %w", err)
 			}
 
-			raData := rlyCfg.RaDataFromRelayerConfig()
-			hd := rlyCfg.HubDataFromRelayerConfig()
+			status := Status{LogFilePath: relayerLogFilePath}
+			pathName, _ := cmd.Flags().GetString(flagPath)
+			rly, err := rlyCfg.NewRelayerForPath(home, pathName)
+			if err != nil {
+				return err
+			}
+			raData, hd := rly.Rollapp, rly.Hub
 
-			_, err = rollapp.GetMetadataFromChain(raData.ID, *hd)
+			_, err = rollapp.GetMetadataFromChain(raData.ID, hd)
 			if err != nil {
-				pterm.Error.Println("failed to fetch rollapp information from hub: ", err)
-				return
+				return fmt.Errorf("failed to fetch rollapp information from hub: %w", err)
 			}
 
-			rly := relayer.NewRelayer(
-				home,
-				*raData,
-				*hd,
-			)
+			status.Path = rly.Path
+			status.Paths = rlyCfg.PathNames()
 
 			bytes, err := os.ReadFile(rly.StatusFilePath())
+			switch {
+			case errors.Is(err, os.ErrNotExist):
+				status.Status = "Starting..."
+			case err != nil:
+				pterm.Warning.Println("failed to read relayer process status:", err)
+			default:
+				status.Status = strings.TrimSpace(string(bytes))
+			}
+
+			spinner, _ := pterm.DefaultSpinner.Start("querying the relayer path on both chains")
+			status.Chain = rly.QueryChainStatus(raData, hd)
+			// nolint: errcheck
+			spinner.Stop()
+
+			status.Watchdog, err = relayer.LoadWatchdogState(rly.RelayerHome, rly.Path)
 			if err != nil {
-				if errors.Is(err, os.ErrNotExist) {
-					fmt.Println("💈 Starting...")
-					return
-				}
-			} else {
-				errorhandling.PrettifyErrorIfExists(err)
+				pterm.Warning.Println("failed to load client watchdog state:", err)
 			}
-			fmt.Println(string(bytes))
-			fmt.Println("💈 Log file path: ", relayerLogFilePath)
+			status.Chain.Issues = append(status.Chain.Issues, watchdogIssues(status.Watchdog)...)
 
 			kc := keys.KeyConfig{
 				ChainBinary:    consts.Executables.Dymension,
@@ -73,17 +124,148 @@ func Cmd() *cobra.Command {
 				KeyringBackend: consts.SupportedKeyringBackends.Test,
 			}
 
-			rlyAddrData, err := keys.GetRelayerData(home, kc, *hd)
+			rlyAddrData, err := keys.GetRelayerData(home, kc, hd)
 			if err != nil {
-				pterm.Error.Println("failed to retrieve relayer address", err)
-				return
+				status.Chain.Issues = append(
+					status.Chain.Issues,
+					fmt.Sprintf("failed to retrieve relayer wallet: %v", err),
+				)
+			} else {
+				status.Address = rlyAddrData[0].Address
+				status.Balance = rlyAddrData[0].Balance.String()
+
+				amount, _ := new(big.Float).SetInt(rlyAddrData[0].Balance.Amount.BigInt()).Float64()
+				samples, err := runway.Record(
+					filepath.Join(rly.RelayerHome, "balance_samples.json"),
+					amount,
+				)
+				if err != nil {
+					pterm.Warning.Println("failed to record relayer balance:", err)
+				}
+				f := runway.Estimate(samples)
+				status.Runway = &f
+				if f.Known && f.Runway < runwayWarning {
+					status.Chain.Issues = append(
+						status.Chain.Issues,
+						fmt.Sprintf("the relayer wallet runs out of funds in %s", f),
+					)
+				}
+			}
+
+			if format.IsStructured() {
+				return output.Print(format, status)
 			}
 
-			pterm.DefaultSection.WithIndentCharacter("💈").
-				Println("Wallet Info:")
-			fmt.Println("Relayer Address:", rlyAddrData[0].Address)
-			fmt.Println("Relayer Balance:", rlyAddrData[0].Balance.String())
+			printStatus(status)
+			return nil
 		},
 	}
+	cmd.Flags().String(flagPath, consts.DefaultRelayerPath, "relayer path to show the status of")
+
 	return cmd
 }
+
+func printStatus(s Status) {
+	c := s.Chain
+
+	pterm.DefaultSection.WithIndentCharacter("💈").Println("Relayer Process:")
+	fmt.Println(s.Status)
+	fmt.Println("Path:", s.Path)
+	if len(s.Paths) > 1 {
+		fmt.Println("Relayed paths:", strings.Join(s.Paths, ", "))
+	}
+	fmt.Println("Log file path:", s.LogFilePath)
+
+	if len(c.Clients) > 0 {
+		pterm.DefaultSection.WithIndentCharacter("💈").Println("Clients:")
+		data := pterm.TableData{{"CHAIN", "CLIENT", "TRACKS", "HEIGHT", "LAST UPDATE", "EXPIRES IN"}}
+		for _, cl := range c.Clients {
+			data = append(data, []string{
+				cl.Chain,
+				cl.ClientID,
+				cl.TrackedChain,
+				fmt.Sprint(cl.LatestHeight),
+				ago(cl.LastUpdate),
+				time.Until(cl.ExpiresAt).Round(time.Minute).String(),
+			})
+		}
+		// nolint: errcheck
+		pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+	}
+
+	if len(c.Connections) > 0 || len(c.Channels) > 0 {
+		pterm.DefaultSection.WithIndentCharacter("💈").Println("Connections and Channels:")
+		data := pterm.TableData{{"CHAIN", "ID", "STATE", "COUNTERPARTY"}}
+		for _, e := range append(c.Connections, c.Channels...) {
+			data = append(data, []string{e.Chain, e.ID, e.State, e.Counterparty})
+		}
+		// nolint: errcheck
+		pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+	}
+
+	if len(c.Pending) > 0 {
+		pterm.DefaultSection.WithIndentCharacter("💈").Println("Pending Packets:")
+		data := pterm.TableData{{"DIRECTION", "PACKETS", "ACKS"}}
+		for _, p := range c.Pending {
+			data = append(data, []string{p.Direction, fmt.Sprint(p.Packets), fmt.Sprint(p.Acks)})
+		}
+		// nolint: errcheck
+		pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+	}
+
+	if w := s.Watchdog; w != nil {
+		pterm.DefaultSection.WithIndentCharacter("💈").Println("Client Watchdog:")
+		fmt.Println("Last check:", ago(w.CheckedAt))
+		fmt.Println("Update threshold:", w.UpdateThreshold)
+		if !w.LastUpdateAt.IsZero() {
+			fmt.Println("Last client update:", ago(w.LastUpdateAt))
+		}
+		for _, a := range w.Alerts {
+			fmt.Printf("%s: %s\n", a.Time.Local().Format(time.DateTime), a.Message)
+		}
+	}
+
+	if !c.LastRelayedAt.IsZero() {
+		fmt.Println("Last relayed:", ago(c.LastRelayedAt))
+	}
+
+	if s.Address != "" {
+		pterm.DefaultSection.WithIndentCharacter("💈").Println("Wallet Info:")
+		fmt.Println("Relayer Address:", s.Address)
+		fmt.Println("Relayer Balance:", s.Balance)
+		if s.Runway != nil {
+			fmt.Println("Relayer Runway:", s.Runway)
+		}
+	}
+
+	fmt.Println()
+	if len(c.Issues) == 0 {
+		pterm.Success.Println("no issues found")
+		return
+	}
+	for _, issue := range c.Issues {
+		pterm.Warning.Println(issue)
+	}
+}
+
+func watchdogIssues(w *relayer.WatchdogState) []string {
+	if w == nil {
+		return []string{"the client expiry watchdog never ran, start the relayer to run it"}
+	}
+
+	var issues []string
+	if time.Since(w.CheckedAt) > watchdogStale {
+		issues = append(issues, fmt.Sprintf("the client expiry watchdog did not run for %s", time.Since(w.CheckedAt).Round(time.Minute)))
+	}
+	if w.CheckError != "" {
+		issues = append(issues, "the client expiry watchdog failed to query the clients: "+w.CheckError)
+	}
+	if w.LastUpdateError != "" {
+		issues = append(issues, "the client expiry watchdog failed to update the clients: "+w.LastUpdateError)
+	}
+	return issues
+}
+
+func ago(t time.Time) string {
+	return fmt.Sprintf("%s ago", time.Since(t).Round(time.Second))
+}
@@ -10,9 +10,9 @@ import (
 	"github.com/dymensionxyz/roller/utils/dependencies/types"
 	dependencytypes "github.com/dymensionxyz/roller/utils/dependencies/types"
 	"github.com/dymensionxyz/roller/utils/filesystem"
-	"github.com/dymensionxyz/roller/utils/firebase"
 	"github.com/dymensionxyz/roller/utils/roller"
 	servicemanager "github.com/dymensionxyz/roller/utils/service_manager"
+	"github.com/dymensionxyz/roller/utils/versions"
 )
 
 func Cmd() *cobra.Command {
@@ -37,7 +37,7 @@ func Cmd() *cobra.Command {
 
 			pterm.Info.Println("preparing update")
 
-			bvi, err := firebase.GetDependencyVersions(localRollerConfig.HubData.Environment)
+			bvi, err := versions.GetDependencyVersions(localRollerConfig.HubData.Environment)
 			if err != nil {
 				pterm.Error.Println("failed to fetch binary versions: ", err)
 				return
@@ -49,6 +49,7 @@ func Cmd() *cobra.Command {
 				RepositoryName:  "go-relayer",
 				RepositoryUrl:   "https://github.com/dymensionxyz/go-relayer",
 				Release:         bvi.Relayer,
+				ChecksumsFile:   dependencies.GoreleaserChecksumsFile,
 				Binaries: []types.BinaryPathPair{
 					{
 						Binary:            "rly",
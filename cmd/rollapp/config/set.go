@@ -171,5 +171,7 @@ func SetCmd() *cobra.Command {
 		},
 	}
 
+	initconfig.AddDryRunFlag(cmd)
+
 	return cmd
 }
@@ -10,15 +10,15 @@ import (
 
 	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
 	"github.com/dymensionxyz/roller/cmd/consts"
-	"github.com/dymensionxyz/roller/utils/archives"
+	"github.com/dymensionxyz/roller/utils/binstore"
 	"github.com/dymensionxyz/roller/utils/config/tomlconfig"
 	"github.com/dymensionxyz/roller/utils/dependencies"
 	dymintutils "github.com/dymensionxyz/roller/utils/dymint"
 	"github.com/dymensionxyz/roller/utils/filesystem"
-	firebaseutils "github.com/dymensionxyz/roller/utils/firebase"
 	rollapputils "github.com/dymensionxyz/roller/utils/rollapp"
 	"github.com/dymensionxyz/roller/utils/roller"
 	servicemanager "github.com/dymensionxyz/roller/utils/service_manager"
+	"github.com/dymensionxyz/roller/utils/versions"
 )
 
 func UpdateCmd() *cobra.Command {
@@ -62,7 +62,7 @@ func UpdateCmd() *cobra.Command {
 				pterm.Info.Println("Installing the latest version", err)
 			}
 
-			drsInfo, err := firebaseutils.GetLatestDrsVersionCommit(
+			drsInfo, err := versions.GetLatestDrsVersionCommit(
 				drsVersion,
 				rollerData.HubData.Environment,
 			)
@@ -139,8 +139,9 @@ func UpdateCmd() *cobra.Command {
 				return
 			}
 
-			// replace the current binary with the new one
-			err = archives.MoveBinaryIntoPlaceAndMakeExecutable(
+			// replace the current binary with the new one, the binary store keeps
+			// the current one as the previous version
+			previous, err := binstore.Install(
 				tmpBinLocation,
 				consts.Executables.RollappEVM,
 			)
@@ -148,6 +149,14 @@ func UpdateCmd() *cobra.Command {
 				pterm.Error.Println("failed to move rollapp binary: ", err)
 				return
 			}
+			if previous != "" {
+				pterm.Info.Printf(
+					"the previous rollapp binary %s is kept, restore it with %s\n",
+					previous,
+					pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
+						Sprintf("roller binaries rollback rollappd"),
+				)
+			}
 
 			// start services
 			err = servicemanager.StartSystemServices([]string{"rollapp"})
@@ -157,7 +166,7 @@ func UpdateCmd() *cobra.Command {
 			}
 
 			// wait for healthy endpoint
-			dymintutils.WaitForHealthyRollApp("http://localhost:26657/health")
+			dymintutils.WaitForHealthyRollApp(roller.LocalRollappRPC() + "/health")
 
 			err = tomlconfig.UpdateFieldInFile(
 				filepath.Join(home, "roller.toml"),
@@ -173,5 +182,7 @@ func UpdateCmd() *cobra.Command {
 		},
 	}
 
+	initconfig.AddDryRunFlag(cmd)
+
 	return cmd
 }
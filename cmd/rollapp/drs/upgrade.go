@@ -11,15 +11,15 @@ import (
 	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
 	"github.com/dymensionxyz/roller/cmd/consts"
 	datalayer "github.com/dymensionxyz/roller/data_layer"
-	"github.com/dymensionxyz/roller/utils/archives"
+	"github.com/dymensionxyz/roller/utils/binstore"
 	"github.com/dymensionxyz/roller/utils/config/tomlconfig"
 	"github.com/dymensionxyz/roller/utils/dependencies"
 	"github.com/dymensionxyz/roller/utils/filesystem"
-	firebaseutils "github.com/dymensionxyz/roller/utils/firebase"
 	"github.com/dymensionxyz/roller/utils/rollapp"
 	rollapputils "github.com/dymensionxyz/roller/utils/rollapp"
 	"github.com/dymensionxyz/roller/utils/roller"
 	"github.com/dymensionxyz/roller/utils/sequencer"
+	"github.com/dymensionxyz/roller/utils/versions"
 )
 
 func UpgradeCmd() *cobra.Command {
@@ -82,7 +82,7 @@ func UpgradeCmd() *cobra.Command {
 				return
 			}
 
-			drsInfo, err := firebaseutils.GetLatestDrsVersionCommit(
+			drsInfo, err := versions.GetLatestDrsVersionCommit(
 				targetDrs,
 				rollerData.HubData.Environment,
 			)
@@ -137,8 +137,9 @@ func UpgradeCmd() *cobra.Command {
 				return
 			}
 
-			// replace the current binary with the new one
-			err = archives.MoveBinaryIntoPlaceAndMakeExecutable(
+			// replace the current binary with the new one, the binary store keeps
+			// the current one as the previous version
+			previous, err := binstore.Install(
 				tmpBinLocation,
 				consts.Executables.RollappEVM,
 			)
@@ -146,6 +147,14 @@ func UpgradeCmd() *cobra.Command {
 				pterm.Error.Println("failed to move rollapp binary: ", err)
 				return
 			}
+			if previous != "" {
+				pterm.Info.Printf(
+					"the previous rollapp binary %s is kept, restore it with %s\n",
+					previous,
+					pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
+						Sprintf("roller binaries rollback rollappd"),
+				)
+			}
 
 			rollappConfig, err := rollapp.PopulateRollerConfigWithRaMetadataFromChain(
 				home,
@@ -158,12 +167,16 @@ func UpgradeCmd() *cobra.Command {
 				return
 			}
 
-			damanager := datalayer.NewDAManager(
+			damanager, err := datalayer.NewDAManager(
 				rollappConfig.DA.Backend,
 				rollappConfig.Home,
 				rollappConfig.KeyringBackend,
 				rollappConfig.NodeType, // TODO: NodeType here will be empty because it is not set before.
 			)
+			if err != nil {
+				pterm.Error.Println("failed to initialize DA manager: ", err)
+				return
+			}
 
 			if rollapp.IsDAConfigMigrationRequired(
 				drsVersion,
@@ -194,6 +207,8 @@ func UpgradeCmd() *cobra.Command {
 
 	cmd.Flags().BoolP("yes", "y", false, "automatically accept prompts")
 
+	initconfig.AddDryRunFlag(cmd)
+
 	return cmd
 }
 
@@ -217,6 +232,6 @@ func upgradeDaConfig(dymintConfigPath string, daManager datalayer.DAManager) {
 	_ = tomlconfig.UpdateFieldInFile(
 		dymintConfigPath,
 		"da_layer",
-		[]string{string(daManager.DaType)},
+		[]string{string(daManager.GetDymintDALayer())},
 	)
 }
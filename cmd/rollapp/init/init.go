@@ -107,7 +107,12 @@ func Cmd() *cobra.Command {
 				return
 			}
 
-			err = rollerfs.CreateRollerRootWithOptionalOverride(home, forceOverwrite)
+			raServices := make([]string, 0, len(consts.RollappSystemdServices))
+			for _, svc := range consts.RollappSystemdServices {
+				raServices = append(raServices, roller.ServiceName(svc))
+			}
+
+			err = rollerfs.CreateRollerRootWithOptionalOverride(home, forceOverwrite, raServices)
 			if err != nil {
 				pterm.Error.Printf(
 					"failed to create roller home directory (%s): %v\n",
@@ -177,7 +182,11 @@ func Cmd() *cobra.Command {
 			var kb consts.SupportedKeyringBackend
 
 			if env != "custom" {
-				kb = keys.KeyringBackendFromEnv(env)
+				kb, err = keys.KeyringBackendFromEnv(env)
+				if err != nil {
+					pterm.Error.Println("failed to select the keyring backend: ", err)
+					return
+				}
 			}
 
 			switch env {
@@ -359,8 +368,8 @@ func Cmd() *cobra.Command {
 				}
 			}
 
-			if kb == consts.SupportedKeyringBackends.OS {
-				pterm.Info.Println("creating startup scripts for OS keyring backend")
+			if kb.RequiresPassphrase() && !keys.HasPassphraseSource(home) {
+				pterm.Info.Printf("creating startup scripts for %s keyring backend\n", kb)
 				err := scripts.CreateRollappStartup(home)
 				if err != nil {
 					pterm.Error.Println("failed to generate startup scripts:", err)
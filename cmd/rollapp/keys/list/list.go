@@ -1,17 +1,15 @@
 package list
 
 import (
-	"encoding/json"
 	"fmt"
 
-	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 
 	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
 	"github.com/dymensionxyz/roller/cmd/consts"
 	datalayer "github.com/dymensionxyz/roller/data_layer"
-	"github.com/dymensionxyz/roller/utils/errorhandling"
 	"github.com/dymensionxyz/roller/utils/keys"
+	"github.com/dymensionxyz/roller/utils/output"
 	"github.com/dymensionxyz/roller/utils/roller"
 )
 
@@ -19,46 +17,61 @@ func Cmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List all rollapp addresses.",
-		Run: func(cmd *cobra.Command, args []string) {
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
 			home := cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String()
+			format, err := initconfig.GetOutputFormat(cmd)
+			if err != nil {
+				return err
+			}
+
 			rollerData, err := roller.LoadConfig(home)
-			errorhandling.PrettifyErrorIfExists(err)
+			if err != nil {
+				return fmt.Errorf("failed to load roller config file: %w", err)
+			}
 
 			aki, err := keys.All(rollerData, rollerData.HubData)
 			if err != nil {
-				pterm.Error.Println("failed to get all keys", err)
-				return
+				return fmt.Errorf("failed to get all keys: %w", err)
 			}
 
 			if rollerData.DA.Backend != "" && rollerData.DA.Backend != consts.Mock {
-				daManager := datalayer.NewDAManager(rollerData.DA.Backend, rollerData.Home, rollerData.KeyringBackend, rollerData.NodeType)
+				daManager, err := datalayer.NewDAManager(rollerData.DA.Backend, rollerData.Home, rollerData.KeyringBackend, rollerData.NodeType)
+				if err != nil {
+					return fmt.Errorf("failed to initialize DA manager: %w", err)
+				}
 				daKi, err := daManager.GetDAAccountAddress()
 				if err != nil {
-					pterm.Error.Println("failed to get DA key", err)
-				} else if daKi != nil {
+					return fmt.Errorf("failed to get DA key: %w", err)
+				}
+				if daKi != nil {
 					aki = append(aki, *daKi)
 				}
 			}
 
+			if format.IsStructured() {
+				return output.Print(format, toKeysOutput(aki))
+			}
+
 			for _, addrData := range aki {
 				addrData.Print(keys.WithName())
 			}
+			return nil
 		},
 	}
-	// cmd.Flags().StringP(flagNames.outputType, "", "text", "Output format (text|json)")
 	return cmd
 }
 
-// nolint: unused
-func printAsJSON(addresses []keys.KeyInfo) error {
-	addrMap := make(map[string]string)
+type keyOutput struct {
+	Name    string `json:"name" yaml:"name"`
+	Address string `json:"address" yaml:"address"`
+}
+
+func toKeysOutput(addresses []keys.KeyInfo) []keyOutput {
+	out := make([]keyOutput, 0, len(addresses))
 	for _, addrData := range addresses {
-		addrMap[addrData.Name] = addrData.Address
-	}
-	data, err := json.MarshalIndent(addrMap, "", "  ")
-	if err != nil {
-		return fmt.Errorf("error marshalling data %s", err)
+		out = append(out, keyOutput{Name: addrData.Name, Address: addrData.Address})
 	}
-	fmt.Println(string(data))
-	return nil
+	return out
 }
@@ -76,6 +76,8 @@ func Cmd() *cobra.Command {
 			}
 		},
 	}
+	initconfig.AddDryRunFlag(cmd)
+
 	return cmd
 }
 
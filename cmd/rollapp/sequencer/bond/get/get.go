@@ -7,38 +7,52 @@ import (
 	"github.com/spf13/cobra"
 
 	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/utils/output"
 	"github.com/dymensionxyz/roller/utils/roller"
 	"github.com/dymensionxyz/roller/utils/sequencer"
 )
 
+type bondOutput struct {
+	Address string `json:"address" yaml:"address"`
+	Bond    string `json:"bond" yaml:"bond"`
+}
+
 func Cmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "get",
 		Short: "Retrieve the current sequencer bond amount",
-		Run: func(cmd *cobra.Command, args []string) {
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
 			home := cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String()
+			format, err := initconfig.GetOutputFormat(cmd)
+			if err != nil {
+				return err
+			}
 
 			rollerData, err := roller.LoadConfig(home)
 			if err != nil {
-				pterm.Error.Println("failed to load roller config file", err)
-				return
+				return fmt.Errorf("failed to load roller config file: %w", err)
 			}
 
 			address, err := sequencer.GetHubSequencerAddress(rollerData)
 			if err != nil {
-				pterm.Error.Println("failed to retrieve sequencer address", err)
-				return
+				return fmt.Errorf("failed to retrieve sequencer address: %w", err)
 			}
 
 			bond, err := sequencer.GetSequencerBond(address, rollerData.HubData)
 			if err != nil {
-				pterm.Error.Println("failed to retrieve sequencer bond", err)
-				return
+				return fmt.Errorf("failed to retrieve sequencer bond: %w", err)
+			}
+
+			if format.IsStructured() {
+				return output.Print(format, bondOutput{Address: address, Bond: bond.String()})
 			}
 
 			pterm.DefaultSection.WithIndentCharacter("💈").
 				Printf("%s bonded tokens", address)
 			fmt.Println(bond.String())
+			return nil
 		},
 	}
 
@@ -14,8 +14,9 @@ import (
 	"github.com/dymensionxyz/roller/cmd/consts"
 	"github.com/dymensionxyz/roller/cmd/tx/tx_utils"
 	"github.com/dymensionxyz/roller/utils/bash"
-	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/keys"
 	"github.com/dymensionxyz/roller/utils/roller"
+	"github.com/dymensionxyz/roller/utils/signer"
 	"github.com/dymensionxyz/roller/utils/tx"
 )
 
@@ -67,16 +68,16 @@ func Cmd() *cobra.Command {
 			}
 			var txHash string
 
-			if rollerData.KeyringBackend == consts.SupportedKeyringBackends.OS {
-				pswFileName, err := filesystem.GetOsKeyringPswFileName(consts.Executables.Dymension)
+			if rollerData.Signer.HasRemoteOperator() {
+				txHash, err = signer.OperatorTx(rollerData, bondArgs)
 				if err != nil {
-					pterm.Error.Println("failed to get os keyring psw file name", err)
+					pterm.Error.Println("failed to sign with the remote signer", err)
 					return
 				}
-				fp := filepath.Join(home, string(pswFileName))
-				psw, err := filesystem.ReadFromFile(fp)
+			} else if rollerData.KeyringBackend.RequiresPassphrase() {
+				psw, err := keys.ReadKeyringPassphrase(home, consts.Executables.Dymension)
 				if err != nil {
-					pterm.Error.Println("failed to read keyring passphrase file", err)
+					pterm.Error.Println("failed to read keyring passphrase", err)
 					return
 				}
 
@@ -1,17 +1,12 @@
 package unbond
 
 import (
-	"fmt"
-	"os/exec"
-	"path/filepath"
-
 	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 
 	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
-	"github.com/dymensionxyz/roller/cmd/consts"
-	"github.com/dymensionxyz/roller/utils/bash"
 	"github.com/dymensionxyz/roller/utils/roller"
+	"github.com/dymensionxyz/roller/utils/rotation"
 	"github.com/dymensionxyz/roller/utils/tx"
 )
 
@@ -28,25 +23,16 @@ func Cmd() *cobra.Command {
 				return
 			}
 
-			c := exec.Command(
-				consts.Executables.Dymension, "tx",
-				"sequencer", "unbond", "--keyring-backend",
-				"test", "--from", consts.KeysIds.HubSequencer, "--keyring-dir", filepath.Join(
-					home,
-					consts.ConfigDirName.HubKeys,
-				), "--fees", fmt.Sprintf("%d%s", consts.DefaultTxFee, consts.Denoms.Hub),
-				"--node", rollerData.HubData.RpcUrl, "--chain-id", rollerData.HubData.ID,
-			)
-
-			txOutput, err := bash.ExecCommandWithInput(home, c, "signatures")
-			if err != nil {
-				pterm.Error.Println("failed to update bond: ", err)
+			proceed, _ := pterm.DefaultInteractiveConfirm.WithDefaultText(
+				"this transaction is going to unbond the sequencer. do you want to continue?",
+			).Show()
+			if !proceed {
 				return
 			}
 
-			txHash, err := bash.ExtractTxHash(txOutput)
+			txHash, err := rotation.Unbond(rollerData)
 			if err != nil {
-				pterm.Error.Println("failed to update bond: ", err)
+				pterm.Error.Println("failed to unbond: ", err)
 				return
 			}
 
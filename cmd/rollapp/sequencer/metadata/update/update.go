@@ -17,8 +17,10 @@ import (
 	"github.com/dymensionxyz/roller/utils/bash"
 	"github.com/dymensionxyz/roller/utils/config/tomlconfig"
 	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/keys"
 	"github.com/dymensionxyz/roller/utils/roller"
 	sequencerutils "github.com/dymensionxyz/roller/utils/sequencer"
+	"github.com/dymensionxyz/roller/utils/signer"
 	"github.com/dymensionxyz/roller/utils/tx"
 )
 
@@ -71,16 +73,16 @@ func Cmd() *cobra.Command {
 			}
 			var txHash string
 
-			if raData.KeyringBackend == consts.SupportedKeyringBackends.OS {
-				pswFileName, err := filesystem.GetOsKeyringPswFileName(consts.Executables.Dymension)
+			if raData.Signer.HasRemoteOperator() {
+				txHash, err = signer.OperatorTx(raData, updateSeqArgs)
 				if err != nil {
-					pterm.Error.Println("failed to get os keyring psw file name", err)
+					pterm.Error.Println("failed to sign with the remote signer", err)
 					return
 				}
-				fp := filepath.Join(home, string(pswFileName))
-				psw, err := filesystem.ReadFromFile(fp)
+			} else if raData.KeyringBackend.RequiresPassphrase() {
+				psw, err := keys.ReadKeyringPassphrase(home, consts.Executables.Dymension)
 				if err != nil {
-					pterm.Error.Println("failed to read keyring passphrase file", err)
+					pterm.Error.Println("failed to read keyring passphrase", err)
 					return
 				}
 
@@ -182,7 +182,7 @@ func Cmd() *cobra.Command {
 					return
 				}
 
-				err = tx.MonitorTransaction(consts.DefaultRollappRPC, txHash)
+				err = tx.MonitorTransaction(roller.LocalRollappRPC(), txHash)
 				if err != nil {
 					pterm.Error.Println("failed to update sequencer: ", err)
 					return
@@ -191,7 +191,7 @@ func Cmd() *cobra.Command {
 				updSeqCmd := exec.Command(
 					consts.Executables.RollappEVM,
 					"tx", "sequencer", "update-sequencer",
-					address, "--keyring-backend", "test", "--node", consts.DefaultRollappRPC,
+					address, "--keyring-backend", "test", "--node", roller.LocalRollappRPC(),
 					"--chain-id", rollerCfg.RollappID,
 					"--from", "rollapp",
 					"--gas-prices",
@@ -214,7 +214,7 @@ func Cmd() *cobra.Command {
 					return
 				}
 
-				err = tx.MonitorTransaction(consts.DefaultRollappRPC, uTxHash)
+				err = tx.MonitorTransaction(roller.LocalRollappRPC(), uTxHash)
 				if err != nil {
 					pterm.Error.Println("failed to update sequencer: ", err)
 					return
@@ -0,0 +1,344 @@
+package rotate
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/plan"
+	"github.com/dymensionxyz/roller/utils/rollapp"
+	"github.com/dymensionxyz/roller/utils/roller"
+	"github.com/dymensionxyz/roller/utils/rotation"
+	sequencerutils "github.com/dymensionxyz/roller/utils/sequencer"
+	"github.com/dymensionxyz/roller/utils/tx"
+)
+
+const (
+	flagRole        = "role"
+	flagSuccessor   = "successor"
+	flagTimeout     = "timeout"
+	flagInterval    = "interval"
+	flagSkipRestart = "skip-restart"
+	flagYes         = "yes"
+)
+
+type options struct {
+	successor   string
+	interval    time.Duration
+	timeout     time.Duration
+	skipRestart bool
+	yes         bool
+	dryRun      bool
+}
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rotate",
+		Short: "Hand the proposer role of the rollapp over to another sequencer",
+		Long: `Hand the proposer role of the rollapp over to another sequencer.
+
+Run it on both hosts of the handover. The role is detected from the local
+sequencer address: the current proposer is the outgoing side, any other
+sequencer is the incoming side.
+
+The outgoing side checks that the successor is registered, bonded above the
+minimum bond, not jailed and opted in, then unbonds, which starts the notice
+period of the proposer. It refuses to unbond when the hub would select another
+sequencer than the successor, unless --yes is passed. Once the hub rotates to the successor, the local node
+is restarted as a full node.
+
+The incoming side opts in when needed, waits for the hub to select it as the
+proposer and restarts the local node as a sequencer.
+
+The notice period can last for days. Interrupting the command is safe, running
+it again resumes the handover where it stopped.
+`,
+		Example: `  roller rollapp sequencer rotate --successor dym1... --dry-run
+  roller rollapp sequencer rotate --role incoming --timeout 24h`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			home, err := filesystem.ExpandHomePath(cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String())
+			if err != nil {
+				return err
+			}
+			rollerData, err := roller.LoadConfig(home)
+			if err != nil {
+				return fmt.Errorf("failed to load roller config: %w", err)
+			}
+			if rollerData.HubData.ID == consts.MockHubID {
+				return fmt.Errorf("sequencer rotation is not available with the mock hub")
+			}
+
+			role, _ := cmd.Flags().GetString(flagRole)
+			opts := options{dryRun: initconfig.IsDryRun(cmd)}
+			opts.successor, _ = cmd.Flags().GetString(flagSuccessor)
+			opts.interval, _ = cmd.Flags().GetDuration(flagInterval)
+			opts.timeout, _ = cmd.Flags().GetDuration(flagTimeout)
+			opts.skipRestart, _ = cmd.Flags().GetBool(flagSkipRestart)
+			opts.yes, _ = cmd.Flags().GetBool(flagYes)
+
+			local, err := sequencerutils.GetHubSequencerAddress(rollerData)
+			if err != nil {
+				return fmt.Errorf("failed to retrieve the local sequencer address: %w", err)
+			}
+			proposer, err := rollapp.GetCurrentProposer(rollerData.RollappID, rollerData.HubData)
+			if err != nil {
+				return fmt.Errorf("failed to retrieve the current proposer: %w", err)
+			}
+
+			switch role {
+			case "":
+				role = rotation.RoleIncoming
+				if proposer == local {
+					role = rotation.RoleOutgoing
+				}
+				pterm.Info.Printf("the local sequencer %s is the %s side of the rotation\n", local, role)
+			case rotation.RoleOutgoing, rotation.RoleIncoming:
+			default:
+				return fmt.Errorf("unknown role %q, expected %s or %s", role, rotation.RoleOutgoing, rotation.RoleIncoming)
+			}
+
+			if role == rotation.RoleOutgoing {
+				return rotateOut(rollerData, local, proposer, opts)
+			}
+			return rotateIn(rollerData, local, proposer, opts)
+		},
+	}
+
+	cmd.Flags().String(flagRole, "", "side of the handover, outgoing or incoming (detected from the current proposer by default)")
+	cmd.Flags().String(flagSuccessor, "", "hub address of the sequencer taking over (outgoing side, defaults to the next proposer of the hub)")
+	cmd.Flags().Duration(flagTimeout, 0, "how long to wait for the proposer to change, 0 waits until it does")
+	cmd.Flags().Duration(flagInterval, 30*time.Second, "time between two queries of the proposer")
+	cmd.Flags().Bool(flagSkipRestart, false, "don't switch the node type and restart the local services")
+	cmd.Flags().BoolP(flagYes, "y", false, "automatically accept prompts")
+	initconfig.AddDryRunFlag(cmd)
+
+	return cmd
+}
+
+func rotateOut(rollerData roller.RollappConfig, local, proposer string, opts options) error {
+	raID, hd := rollerData.RollappID, rollerData.HubData
+
+	if proposer != local {
+		pterm.Info.Printf("the local sequencer is not the proposer, %s is\n", proposer)
+		return switchMode(rollerData, consts.NodeType.FullNode, opts)
+	}
+
+	next, err := rotation.GetNextProposer(raID, hd)
+	if err != nil {
+		pterm.Warning.Println("failed to retrieve the next proposer: ", err)
+	}
+
+	successor := opts.successor
+	if successor == "" {
+		if next == nil || next.NextProposerAddr == "" {
+			return fmt.Errorf("the hub has no next proposer, pass the successor with --%s", flagSuccessor)
+		}
+		successor = next.NextProposerAddr
+	}
+	if successor == local {
+		return fmt.Errorf("the successor can't be the local sequencer")
+	}
+
+	s, err := rotation.CheckSuccessor(raID, hd, successor)
+	if err != nil {
+		return err
+	}
+	pterm.Success.Printf("%s ( %s ) is eligible to take over, bond: %s\n", successor, s.Metadata.Moniker, s.Tokens)
+
+	mismatch := next != nil && next.NextProposerAddr != "" && next.NextProposerAddr != successor
+	if mismatch {
+		pterm.Warning.Printf(
+			"the hub will rotate to %s instead of %s, the sequencer with the highest bond is selected\n",
+			next.NextProposerAddr,
+			successor,
+		)
+	}
+
+	self, err := rotation.GetSequencer(raID, hd, local)
+	if err != nil {
+		return err
+	}
+
+	if rotation.InNoticePeriod(*self) {
+		pterm.Info.Printf("the notice period already started, it ends at %s\n", self.NoticePeriodTime.Local())
+	} else {
+		// once unbonded the rotation can't be redirected, so don't hand over
+		// to another sequencer than the expected one without consent
+		if mismatch && !opts.yes {
+			return fmt.Errorf(
+				"refusing to unbond: the hub would rotate to %s instead of %s, pass --%s to unbond anyway",
+				next.NextProposerAddr,
+				successor,
+				flagYes,
+			)
+		}
+
+		noticePeriod := "unknown"
+		if params, err := sequencerutils.GetSequencerParams(hd); err == nil && params.Params.NoticePeriod != "" {
+			noticePeriod = params.Params.NoticePeriod
+		}
+
+		pterm.Info.Printf(
+			"unbonding %s starts a notice period of %s, the hub rotates to the next proposer when it ends\n",
+			local,
+			noticePeriod,
+		)
+		if opts.dryRun {
+			plan.Record(plan.KindCommand, "unbond %s to start the notice period", local)
+		} else {
+			if !confirm(opts, "unbond the local sequencer and start the rotation?") {
+				return nil
+			}
+
+			txHash, err := rotation.Unbond(rollerData)
+			if err != nil {
+				return fmt.Errorf("failed to unbond: %w", err)
+			}
+			err = tx.MonitorTransaction(hd.WsUrl, txHash)
+			if err != nil {
+				return fmt.Errorf("failed to unbond: %w", err)
+			}
+		}
+	}
+
+	if opts.dryRun {
+		plan.Record(plan.KindCommand, "wait for the hub to rotate the proposer")
+		return switchMode(rollerData, consts.NodeType.FullNode, opts)
+	}
+
+	newProposer, err := waitForProposer(rollerData, opts, func(p string) bool { return p != local })
+	if err != nil {
+		return err
+	}
+	pterm.Success.Printf("the proposer is now %s\n", newProposer)
+
+	return switchMode(rollerData, consts.NodeType.FullNode, opts)
+}
+
+func rotateIn(rollerData roller.RollappConfig, local, proposer string, opts options) error {
+	raID, hd := rollerData.RollappID, rollerData.HubData
+
+	if proposer == local {
+		pterm.Info.Println("the local sequencer is already the proposer")
+		return switchMode(rollerData, consts.NodeType.Sequencer, opts)
+	}
+
+	self, err := rotation.GetSequencer(raID, hd, local)
+	if err != nil {
+		return err
+	}
+
+	if !self.OptedIn {
+		pterm.Info.Println("the local sequencer is not opted in, it can't be selected as proposer")
+		if opts.dryRun {
+			plan.Record(plan.KindCommand, "opt in %s", local)
+		} else {
+			if !confirm(opts, "opt in the local sequencer?") {
+				return nil
+			}
+
+			txHash, err := rotation.OptIn(rollerData)
+			if err != nil {
+				return fmt.Errorf("failed to opt in: %w", err)
+			}
+			err = tx.MonitorTransaction(hd.WsUrl, txHash)
+			if err != nil {
+				return fmt.Errorf("failed to opt in: %w", err)
+			}
+		}
+	}
+
+	// the opt in is not applied in a dry run, it is the only eligibility
+	// requirement it fulfills
+	if !opts.dryRun || self.OptedIn {
+		if _, err := rotation.CheckSuccessor(raID, hd, local); err != nil {
+			return err
+		}
+	}
+
+	next, err := rotation.GetNextProposer(raID, hd)
+	switch {
+	case err != nil:
+		pterm.Warning.Println("failed to retrieve the next proposer: ", err)
+	case next.NextProposerAddr != "" && next.NextProposerAddr != local:
+		pterm.Warning.Printf(
+			"the hub will rotate to %s, the local sequencer takes over only once it is the next proposer\n",
+			next.NextProposerAddr,
+		)
+	}
+
+	current, err := rotation.GetSequencer(raID, hd, proposer)
+	if err == nil && rotation.InNoticePeriod(*current) {
+		pterm.Info.Printf("the notice period of %s ends at %s\n", proposer, current.NoticePeriodTime.Local())
+	} else {
+		pterm.Info.Printf(
+			"the notice period of %s has not started, run `roller rollapp sequencer rotate` on its host\n",
+			proposer,
+		)
+	}
+
+	if opts.dryRun {
+		plan.Record(plan.KindCommand, "wait for the hub to rotate the proposer")
+		return switchMode(rollerData, consts.NodeType.Sequencer, opts)
+	}
+
+	_, err = waitForProposer(rollerData, opts, func(p string) bool { return p == local })
+	if err != nil {
+		return err
+	}
+	pterm.Success.Println("the local sequencer is now the proposer")
+
+	return switchMode(rollerData, consts.NodeType.Sequencer, opts)
+}
+
+func waitForProposer(rollerData roller.RollappConfig, opts options, done func(string) bool) (string, error) {
+	spinner, _ := pterm.DefaultSpinner.WithText("waiting for the hub to rotate the proposer").Start()
+	proposer, err := rotation.WaitForProposer(
+		rollerData.RollappID,
+		rollerData.HubData,
+		opts.interval,
+		opts.timeout,
+		done,
+	)
+	if err != nil {
+		spinner.Fail(err)
+		return "", err
+	}
+	spinner.Success()
+	return proposer, nil
+}
+
+func switchMode(rollerData roller.RollappConfig, nodeType string, opts options) error {
+	if opts.skipRestart {
+		return nil
+	}
+	if opts.dryRun {
+		plan.Record(plan.KindService, "run the local node as a %s and restart its services", nodeType)
+		return nil
+	}
+
+	if rollerData.NodeType != nodeType {
+		pterm.Info.Printf("switching the local node to %s\n", nodeType)
+	}
+	err := rotation.SwitchMode(rollerData, nodeType)
+	if err != nil {
+		return fmt.Errorf("failed to run the local node as a %s: %w", nodeType, err)
+	}
+	pterm.Success.Printf("the local node runs as a %s\n", nodeType)
+	return nil
+}
+
+func confirm(opts options, text string) bool {
+	if opts.yes {
+		return true
+	}
+	proceed, _ := pterm.DefaultInteractiveConfirm.WithDefaultText(text).Show()
+	return proceed
+}
@@ -4,6 +4,8 @@ import (
 	"github.com/dymensionxyz/roller/cmd/rollapp/sequencer/bond"
 	"github.com/dymensionxyz/roller/cmd/rollapp/sequencer/metadata"
 	"github.com/dymensionxyz/roller/cmd/rollapp/sequencer/rewards"
+	"github.com/dymensionxyz/roller/cmd/rollapp/sequencer/rotate"
+	"github.com/dymensionxyz/roller/cmd/rollapp/sequencer/signer"
 	"github.com/spf13/cobra"
 )
 
@@ -16,6 +18,8 @@ func Cmd() *cobra.Command {
 	cmd.AddCommand(metadata.Cmd())
 	cmd.AddCommand(rewards.Cmd())
 	cmd.AddCommand(bond.Cmd())
+	cmd.AddCommand(rotate.Cmd())
+	cmd.AddCommand(signer.Cmd())
 
 	return cmd
 }
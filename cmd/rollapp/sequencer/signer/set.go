@@ -0,0 +1,192 @@
+package signer
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/config/tomlconfig"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/plan"
+	"github.com/dymensionxyz/roller/utils/rollapp"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+const (
+	flagOperatorURL     = "operator-url"
+	flagOperatorAddress = "operator-address"
+	flagHeader          = "header"
+	flagConsensusLaddr  = "consensus-laddr"
+	flagConsensusPubKey = "consensus-pubkey"
+	flagReset           = "reset"
+)
+
+func SetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set",
+		Short: "Set the remote signer of the sequencer keys in roller.toml",
+		Long: `Set the remote signer of the sequencer keys in the Signer section of
+roller.toml, only the given flags are changed.
+
+Header values can reference environment variables, they are expanded when a
+transaction is signed. The consensus listen address is set as
+priv_validator_laddr of the rollapp node, restart the node for it to take
+effect. It is refused for rollapps older than DRS 9. The state updates of the sequencer are still signed by the dymint
+account of the rollapp keyring.`,
+		Example: `  roller rollapp sequencer signer set \
+    --operator-url https://signer.internal/sign \
+    --operator-address dym1... \
+    --header 'Authorization=Bearer ${SIGNER_TOKEN}'
+  roller rollapp sequencer signer set --consensus-laddr tcp://0.0.0.0:26659
+  roller rollapp sequencer signer set --reset`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				return err
+			}
+
+			rollerData, err := roller.LoadConfig(home)
+			if err != nil {
+				return fmt.Errorf("failed to load roller config file: %w", err)
+			}
+
+			s := rollerData.Signer
+			if reset, _ := cmd.Flags().GetBool(flagReset); reset {
+				s = roller.SignerConfig{}
+			}
+
+			flags := cmd.Flags()
+			if flags.Changed(flagOperatorURL) {
+				s.OperatorURL, _ = flags.GetString(flagOperatorURL)
+			}
+			if flags.Changed(flagOperatorAddress) {
+				s.OperatorAddress, _ = flags.GetString(flagOperatorAddress)
+			}
+			if flags.Changed(flagHeader) {
+				s.Headers, _ = flags.GetStringToString(flagHeader)
+			}
+			if flags.Changed(flagConsensusLaddr) {
+				s.ConsensusLaddr, _ = flags.GetString(flagConsensusLaddr)
+			}
+			if flags.Changed(flagConsensusPubKey) {
+				s.ConsensusPubKey, _ = flags.GetString(flagConsensusPubKey)
+			}
+
+			if err := validate(s); err != nil {
+				return err
+			}
+
+			laddrChanged := s.ConsensusLaddr != rollerData.Signer.ConsensusLaddr
+			if laddrChanged && s.ConsensusLaddr != "" {
+				if err := checkConsensusSignerSupport(rollerData); err != nil {
+					return err
+				}
+			}
+			if laddrChanged {
+				err := tomlconfig.UpdateFieldInFile(
+					filepath.Join(home, consts.ConfigDirName.Rollapp, "config", "config.toml"),
+					"priv_validator_laddr",
+					s.ConsensusLaddr,
+				)
+				if err != nil {
+					return fmt.Errorf("failed to update the rollapp node config: %w", err)
+				}
+			}
+
+			rollerData.Signer = s
+			if err := roller.WriteConfig(rollerData); err != nil {
+				return fmt.Errorf("failed to update roller config file: %w", err)
+			}
+			if plan.Enabled() {
+				return nil
+			}
+
+			pterm.Success.Println("remote signer updated")
+			if s.ConsensusLaddr != "" && s.ConsensusPubKey == "" {
+				pterm.Warning.Printf(
+					"set %s to register the sequencer with the consensus key of the remote signer\n",
+					"--"+flagConsensusPubKey,
+				)
+			}
+			if laddrChanged {
+				pterm.Info.Printf(
+					"restart the rollapp for the change to take effect, e.g. %s\n",
+					pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
+						Sprintf("roller rollapp services restart"),
+				)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagOperatorURL, "", "url of the signing service holding the operator key")
+	cmd.Flags().String(flagOperatorAddress, "", "hub address of the operator key held by the signing service")
+	cmd.Flags().StringToString(flagHeader, nil, "headers sent to the signing service, e.g. Authorization='Bearer ${TOKEN}'")
+	cmd.Flags().String(flagConsensusLaddr, "", "address the rollapp node listens on for the consensus key signer")
+	cmd.Flags().String(flagConsensusPubKey, "", "consensus public key of the signer, as printed by dymint show-sequencer")
+	cmd.Flags().Bool(flagReset, false, "remove the remote signer, the flags given with it are set afterwards")
+	initconfig.AddDryRunFlag(cmd)
+
+	return cmd
+}
+
+// checkConsensusSignerSupport refuses a consensus signer for rollapps whose DRS
+// ignores priv_validator_laddr, it only warns when the DRS can't be queried
+func checkConsensusSignerSupport(rollerData roller.RollappConfig) error {
+	drsVersion, err := rollapp.GetDrsVersionFromChain(rollerData.RollappID, rollerData.HubData)
+	if err != nil {
+		pterm.Warning.Printf(
+			"failed to get the drs version of %s, the consensus signer requires drs %d or later: %v\n",
+			rollerData.RollappID,
+			rollapp.MinDRSRemoteConsensusSigner,
+			err,
+		)
+		return nil
+	}
+
+	ok, err := rollapp.SupportsRemoteConsensusSigner(drsVersion)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf(
+			"%s runs drs %s, the consensus signer requires drs %d or later",
+			rollerData.RollappID,
+			drsVersion,
+			rollapp.MinDRSRemoteConsensusSigner,
+		)
+	}
+	return nil
+}
+
+func validate(s roller.SignerConfig) error {
+	if s.OperatorURL == "" {
+		if s.OperatorAddress != "" || len(s.Headers) > 0 {
+			return fmt.Errorf("--%s is required with the operator address and headers", flagOperatorURL)
+		}
+		return nil
+	}
+
+	u, err := url.Parse(s.OperatorURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid operator url %q, expected an http(s) url", s.OperatorURL)
+	}
+	if s.OperatorAddress == "" {
+		return fmt.Errorf("--%s is required with the operator url", flagOperatorAddress)
+	}
+	hrp, _, err := bech32.DecodeAndConvert(s.OperatorAddress)
+	if err != nil || hrp != consts.AddressPrefixes.Hub {
+		return fmt.Errorf("invalid operator address %q, expected a %s address", s.OperatorAddress, consts.AddressPrefixes.Hub)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package signer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/output"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+// Status is the signer configuration of the sequencer. Only the header names
+// are shown, their values usually hold credentials
+type Status struct {
+	KeyringBackend  string   `json:"keyring_backend"`
+	OperatorURL     string   `json:"operator_url,omitempty"`
+	OperatorAddress string   `json:"operator_address,omitempty"`
+	Headers         []string `json:"headers,omitempty"`
+	ConsensusLaddr  string   `json:"consensus_laddr,omitempty"`
+	ConsensusPubKey string   `json:"consensus_pubkey,omitempty"`
+}
+
+func ShowCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "show",
+		Short:        "Show the signers of the sequencer keys",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := initconfig.GetOutputFormat(cmd)
+			if err != nil {
+				return err
+			}
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				return err
+			}
+
+			rollerData, err := roller.LoadConfig(home)
+			if err != nil {
+				return fmt.Errorf("failed to load roller config file: %w", err)
+			}
+
+			s := rollerData.Signer
+			st := Status{
+				KeyringBackend:  string(rollerData.KeyringBackend),
+				OperatorURL:     s.OperatorURL,
+				OperatorAddress: s.OperatorAddress,
+				ConsensusLaddr:  s.ConsensusLaddr,
+				ConsensusPubKey: s.ConsensusPubKey,
+			}
+			for h := range s.Headers {
+				st.Headers = append(st.Headers, h)
+			}
+			sort.Strings(st.Headers)
+
+			if format.IsStructured() {
+				return output.Print(format, st)
+			}
+
+			operator := "local keyring (" + st.KeyringBackend + ")"
+			if s.HasRemoteOperator() {
+				operator = fmt.Sprintf("%s at %s", st.OperatorAddress, st.OperatorURL)
+			}
+			consensus := "local key of the rollapp node"
+			if st.ConsensusLaddr != "" {
+				consensus = "remote signer connecting to " + st.ConsensusLaddr
+			}
+
+			data := pterm.TableData{
+				{"operator key", operator},
+				{"consensus key", consensus},
+			}
+			if len(st.Headers) > 0 {
+				data = append(data, []string{"headers", strings.Join(st.Headers, ", ")})
+			}
+			if st.ConsensusPubKey != "" {
+				data = append(data, []string{"consensus pubkey", st.ConsensusPubKey})
+			}
+			return pterm.DefaultTable.WithData(data).Render()
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,28 @@
+package signer
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "signer [command]",
+		Short: "Configure the remote signer of the sequencer keys",
+		Long: `Configure the remote signer of the sequencer keys.
+
+The operator key of the sequencer can be held by an HTTP signing service
+instead of the local keyring. roller generates the hub transactions of the
+sequencer, e.g. bond, metadata and rotation transactions, posts them with the
+account number and sequence of the operator to the service and broadcasts the
+signed transaction it returns.
+
+The consensus key of the rollapp node can be held by a tmkms-style signer
+connecting to priv_validator_laddr of the node, this requires a DRS whose node
+supports a remote consensus signer.`,
+	}
+
+	cmd.AddCommand(SetCmd())
+	cmd.AddCommand(ShowCmd())
+
+	return cmd
+}
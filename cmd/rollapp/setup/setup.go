@@ -31,6 +31,7 @@ import (
 	"github.com/dymensionxyz/roller/utils/filesystem"
 	"github.com/dymensionxyz/roller/utils/genesis"
 	"github.com/dymensionxyz/roller/utils/keys"
+	"github.com/dymensionxyz/roller/utils/plan"
 	"github.com/dymensionxyz/roller/utils/rollapp"
 	"github.com/dymensionxyz/roller/utils/rollapp/iro"
 	"github.com/dymensionxyz/roller/utils/roller"
@@ -421,7 +422,7 @@ func Cmd() *cobra.Command {
 						dataDirNotEmpty, err := filesystem.DirNotEmpty(dataDir)
 						if err != nil {
 							pterm.Error.Printf("failed to check if data directory is empty: %v\n", err)
-							os.Exit(1)
+							plan.Exit(1)
 						}
 
 						// Check if wasm directory exists and is not empty
@@ -430,7 +431,7 @@ func Cmd() *cobra.Command {
 							wasmDirNotEmpty, err = filesystem.DirNotEmpty(wasmDir)
 							if err != nil {
 								pterm.Error.Printf("failed to check if wasm directory is empty: %v\n", err)
-								os.Exit(1)
+								plan.Exit(1)
 							}
 						}
 
@@ -514,7 +515,7 @@ func Cmd() *cobra.Command {
 							)
 							if err != nil {
 								spinner.Fail(fmt.Sprintf("error downloading file: %v", err))
-								os.Exit(1)
+								plan.Exit(1)
 							}
 							spinner.Success("file downloaded successfully")
 
@@ -591,12 +592,16 @@ func Cmd() *cobra.Command {
 
 				case consts.Avail:
 					// Initialize DAManager for Avail
-					damanager := datalayer.NewDAManager(
+					damanager, err := datalayer.NewDAManager(
 						consts.Avail,
 						home,
 						localRollerConfig.KeyringBackend,
 						localRollerConfig.NodeType,
 					)
+					if err != nil {
+						pterm.Error.Println("failed to initialize DA manager: ", err)
+						return
+					}
 
 					// Retrieve DA account address
 					daAddress, err := damanager.GetDAAccountAddress()
@@ -614,12 +619,16 @@ func Cmd() *cobra.Command {
 					}
 				case consts.LoadNetwork:
 					// Initialize DAManager for LoadNetwork
-					damanager := datalayer.NewDAManager(
+					damanager, err := datalayer.NewDAManager(
 						consts.LoadNetwork,
 						home,
 						localRollerConfig.KeyringBackend,
 						localRollerConfig.NodeType,
 					)
+					if err != nil {
+						pterm.Error.Println("failed to initialize DA manager: ", err)
+						return
+					}
 
 					// Retrieve DA account address
 					daAddress, err := damanager.GetDAAccountAddress()
@@ -637,12 +646,16 @@ func Cmd() *cobra.Command {
 					}
 				case consts.Bnb:
 					// Initialize DAManager for Bnb
-					damanager := datalayer.NewDAManager(
+					damanager, err := datalayer.NewDAManager(
 						consts.Bnb,
 						home,
 						localRollerConfig.KeyringBackend,
 						localRollerConfig.NodeType,
 					)
+					if err != nil {
+						pterm.Error.Println("failed to initialize DA manager: ", err)
+						return
+					}
 
 					// Retrieve DA account address
 					daAddress, err := damanager.GetDAAccountAddress()
@@ -660,12 +673,16 @@ func Cmd() *cobra.Command {
 					}
 				case consts.Sui:
 					// Initialize DAManager for Sui
-					damanager := datalayer.NewDAManager(
+					damanager, err := datalayer.NewDAManager(
 						consts.Sui,
 						home,
 						localRollerConfig.KeyringBackend,
 						localRollerConfig.NodeType,
 					)
+					if err != nil {
+						pterm.Error.Println("failed to initialize DA manager: ", err)
+						return
+					}
 
 					// Retrieve DA account address
 					daAddress, err := damanager.GetDAAccountAddress()
@@ -683,12 +700,16 @@ func Cmd() *cobra.Command {
 					}
 				case consts.Aptos:
 					// Initialize DAManager for Aptos
-					damanager := datalayer.NewDAManager(
+					damanager, err := datalayer.NewDAManager(
 						consts.Aptos,
 						home,
 						localRollerConfig.KeyringBackend,
 						localRollerConfig.NodeType,
 					)
+					if err != nil {
+						pterm.Error.Println("failed to initialize DA manager: ", err)
+						return
+					}
 
 					// Retrieve DA account address
 					daAddress, err := damanager.GetDAAccountAddress()
@@ -706,12 +727,16 @@ func Cmd() *cobra.Command {
 					}
 				case consts.Walrus:
 					// Initialize DAManager for Walrus
-					damanager := datalayer.NewDAManager(
+					damanager, err := datalayer.NewDAManager(
 						consts.Walrus,
 						home,
 						localRollerConfig.KeyringBackend,
 						localRollerConfig.NodeType,
 					)
+					if err != nil {
+						pterm.Error.Println("failed to initialize DA manager: ", err)
+						return
+					}
 
 					// Retrieve DA account address
 					daAddress, err := damanager.GetDAAccountAddress()
@@ -728,12 +753,16 @@ func Cmd() *cobra.Command {
 					}
 				case consts.Solana:
 					// Initialize DAManager for Solana
-					damanager := datalayer.NewDAManager(
+					damanager, err := datalayer.NewDAManager(
 						consts.Solana,
 						home,
 						localRollerConfig.KeyringBackend,
 						localRollerConfig.NodeType,
 					)
+					if err != nil {
+						pterm.Error.Println("failed to initialize DA manager: ", err)
+						return
+					}
 
 					// Retrieve DA account address
 					daAddress, err := damanager.GetDAAccountAddress()
@@ -750,12 +779,16 @@ func Cmd() *cobra.Command {
 					}
 				case consts.Ethereum:
 					// Initialize DAManager for Ethereum
-					damanager := datalayer.NewDAManager(
+					damanager, err := datalayer.NewDAManager(
 						consts.Ethereum,
 						home,
 						localRollerConfig.KeyringBackend,
 						localRollerConfig.NodeType,
 					)
+					if err != nil {
+						pterm.Error.Println("failed to initialize DA manager: ", err)
+						return
+					}
 					// Retrieve DA account address
 					daAddress, err := damanager.GetDAAccountAddress()
 					if err != nil {
@@ -772,12 +805,16 @@ func Cmd() *cobra.Command {
 					}
 				case consts.Kaspa:
 					// Initialize DAManager for Kaspa
-					damanager := datalayer.NewDAManager(
+					damanager, err := datalayer.NewDAManager(
 						consts.Kaspa,
 						home,
 						localRollerConfig.KeyringBackend,
 						localRollerConfig.NodeType,
 					)
+					if err != nil {
+						pterm.Error.Println("failed to initialize DA manager: ", err)
+						return
+					}
 
 					// Retrieve DA account address
 					daAddress, err := damanager.GetDAAccountAddress()
@@ -787,6 +824,37 @@ func Cmd() *cobra.Command {
 					}
 
 					// Append DA account address if available
+					if daAddress != nil {
+						addresses = append(addresses, keys.KeyInfo{
+							Name:    damanager.GetKeyName(),
+							Address: daAddress.Address,
+						})
+					}
+				case consts.Generic:
+					damanager, err := datalayer.NewDAManager(
+						consts.Generic,
+						home,
+						localRollerConfig.KeyringBackend,
+						localRollerConfig.NodeType,
+					)
+					if err != nil {
+						pterm.Error.Println("failed to initialize DA manager: ", err)
+						return
+					}
+
+					// the endpoint has to be configured before the address can be queried
+					_, err = damanager.InitializeLightNodeConfig()
+					if err != nil {
+						pterm.Error.Println("failed to initialize generic DA config: ", err)
+						return
+					}
+
+					daAddress, err := damanager.GetDAAccountAddress()
+					if err != nil {
+						pterm.Error.Println("failed to get generic DA account address: ", err)
+						return
+					}
+
 					if daAddress != nil {
 						addresses = append(addresses, keys.KeyInfo{
 							Name:    damanager.GetKeyName(),
@@ -800,12 +868,16 @@ func Cmd() *cobra.Command {
 				}
 			}
 
-			damanager := datalayer.NewDAManager(
+			damanager, err := datalayer.NewDAManager(
 				rollappConfig.DA.Backend,
 				rollappConfig.Home,
 				rollappConfig.KeyringBackend,
 				nodeType,
 			)
+			if err != nil {
+				pterm.Error.Println("failed to initialize DA manager: ", err)
+				return
+			}
 			if !skipDA {
 
 				daHome := filepath.Join(
@@ -1029,7 +1101,7 @@ func Cmd() *cobra.Command {
 			_ = tomlconfig.UpdateFieldInFile(
 				dymintConfigPath,
 				"da_layer",
-				getDaLayer(rollappConfig.RollappID, rollappConfig.HubData, raResponse, damanager.DaType),
+				getDaLayer(rollappConfig.RollappID, rollappConfig.HubData, raResponse, damanager.GetDymintDALayer()),
 			)
 
 			if !skipDA {
@@ -13,6 +13,7 @@ import (
 	"github.com/dymensionxyz/roller/cmd/consts"
 	"github.com/dymensionxyz/roller/utils/errorhandling"
 	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/plan"
 	"github.com/dymensionxyz/roller/utils/rollapp"
 	"github.com/dymensionxyz/roller/utils/roller"
 	servicemanager "github.com/dymensionxyz/roller/utils/service_manager"
@@ -94,7 +95,7 @@ func Cmd() *cobra.Command {
 				_, err := filesystem.DirNotEmpty(dataDir)
 				if err != nil {
 					pterm.Error.Printf("failed to check if data directory is empty: %v\n", err)
-					os.Exit(1)
+					plan.Exit(1)
 				}
 
 				timestamp := time.Now().Format("2006-01-02-15-04-06")
@@ -19,10 +19,13 @@ import (
 	"github.com/dymensionxyz/roller/sequencer"
 	"github.com/dymensionxyz/roller/utils/bash"
 	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/funding"
 	genesisutils "github.com/dymensionxyz/roller/utils/genesis"
 	"github.com/dymensionxyz/roller/utils/healthagent"
+	"github.com/dymensionxyz/roller/utils/keys"
 	"github.com/dymensionxyz/roller/utils/logging"
 	"github.com/dymensionxyz/roller/utils/migrations"
+	"github.com/dymensionxyz/roller/utils/plan"
 	"github.com/dymensionxyz/roller/utils/roller"
 	sequencerutils "github.com/dymensionxyz/roller/utils/sequencer"
 	"github.com/dymensionxyz/roller/utils/upgrades"
@@ -120,21 +123,16 @@ Consider using 'services' if you want to run a 'systemd'(unix) or 'launchd'(mac)
 				go healthagent.Start(home, rollappConfig.HealthAgent, rollerLogger)
 			}
 
+			if rollappConfig.HubData.ID != "mock" && rollappConfig.Funding.IsConfigured() {
+				go funding.Start(home, rollappConfig.Funding, rollerLogger)
+			}
+
 			done := make(chan error, 1)
 			// nolint: errcheck
-			if rollappConfig.KeyringBackend == consts.SupportedKeyringBackends.OS {
-				pswFileName, err := filesystem.GetOsKeyringPswFileName(
-					consts.Executables.RollappEVM,
-				)
+			if rollappConfig.KeyringBackend.RequiresPassphrase() {
+				psw, err := keys.ReadKeyringPassphrase(home, consts.Executables.RollappEVM)
 				if err != nil {
-					pterm.Error.Println("failed to get os keyring password file name: ", err)
-					return
-				}
-
-				fp := filepath.Join(home, string(pswFileName))
-				psw, err := filesystem.ReadFromFile(fp)
-				if err != nil {
-					pterm.Error.Println("failed to read os keyring password file: ", err)
+					pterm.Error.Println("failed to read keyring passphrase: ", err)
 					return
 				}
 
@@ -173,7 +171,7 @@ Consider using 'services' if you want to run a 'systemd'(unix) or 'launchd'(mac)
 				case err := <-done:
 					if err != nil {
 						pterm.Error.Println("rollapp's process returned an error: ", err)
-						os.Exit(1)
+						plan.Exit(1)
 					}
 				case <-ctx.Done():
 					pterm.Error.Println("context cancelled, terminating command")
@@ -257,7 +255,11 @@ func PrintOutput(
 
 	if isHealthy {
 		seqAddrData, err := sequencerutils.GetSequencerData(rlpCfg)
-		daManager := datalayer.NewDAManager(rlpCfg.DA.Backend, rlpCfg.Home, rlpCfg.KeyringBackend, rlpCfg.NodeType)
+		daManager, daErr := datalayer.NewDAManager(rlpCfg.DA.Backend, rlpCfg.Home, rlpCfg.KeyringBackend, rlpCfg.NodeType)
+		if daErr != nil {
+			pterm.Error.Println("failed to initialize DA manager: ", daErr)
+			return
+		}
 		daAddrData, errCel := daManager.GetDAAccData(rlpCfg)
 		if err != nil {
 			return
@@ -2,44 +2,144 @@ package status
 
 import (
 	"fmt"
+	"path/filepath"
+	"runtime"
 
+	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 
 	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/cmd/consts"
 	"github.com/dymensionxyz/roller/cmd/rollapp/start"
+	datalayer "github.com/dymensionxyz/roller/data_layer"
+	"github.com/dymensionxyz/roller/sequencer"
 	"github.com/dymensionxyz/roller/utils/dymint"
 	"github.com/dymensionxyz/roller/utils/healthagent"
+	"github.com/dymensionxyz/roller/utils/output"
 	"github.com/dymensionxyz/roller/utils/roller"
+	sequencerutils "github.com/dymensionxyz/roller/utils/sequencer"
 )
 
+type Status struct {
+	RollappID        string     `json:"rollapp_id" yaml:"rollapp_id"`
+	NodeType         string     `json:"node_type" yaml:"node_type"`
+	KeyringBackend   string     `json:"keyring_backend" yaml:"keyring_backend"`
+	NodeID           string     `json:"node_id" yaml:"node_id"`
+	Healthy          bool       `json:"healthy" yaml:"healthy"`
+	UnhealthyMessage string     `json:"unhealthy_message,omitempty" yaml:"unhealthy_message,omitempty"`
+	Endpoints        *Endpoints `json:"endpoints,omitempty" yaml:"endpoints,omitempty"`
+	RollappDir       string     `json:"rollapp_dir" yaml:"rollapp_dir"`
+	OS               string     `json:"os" yaml:"os"`
+	Arch             string     `json:"arch" yaml:"arch"`
+	SequencerBalance string     `json:"sequencer_balance,omitempty" yaml:"sequencer_balance,omitempty"`
+	DABalance        string     `json:"da_balance,omitempty" yaml:"da_balance,omitempty"`
+}
+
+type Endpoints struct {
+	EvmRPC  string `json:"evm_rpc,omitempty" yaml:"evm_rpc,omitempty"`
+	NodeRPC string `json:"node_rpc" yaml:"node_rpc"`
+	RestAPI string `json:"rest_api" yaml:"rest_api"`
+}
+
 func Cmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "status",
 		Short: "Show the status of the sequencer on the local machine.",
-		Run: func(cmd *cobra.Command, args []string) {
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
 			home := cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String()
+			format, err := initconfig.GetOutputFormat(cmd)
+			if err != nil {
+				return err
+			}
+
 			rollerConfig, err := roller.LoadConfig(home)
 			if err != nil {
-				fmt.Println("failed to load config:", err)
-				return
+				return fmt.Errorf("failed to load config: %w", err)
 			}
 
 			nodeID, err := dymint.GetNodeID(home)
 			if err != nil {
-				fmt.Println("failed to retrieve dymint node id:", err)
-				return
+				return fmt.Errorf("failed to retrieve dymint node id: %w", err)
+			}
+
+			ok, msg := healthagent.IsEndpointHealthy(roller.LocalRollappRPC() + "/health")
+
+			if format.IsStructured() {
+				return output.Print(format, getStatus(rollerConfig, nodeID, ok, msg))
 			}
 
-			ok, msg := healthagent.IsEndpointHealthy("http://localhost:26657/health")
 			if !ok {
 				// TODO: use options pattern, this is ugly af
 				start.PrintOutput(rollerConfig, true, false, true, false, nodeID)
 				fmt.Println("Unhealthy Message: ", msg)
-				return
+				return nil
 			}
 
 			start.PrintOutput(rollerConfig, true, true, true, true, nodeID)
+			return nil
 		},
 	}
 	return cmd
 }
+
+func getStatus(rlpCfg roller.RollappConfig, nodeID string, isHealthy bool, msg any) Status {
+	s := Status{
+		RollappID:      rlpCfg.RollappID,
+		NodeType:       rlpCfg.NodeType,
+		KeyringBackend: string(rlpCfg.KeyringBackend),
+		NodeID:         nodeID,
+		Healthy:        isHealthy,
+		RollappDir:     filepath.Join(rlpCfg.Home, consts.ConfigDirName.Rollapp),
+		OS:             runtime.GOOS,
+		Arch:           runtime.GOARCH,
+	}
+
+	if !isHealthy {
+		s.UnhealthyMessage = fmt.Sprint(msg)
+		return s
+	}
+
+	seq := sequencer.GetInstance(rlpCfg)
+	s.Endpoints = &Endpoints{
+		NodeRPC: fmt.Sprintf("http://0.0.0.0:%v", seq.RPCPort),
+		RestAPI: fmt.Sprintf("http://0.0.0.0:%v", seq.APIPort),
+	}
+	if rlpCfg.RollappVMType == consts.EVM_ROLLAPP {
+		s.Endpoints.EvmRPC = fmt.Sprintf("http://0.0.0.0:%v", seq.JsonRPCPort)
+	}
+
+	if rlpCfg.NodeType != consts.NodeType.Sequencer {
+		return s
+	}
+
+	seqAddrData, err := sequencerutils.GetSequencerData(rlpCfg)
+	if err != nil {
+		pterm.Warning.Println("failed to retrieve sequencer balance:", err)
+	} else if len(seqAddrData) > 0 {
+		s.SequencerBalance = seqAddrData[0].Balance.String()
+	}
+
+	if rlpCfg.HubData.ID != consts.MockHubID {
+		daManager, err := datalayer.NewDAManager(
+			rlpCfg.DA.Backend,
+			rlpCfg.Home,
+			rlpCfg.KeyringBackend,
+			rlpCfg.NodeType,
+		)
+		if err != nil {
+			pterm.Warning.Println("failed to initialize DA manager:", err)
+			return s
+		}
+
+		daAddrData, err := daManager.GetDAAccData(rlpCfg)
+		if err != nil {
+			pterm.Warning.Println("failed to retrieve DA balance:", err)
+		} else if len(daAddrData) > 0 {
+			s.DABalance = daAddrData[0].Balance.String()
+		}
+	}
+
+	return s
+}
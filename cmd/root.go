@@ -1,22 +1,34 @@
 package cmd
 
 import (
-	"os"
+	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	alertagent "github.com/dymensionxyz/roller/cmd/alert-agent"
+	"github.com/dymensionxyz/roller/cmd/binaries"
 	blockexplorer "github.com/dymensionxyz/roller/cmd/block-explorer"
 	"github.com/dymensionxyz/roller/cmd/config"
 	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	rollercontext "github.com/dymensionxyz/roller/cmd/context"
 	da_light_client "github.com/dymensionxyz/roller/cmd/da-light-client"
+	"github.com/dymensionxyz/roller/cmd/doctor"
 	"github.com/dymensionxyz/roller/cmd/eibc"
+	"github.com/dymensionxyz/roller/cmd/notify"
 	"github.com/dymensionxyz/roller/cmd/observability"
 	"github.com/dymensionxyz/roller/cmd/oracle"
 	"github.com/dymensionxyz/roller/cmd/relayer"
 	"github.com/dymensionxyz/roller/cmd/rollapp"
 	"github.com/dymensionxyz/roller/cmd/rollapp/keys"
+	"github.com/dymensionxyz/roller/cmd/runway"
 	"github.com/dymensionxyz/roller/cmd/version"
+	"github.com/dymensionxyz/roller/utils/config/backup"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/output"
+	"github.com/dymensionxyz/roller/utils/plan"
+	"github.com/dymensionxyz/roller/utils/roller"
+	"github.com/dymensionxyz/roller/utils/versions"
 )
 
 var rootCmd = &cobra.Command{
@@ -25,12 +37,67 @@ var rootCmd = &cobra.Command{
 	Long: `
 Roller CLI is a tool for registering and running autonomous RollApps built with Dymension RDK. Roller provides everything you need to scaffold, configure, register, and run your RollApp.
 	`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		format, err := initconfig.GetOutputFormat(cmd)
+		if err != nil {
+			return err
+		}
+
+		output.Configure(format)
+
+		if err := initconfig.ApplyProfile(cmd); err != nil {
+			cmd.SilenceUsage = true
+			return err
+		}
+
+		if err := configureVersions(cmd); err != nil {
+			cmd.SilenceUsage = true
+			return err
+		}
+
+		if initconfig.IsDryRun(cmd) {
+			plan.Enable(format)
+			return nil
+		}
+
+		if f := cmd.Flag(initconfig.GlobalFlagNames.Home); f != nil {
+			home, err := filesystem.ExpandHomePath(f.Value.String())
+			if err != nil {
+				return err
+			}
+			backup.Begin(home, strings.Join(append([]string{cmd.CommandPath()}, args...), " "))
+		}
+		return nil
+	},
+	PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
+		return plan.Flush()
+	},
+}
+
+// configureVersions selects the version source from the [Versions] section of
+// roller.toml, only the environment selects it before the rollapp is initialized
+func configureVersions(cmd *cobra.Command) error {
+	var cfg versions.Config
+	if f := cmd.Flag(initconfig.GlobalFlagNames.Home); f != nil {
+		home, err := filesystem.ExpandHomePath(f.Value.String())
+		if err != nil {
+			return err
+		}
+		if rollerData, err := roller.LoadConfig(home); err == nil {
+			cfg = rollerData.Versions
+		}
+	}
+
+	if err := versions.Configure(cfg); err != nil {
+		return fmt.Errorf("invalid version source: %w", err)
+	}
+	return nil
 }
 
 func Execute() {
 	err := rootCmd.Execute()
 	if err != nil {
-		os.Exit(1)
+		plan.Exit(1)
 	}
 }
 
@@ -46,6 +113,11 @@ func init() {
 	rootCmd.AddCommand(version.Cmd())
 	rootCmd.AddCommand(oracle.Cmd())
 	rootCmd.AddCommand(alertagent.Cmd())
+	rootCmd.AddCommand(doctor.Cmd())
+	rootCmd.AddCommand(notify.Cmd())
+	rootCmd.AddCommand(runway.Cmd())
+	rootCmd.AddCommand(binaries.Cmd())
+	rootCmd.AddCommand(rollercontext.Cmd())
 
 	initconfig.AddGlobalFlags(rootCmd)
 }
@@ -0,0 +1,20 @@
+package runway
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/dymensionxyz/roller/cmd/runway/status"
+	"github.com/dymensionxyz/roller/cmd/runway/topup"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "runway",
+		Short: "Commands to forecast and top up the balance of the operational wallets",
+	}
+
+	cmd.AddCommand(status.Cmd())
+	cmd.AddCommand(topup.Cmd())
+
+	return cmd
+}
@@ -0,0 +1,83 @@
+package status
+
+import (
+	"fmt"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/funding"
+	"github.com/dymensionxyz/roller/utils/output"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the balance and the forecasted runway of the operational wallets",
+		Long: `Query the balance of the sequencer, relayer, DA and eibc wallets set up on
+this machine, record it and forecast how long it lasts from the spending over
+the last week. The forecast needs balance samples at least an hour apart, they
+are recorded by every run of this command and by the funding agent started
+with the rollapp when the [Funding] section of roller.toml is set.
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			format, err := initconfig.GetOutputFormat(cmd)
+			if err != nil {
+				return err
+			}
+
+			home, err := filesystem.ExpandHomePath(cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String())
+			if err != nil {
+				return err
+			}
+			rollerData, err := roller.LoadConfig(home)
+			if err != nil {
+				return fmt.Errorf("failed to load roller config: %w", err)
+			}
+
+			spinner, _ := pterm.DefaultSpinner.WithRemoveWhenDone(true).Start("querying wallet balances")
+			wallets := funding.CollectWallets(home, rollerData)
+			funding.Forecast(home, wallets)
+			spinner.Stop()
+
+			if format.IsStructured() {
+				return output.Print(format, wallets)
+			}
+
+			Render(wallets)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func Render(wallets []funding.WalletRunway) {
+	if len(wallets) == 0 {
+		pterm.Info.Println("no operational wallets are set up on this machine")
+		return
+	}
+
+	data := pterm.TableData{{"Wallet", "Chain", "Address", "Balance", "Burn per day", "Runway"}}
+	for _, w := range wallets {
+		if w.Error != "" {
+			data = append(data, []string{w.Name, w.Chain, "-", pterm.Red("error: " + w.Error), "-", "-"})
+			continue
+		}
+
+		burn, left := "-", w.Forecast.String()
+		if w.Forecast.Known {
+			burn = fmt.Sprintf("%.0f%s", w.Forecast.BurnPerDay, w.Denom)
+			if w.Forecast.Days < 3 {
+				left = pterm.Red(left)
+			}
+		}
+		data = append(data, []string{w.Name, w.Chain, w.Address, w.Balance.String() + w.Denom, burn, left})
+	}
+	_ = pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+}
@@ -0,0 +1,144 @@
+package topup
+
+import (
+	"fmt"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/funding"
+	"github.com/dymensionxyz/roller/utils/output"
+	"github.com/dymensionxyz/roller/utils/plan"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+const flagYes = "yes"
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "top-up",
+		Short: "Top up the operational wallets from the treasury keys",
+		Long: `Top up the wallets of the [[Funding.wallets]] entries of roller.toml that
+crossed their min_balance or min_runway, from the treasury key of their chain
+set in [[Funding.treasuries]]. A top up aims for the largest of target_balance
+and target_runway, is capped by max_top_up and by the daily_budget of the
+treasury over the last 24 hours. Without a daily_budget, a wallet is topped up
+at most once in 24 hours.
+
+Hub wallets are funded from the hub treasury, the rollapp relayer from the
+rollapp treasury and the DA wallet from the da treasury, for celestia only.
+`,
+		Example: `  roller runway top-up --dry-run
+  roller runway top-up --yes`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			format, err := initconfig.GetOutputFormat(cmd)
+			if err != nil {
+				return err
+			}
+
+			home, err := filesystem.ExpandHomePath(cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String())
+			if err != nil {
+				return err
+			}
+			rollerData, err := roller.LoadConfig(home)
+			if err != nil {
+				return fmt.Errorf("failed to load roller config: %w", err)
+			}
+			if len(rollerData.Funding.Wallets) == 0 {
+				return fmt.Errorf("no wallets are configured in the [[Funding.wallets]] entries of roller.toml")
+			}
+
+			wallets := funding.CollectWallets(home, rollerData)
+			funding.Forecast(home, wallets)
+			topUps, err := funding.PlanTopUps(home, rollerData.Funding, wallets)
+			if err != nil {
+				return err
+			}
+
+			if initconfig.IsDryRun(cmd) {
+				// the top ups are printed with the dry run plan
+				for _, t := range topUps {
+					if t.Error != "" {
+						continue
+					}
+					plan.Record(
+						plan.KindCommand,
+						"send %s%s from the %s treasury to %s %s: %s",
+						t.Amount, t.Denom, t.Chain, t.Wallet, t.Address, t.Reason,
+					)
+				}
+				if !format.IsStructured() {
+					render(topUps)
+				}
+				return nil
+			}
+
+			if len(topUps) == 0 {
+				if format.IsStructured() {
+					return output.Print(format, topUps)
+				}
+				render(topUps)
+				return nil
+			}
+
+			if !format.IsStructured() {
+				render(topUps)
+			}
+			yes, _ := cmd.Flags().GetBool(flagYes)
+			if !yes {
+				if format.IsStructured() {
+					return fmt.Errorf("--yes is required to top up with structured output")
+				}
+				proceed, _ := pterm.DefaultInteractiveConfirm.WithDefaultText("send these top ups?").Show()
+				if !proceed {
+					return nil
+				}
+			}
+
+			topUps = funding.ExecuteTopUps(home, rollerData, topUps)
+			if format.IsStructured() {
+				return output.Print(format, topUps)
+			}
+
+			failed := 0
+			for _, t := range topUps {
+				if t.Error != "" {
+					failed++
+					pterm.Error.Printfln("%s: %s", t.Wallet, t.Error)
+					continue
+				}
+				pterm.Success.Printfln("%s: sent %s%s, %s", t.Wallet, t.Amount, t.Denom, t.TxHash)
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d of %d top ups failed", failed, len(topUps))
+			}
+			return nil
+		},
+	}
+
+	initconfig.AddDryRunFlag(cmd)
+	cmd.Flags().BoolP(flagYes, "y", false, "send the top ups without confirmation")
+
+	return cmd
+}
+
+func render(topUps []funding.TopUp) {
+	if len(topUps) == 0 {
+		pterm.Success.Println("no wallet needs a top up")
+		return
+	}
+
+	data := pterm.TableData{{"Wallet", "Chain", "Address", "Amount", "Reason"}}
+	for _, t := range topUps {
+		reason := t.Reason
+		if t.Error != "" {
+			reason = pterm.Red(t.Error)
+		}
+		data = append(data, []string{t.Wallet, t.Chain, t.Address, t.Amount.String() + t.Denom, reason})
+	}
+	_ = pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/dymensionxyz/roller/utils/config/scripts"
 	"github.com/dymensionxyz/roller/utils/errorhandling"
 	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/plan"
 	"github.com/dymensionxyz/roller/utils/roller"
 )
 
@@ -32,7 +33,9 @@ type Service struct {
 }
 
 type ServiceTemplateData struct {
-	Name             string
+	Name string
+	// Unit is the system service name, namespaced for the active profile
+	Unit             string
 	ExecPath         string
 	UserName         string
 	CustomRunCmd     []string
@@ -122,6 +125,9 @@ func writeLaunchctlServiceFile(serviceTxt *bytes.Buffer, serviceName string) err
 		"/Library/LaunchDaemons/",
 		fmt.Sprintf("xyz.dymension.roller.%s.plist", serviceName),
 	)
+	if plan.Enabled() {
+		return plan.WriteFile(filePath, serviceTxt.Bytes(), 0o644)
+	}
 	cmd := exec.Command(
 		"bash", "-c", fmt.Sprintf(
 			"echo '%s' | sudo tee %s",
@@ -142,6 +148,9 @@ func writeLaunchctlServiceFile(serviceTxt *bytes.Buffer, serviceName string) err
 
 func writeSystemdServiceFile(serviceTxt *bytes.Buffer, serviceName string) error {
 	filePath := filepath.Join("/etc/systemd/system/", fmt.Sprintf("%s.service", serviceName))
+	if plan.Enabled() {
+		return plan.WriteFile(filePath, serviceTxt.Bytes(), 0o644)
+	}
 	cmd := exec.Command(
 		"bash", "-c", fmt.Sprintf(
 			"echo '%s' | sudo tee %s",
@@ -168,13 +177,15 @@ func generateLaunchctlServiceTemplate(
 <plist version="1.0">
 <dict>
     <key>Label</key>
-    <string>xyz.dymension.roller.{{.Name}}</string>
+    <string>xyz.dymension.roller.{{.Unit}}</string>
 
     <key>ProgramArguments</key>
     <array>
         <string>{{.ExecPath}}</string>
         <string>{{.Name}}</string>
         <string>start</string>
+        <string>--home</string>
+        <string>{{.Home}}</string>
     </array>
 
     <key>RunAtLoad</key>
@@ -245,7 +256,7 @@ func generateCustomLaunchctlServiceTemplate(
 <plist version="1.0">
 <dict>
     <key>Label</key>
-    <string>xyz.dymension.roller.{{.Name}}</string>
+    <string>xyz.dymension.roller.{{.Unit}}</string>
 
     <key>ProgramArguments</key>
     <array>
@@ -316,7 +327,7 @@ func generateSystemdServiceTemplate(serviceData ServiceTemplateData) (*bytes.Buf
 	switch serviceData.Name {
 	case "rng", "price":
 		tmpl = `[Unit]
-Description=Roller {{.Name}} service
+Description=Roller {{.Unit}} service
 After=network.target
 
 [Service]
@@ -337,7 +348,7 @@ WantedBy=multi-user.target
 `
 	case "rollapp":
 		tmpl = `[Unit]
-Description=Roller {{.Name}} service
+Description=Roller {{.Unit}} service
 After=network.target
 
 [Service]
@@ -358,7 +369,7 @@ WantedBy=multi-user.target
 `
 	default:
 		tmpl = `[Unit]
-Description=Roller {{.Name}} service
+Description=Roller {{.Unit}} service
 After=network.target
 
 [Service]
@@ -395,20 +406,26 @@ func LoadMacOsServices(services []string, rollerData roller.RollappConfig) error
 	for _, service := range services {
 		serviceData := ServiceTemplateData{
 			Name:     service,
+			Unit:     roller.ServiceName(service),
 			ExecPath: consts.Executables.Roller,
 			UserName: os.Getenv("USER"),
+			Home:     rollerData.Home,
 		}
 
 		var tpl *bytes.Buffer
 		var err error
 
 		if service == "da-light-client" {
-			damanager := datalayer.NewDAManager(
+			damanager, err := datalayer.NewDAManager(
 				rollerData.DA.Backend,
 				rollerData.Home,
 				rollerData.KeyringBackend,
 				rollerData.NodeType,
 			)
+			if err != nil {
+				pterm.Error.Println("failed to initialize DA manager: ", err)
+				return err
+			}
 			c := damanager.GetStartDACmd()
 
 			// during the development of ~v1.6.4 there was an issue running
@@ -428,7 +445,7 @@ func LoadMacOsServices(services []string, rollerData roller.RollappConfig) error
 			}
 		}
 
-		err = writeLaunchctlServiceFile(tpl, service)
+		err = writeLaunchctlServiceFile(tpl, serviceData.Unit)
 		if err != nil {
 			pterm.Error.Println("failed to write launchctl file", err)
 			return err
@@ -452,6 +469,7 @@ func LoadLinuxServices(services []string, rollerData roller.RollappConfig) error
 	for _, service := range services {
 		serviceData := ServiceTemplateData{
 			Name:     service,
+			Unit:     roller.ServiceName(service),
 			ExecPath: consts.Executables.Roller,
 			UserName: usr.Username,
 			Home:     rollerData.Home,
@@ -468,7 +486,7 @@ func LoadLinuxServices(services []string, rollerData roller.RollappConfig) error
 
 		tpl, err := generateSystemdServiceTemplate(serviceData)
 		errorhandling.PrettifyErrorIfExists(err)
-		err = writeSystemdServiceFile(tpl, service)
+		err = writeSystemdServiceFile(tpl, serviceData.Unit)
 		errorhandling.PrettifyErrorIfExists(err)
 	}
 
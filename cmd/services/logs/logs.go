@@ -2,7 +2,6 @@ package logs
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
 	"time"
 
@@ -12,6 +11,7 @@ import (
 	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
 	"github.com/dymensionxyz/roller/cmd/consts"
 	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/plan"
 	"github.com/dymensionxyz/roller/utils/roller"
 )
 
@@ -72,7 +72,7 @@ func RollappCmd() *cobra.Command {
 			select {
 			case err := <-errChan:
 				pterm.Error.Println(err)
-				os.Exit(1)
+				plan.Exit(1)
 			default:
 				select {}
 			}
@@ -122,7 +122,7 @@ func RelayerCmd() *cobra.Command {
 			select {
 			case err := <-errChan:
 				pterm.Error.Println(err)
-				os.Exit(1)
+				plan.Exit(1)
 			default:
 				select {}
 			}
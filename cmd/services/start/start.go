@@ -19,6 +19,7 @@ import (
 	"github.com/dymensionxyz/roller/utils/errorhandling"
 	"github.com/dymensionxyz/roller/utils/filesystem"
 	"github.com/dymensionxyz/roller/utils/migrations"
+	"github.com/dymensionxyz/roller/utils/plan"
 	"github.com/dymensionxyz/roller/utils/roller"
 	sequencerutils "github.com/dymensionxyz/roller/utils/sequencer"
 	servicemanager "github.com/dymensionxyz/roller/utils/service_manager"
@@ -398,5 +399,5 @@ func cacheKaspaMnemonic(home, mnemonic string) error {
 		return err
 	}
 	content := fmt.Sprintf("%s=%s\n", kaspa.MnemonicEnvVar, mnemonic)
-	return os.WriteFile(envPath, []byte(content), 0o600)
+	return plan.WriteFile(envPath, []byte(content), 0o600)
 }
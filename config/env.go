@@ -5,9 +5,22 @@ import (
 )
 
 type EnvConfig struct {
-	RollappCommit       string `env:"ROLLER_RA_COMMIT"`
-	RollappGenesis      string `env:"ROLLER_RA_GENESIS"`
-	SkipCelestiaBinary  bool   `env:"ROLLER_SKIP_CELESTIA_BINARY"`
+	RollappCommit      string `env:"ROLLER_RA_COMMIT"`
+	RollappGenesis     string `env:"ROLLER_RA_GENESIS"`
+	SkipCelestiaBinary bool   `env:"ROLLER_SKIP_CELESTIA_BINARY"`
+	// AllowUnverifiedBinaries allows installing release artifacts that have
+	// neither a recorded digest nor a published checksums file
+	AllowUnverifiedBinaries bool `env:"ROLLER_ALLOW_UNVERIFIED_BINARIES"`
+	// VersionSource and VersionsManifest override the [Versions] section of
+	// roller.toml, setting a manifest selects the manifest source
+	VersionSource    string `env:"ROLLER_VERSION_SOURCE"`
+	VersionsManifest string `env:"ROLLER_VERSIONS_MANIFEST"`
+	// KeyringBackend overrides the keyring backend selected for the environment
+	KeyringBackend string `env:"ROLLER_KEYRING_BACKEND"`
+	// KeyringPassphrase and KeyringPassphraseCommand are used instead of the
+	// passphrase files of the os and file keyrings
+	KeyringPassphrase        string `env:"ROLLER_KEYRING_PASSPHRASE"`
+	KeyringPassphraseCommand string `env:"ROLLER_KEYRING_PASSPHRASE_COMMAND"`
 }
 
 var Config EnvConfig
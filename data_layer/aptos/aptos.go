@@ -155,6 +155,11 @@ func (a *Aptos) GetStartDACmd() *exec.Cmd {
 }
 
 func (a *Aptos) GetDAAccData(cfg roller.RollappConfig) ([]keys.AccountData, error) {
+	acc, err := a.getAccount()
+	if err != nil {
+		return nil, err
+	}
+
 	balance, err := a.getBalance()
 	if err != nil {
 		return nil, err
@@ -162,7 +167,7 @@ func (a *Aptos) GetDAAccData(cfg roller.RollappConfig) ([]keys.AccountData, erro
 
 	return []keys.AccountData{
 		{
-			Address: a.PrivateKey,
+			Address: acc.Address.String(),
 			Balance: cosmossdktypes.Coin{
 				Denom:  consts.Denoms.Aptos,
 				Amount: math.NewIntFromUint64(balance),
@@ -194,6 +199,10 @@ func (a *Aptos) GetStatus(c roller.RollappConfig) string {
 	return "Active"
 }
 
+func (a *Aptos) IsHealthy(c roller.RollappConfig) bool {
+	return true
+}
+
 func (a *Aptos) GetKeyName() string {
 	return "aptos"
 }
@@ -206,27 +215,37 @@ func (a *Aptos) GetAppID() uint32 {
 	return 0
 }
 
-func (a *Aptos) getBalance() (uint64, error) {
-	var client *aptos.Client
-	var err error
-
-	if a.Network == "mainnet" {
-		client, err = aptos.NewClient(aptos.MainnetConfig)
-		if err != nil {
-			return 0, err
-		}
+func (a *Aptos) getClient() (*aptos.Client, error) {
+	var cfg aptos.NetworkConfig
+	if a.Network == string(consts.AptosMainnet) {
+		cfg = aptos.MainnetConfig
 	} else {
-		client, err = aptos.NewClient(aptos.TestnetConfig)
-		if err != nil {
-			return 0, err
-		}
+		cfg = aptos.TestnetConfig
 	}
+
+	if a.RpcEndpoint != "" {
+		cfg.NodeUrl = a.RpcEndpoint
+	}
+
+	return aptos.NewClient(cfg)
+}
+
+func (a *Aptos) getAccount() (*aptos.Account, error) {
 	key := crypto.Ed25519PrivateKey{}
-	err = key.FromHex(a.PrivateKey)
+	err := key.FromHex(a.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return aptos.NewAccountFromSigner(&key)
+}
+
+func (a *Aptos) getBalance() (uint64, error) {
+	client, err := a.getClient()
 	if err != nil {
 		return 0, err
 	}
-	acc, err := aptos.NewAccountFromSigner(&key)
+	acc, err := a.getAccount()
 	if err != nil {
 		return 0, err
 	}
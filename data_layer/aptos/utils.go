@@ -7,6 +7,7 @@ import (
 	"github.com/pelletier/go-toml"
 
 	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/plan"
 )
 
 func writeConfigToTOML(path string, a Aptos) error {
@@ -16,11 +17,11 @@ func writeConfigToTOML(path string, a Aptos) error {
 	}
 	dir := filepath.Dir(path)
 	// nolint:gofumpt
-	if err := os.MkdirAll(dir, 0o755); err != nil {
+	if err := plan.MkdirAll(dir, 0o755); err != nil {
 		return err
 	}
 	// nolint:gofumpt
-	err = os.WriteFile(path, tomlBytes, 0o644)
+	err = plan.WriteFile(path, tomlBytes, 0o644)
 	if err != nil {
 		return err
 	}
@@ -45,3 +46,9 @@ func LoadConfigFromTOML(path string) (Aptos, error) {
 func GetCfgFilePath(rollerHome string) string {
 	return filepath.Join(rollerHome, consts.ConfigDirName.DALightNode, ConfigFileName)
 }
+
+// WriteConfig persists the aptos DA config in the roller home. NewAptos loads an
+// existing config without prompting, so this can be used to pre-seed it
+func WriteConfig(rollerHome string, cfg Aptos) error {
+	return writeConfigToTOML(GetCfgFilePath(rollerHome), cfg)
+}
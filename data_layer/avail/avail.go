@@ -263,6 +263,10 @@ func (a *Avail) GetStatus(c roller.RollappConfig) string {
 	return "Active"
 }
 
+func (a *Avail) IsHealthy(c roller.RollappConfig) bool {
+	return true
+}
+
 func (a *Avail) GetKeyName() string {
 	return "avail"
 }
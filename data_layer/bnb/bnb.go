@@ -8,6 +8,9 @@ import (
 	"math/big"
 	"os/exec"
 
+	cosmossdkmath "cosmossdk.io/math"
+	cosmossdktypes "github.com/cosmos/cosmos-sdk/types"
+
 	"github.com/dymensionxyz/roller/cmd/consts"
 	"github.com/dymensionxyz/roller/utils/errorhandling"
 	"github.com/dymensionxyz/roller/utils/keys"
@@ -156,7 +159,19 @@ func (b *Bnb) GetStartDACmd() *exec.Cmd {
 }
 
 func (b *Bnb) GetDAAccData(cfg roller.RollappConfig) ([]keys.AccountData, error) {
-	return nil, nil
+	balance, err := b.getBalance()
+	if err != nil {
+		return nil, err
+	}
+	return []keys.AccountData{
+		{
+			Address: b.Address,
+			Balance: cosmossdktypes.Coin{
+				Denom:  consts.Denoms.Bnb,
+				Amount: cosmossdkmath.NewIntFromBigInt(balance),
+			},
+		},
+	}, nil
 }
 
 func (b *Bnb) GetSequencerDAConfig(_ string) string {
@@ -184,6 +199,10 @@ func (b *Bnb) GetStatus(c roller.RollappConfig) string {
 	return "Active"
 }
 
+func (b *Bnb) IsHealthy(c roller.RollappConfig) bool {
+	return true
+}
+
 func (b *Bnb) GetKeyName() string {
 	return "bnb"
 }
@@ -9,9 +9,9 @@ import (
 	"math/big"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 
-	cosmossdkmath "cosmossdk.io/math"
 	cosmossdktypes "github.com/cosmos/cosmos-sdk/types"
 	"github.com/pterm/pterm"
 	"gopkg.in/yaml.v2"
@@ -59,6 +59,14 @@ type BalanceResponse struct {
 }
 
 func (c *Celestia) GetStatus(rlpCfg roller.RollappConfig) string {
+	if c.IsHealthy(rlpCfg) {
+		return "active"
+	}
+	return "Stopped, Restarting..."
+}
+
+// IsHealthy reports whether the light node answers a balance query
+func (c *Celestia) IsHealthy(rlpCfg roller.RollappConfig) bool {
 	args := []string{
 		"state",
 		"balance",
@@ -67,23 +75,11 @@ func (c *Celestia) GetStatus(rlpCfg roller.RollappConfig) string {
 	}
 	output, err := exec.Command(consts.Executables.Celestia, args...).Output()
 	if err != nil {
-		return "Stopped, Restarting..."
+		return false
 	}
 
 	var resp BalanceResponse
-	err = json.Unmarshal(output, &resp)
-	if err != nil {
-		return "Stopped, Restarting..."
-	}
-
-	if resp.Result.Amount != cosmossdkmath.NewInt(0) {
-		return "active"
-	}
-	// if strings.TrimSpace(resp.Result.Amount) != 0 {
-	// 	return "active"
-	// }
-
-	return "Stopped, Restarting..."
+	return json.Unmarshal(output, &resp) == nil
 }
 
 func (c *Celestia) GetRootDirectory() string {
@@ -138,7 +134,7 @@ func (c *Celestia) InitializeLightNodeConfig() (string, error) {
 		return "", err
 	}
 
-	if c.KeyringBackend == consts.SupportedKeyringBackends.OS {
+	if c.KeyringBackend.RequiresPassphrase() && !keys.HasPassphraseSource(c.Root) {
 		pterm.Info.Println("creating keyring passphrase file")
 		err := keys.CreateDaOsKeyringPswFile(c.Root)
 		if err != nil {
@@ -165,6 +161,19 @@ func (c *Celestia) InitializeLightNodeConfig() (string, error) {
 		return "", err
 	}
 
+	if rpcPort := roller.ProfilePort(consts.DefaultPorts.DARPC); rpcPort != consts.DefaultPorts.DARPC {
+		err = tomlconfig.UpdateFieldsInFile(
+			filepath.Join(c.Root, consts.ConfigDirName.DALightNode, "config.toml"),
+			map[string]interface{}{
+				"RPC.Port":     strconv.Itoa(rpcPort),
+				"Gateway.Port": strconv.Itoa(roller.ProfilePort(consts.DefaultPorts.DAGateway)),
+			},
+		)
+		if err != nil {
+			return "", fmt.Errorf("failed to set light client ports: %w", err)
+		}
+	}
+
 	mnemonic := extractMnemonic(out.String())
 
 	return mnemonic, nil
@@ -30,12 +30,15 @@ func Initialize(env string, rollerData roller.RollappConfig) (*keys.KeyInfo, err
 		kb := rollerData.KeyringBackend
 
 		pterm.Info.Println("initializing da light node configuration")
-		damanager := datalayer.NewDAManager(
+		damanager, err := datalayer.NewDAManager(
 			rollerData.DA.Backend,
 			rollerData.Home,
 			kb,
 			rollerData.NodeType,
 		)
+		if err != nil {
+			return nil, err
+		}
 		mnemonic, err := damanager.InitializeLightNodeConfig()
 		if err != nil {
 			return nil, err
@@ -0,0 +1,632 @@
+package datalayer_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	datalayer "github.com/dymensionxyz/roller/data_layer"
+	"github.com/dymensionxyz/roller/data_layer/aptos"
+	"github.com/dymensionxyz/roller/data_layer/avail"
+	"github.com/dymensionxyz/roller/data_layer/bnb"
+	"github.com/dymensionxyz/roller/data_layer/celestia"
+	"github.com/dymensionxyz/roller/data_layer/damock"
+	"github.com/dymensionxyz/roller/data_layer/ethereum"
+	"github.com/dymensionxyz/roller/data_layer/generic"
+	"github.com/dymensionxyz/roller/data_layer/kaspa"
+	"github.com/dymensionxyz/roller/data_layer/loadnetwork"
+	"github.com/dymensionxyz/roller/data_layer/solana"
+	"github.com/dymensionxyz/roller/data_layer/sui"
+	"github.com/dymensionxyz/roller/data_layer/walrus"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+const (
+	testEvmPrivateKey   = "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+	testAptosPrivateKey = "0x9bf49a6a0755f953811fce125f2683d50429c3bb49e074147e0089a52eae155f"
+	testSuiAddress      = "0x6f2d5e80dd21cb2c87c80b227d662642c688090dc81adbd9c4ae1fe889dfaf71"
+	testAddress         = "conformance-test-address"
+)
+
+// fundedBalance is above the minimum balance required by every backend, in
+// the backend's smallest unit
+var fundedBalance = new(big.Int).Mul(big.NewInt(5), new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil))
+
+// fakeDA is a local stand-in for a backend's RPC. The balance it reports and
+// whether it fails can be changed while the backend under test is using it.
+// Backends queried through their CLI read the same state from stateDir
+type fakeDA struct {
+	mu       sync.Mutex
+	balance  *big.Int
+	fail     bool
+	requests int
+	stateDir string
+}
+
+func (f *fakeDA) set(balance *big.Int, fail bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.balance = balance
+	f.fail = fail
+	f.writeState()
+}
+
+// writeState mirrors the state into stateDir for the fake CLIs
+func (f *fakeDA) writeState() {
+	if f.stateDir == "" {
+		return
+	}
+	_ = os.WriteFile(filepath.Join(f.stateDir, "balance"), []byte(f.balance.String()), 0o644)
+	failFile := filepath.Join(f.stateDir, "fail")
+	if f.fail {
+		_ = os.WriteFile(failFile, nil, 0o644)
+	} else {
+		_ = os.Remove(failFile)
+	}
+}
+
+func (f *fakeDA) requestCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.requests
+}
+
+func (f *fakeDA) get() (*big.Int, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return new(big.Int).Set(f.balance), f.fail
+}
+
+// serve wraps a backend specific handler so that a failing fake answers
+// every request with an internal error
+func (f *fakeDA) serve(h func(w http.ResponseWriter, r *http.Request, balance *big.Int)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		f.requests++
+		f.mu.Unlock()
+
+		balance, fail := f.get()
+		if fail {
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		h(w, r, balance)
+	})
+}
+
+// jsonRPC answers JSON-RPC 2.0 requests with the result of the matching
+// method, unknown methods get a method not found error
+func jsonRPC(methods map[string]func(balance *big.Int) any) func(http.ResponseWriter, *http.Request, *big.Int) {
+	return func(w http.ResponseWriter, r *http.Request, balance *big.Int) {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		resp := map[string]any{"jsonrpc": "2.0", "id": req.ID}
+		if m, ok := methods[req.Method]; ok {
+			resp["result"] = m(balance)
+		} else {
+			resp["error"] = map[string]any{"code": -32601, "message": "method not found"}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}
+}
+
+func ethHandler() func(http.ResponseWriter, *http.Request, *big.Int) {
+	return jsonRPC(map[string]func(*big.Int) any{
+		"eth_getBalance": func(b *big.Int) any { return "0x" + b.Text(16) },
+	})
+}
+
+// fakeCLI installs a shell script in dir as the executable at bin for the
+// duration of the test. The script reads the fake state from dir
+func fakeCLI(t *testing.T, dir string, bin *string, script string) {
+	t.Helper()
+	path := filepath.Join(dir, filepath.Base(*bin))
+	script = strings.ReplaceAll(script, "$STATE", dir)
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	prev := *bin
+	*bin = path
+	t.Cleanup(func() { *bin = prev })
+}
+
+// walrusHandler serves the blob API of a walrus publisher and aggregator
+func walrusHandler(w http.ResponseWriter, r *http.Request, _ *big.Int) {
+	switch {
+	case r.Method == http.MethodPut && r.URL.Path == "/v1/blobs":
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"newlyCreated": map[string]any{"blobObject": map[string]any{"blobId": "conformance"}},
+		})
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/v1/blobs/"):
+		_, _ = w.Write([]byte("blob"))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+type backendCase struct {
+	daType consts.DAType
+	// handler serves the backend's RPC protocol, nil when the backend does
+	// not talk to an RPC roller can fake
+	handler func(http.ResponseWriter, *http.Request, *big.Int)
+	// cli installs fake CLIs for backends queried through their binaries,
+	// they read the fake state from dir
+	cli func(t *testing.T, dir string)
+	// seed writes the backend config pointing at the fake into the roller
+	// home and returns the DataLayer NewDAManager is expected to load
+	seed func(home, url string) (datalayer.DataLayer, error)
+	// checksBalance is set when CheckDABalance queries the RPC
+	checksBalance bool
+	// reportsAccData is set when GetDAAccData queries the RPC
+	reportsAccData bool
+	// endpointInDAConfig is set when the dymint DA config carries the endpoint
+	endpointInDAConfig bool
+	// skipBalance explains why balance queries can't run offline, only set
+	// when neither an RPC nor a CLI fake can stand in for the chain
+	skipBalance string
+}
+
+func backendCases() []backendCase {
+	return []backendCase{
+		{
+			daType: consts.Celestia,
+			seed: func(home, _ string) (datalayer.DataLayer, error) {
+				// the light node RPC port is read from the light node config
+				cfgPath := filepath.Join(home, consts.ConfigDirName.DALightNode, "config.toml")
+				if err := os.MkdirAll(filepath.Dir(cfgPath), 0o755); err != nil {
+					return nil, err
+				}
+				if err := os.WriteFile(cfgPath, []byte("[RPC]\n  Port = \"26658\"\n"), 0o644); err != nil {
+					return nil, err
+				}
+				return celestia.NewCelestia(home, consts.SupportedKeyringBackends.Test), nil
+			},
+			cli: func(t *testing.T, dir string) {
+				fakeCLI(t, dir, &consts.Executables.CelKey,
+					`echo '{"name":"my_celes_key","address":"celestia1conformance","pubkey":"pk"}'`)
+				fakeCLI(t, dir, &consts.Executables.CelestiaApp, `
+if [ -e "$STATE/fail" ]; then echo "rpc error" >&2; exit 1; fi
+printf '{"balances":[{"denom":"utia","amount":"%s"}]}' "$(cat "$STATE/balance")"
+`)
+				fakeCLI(t, dir, &consts.Executables.Celestia, `
+if [ "$2" = "auth" ]; then echo "conformance-token"; exit 0; fi
+if [ -e "$STATE/fail" ]; then echo "rpc error" >&2; exit 1; fi
+printf '{"result":{"denom":"utia","amount":"%s"}}' "$(cat "$STATE/balance")"
+`)
+			},
+			checksBalance:  true,
+			reportsAccData: true,
+		},
+		{
+			daType: consts.Avail,
+			seed: func(home, url string) (datalayer.DataLayer, error) {
+				cfg := avail.Avail{
+					Root:        home,
+					Mnemonic:    "test test test",
+					AccAddress:  testAddress,
+					RpcEndpoint: url,
+					AppID:       1,
+				}
+				return &cfg, avail.WriteConfig(home, cfg)
+			},
+			checksBalance:      true,
+			reportsAccData:     true,
+			endpointInDAConfig: true,
+			// the avail SDK decodes the SCALE encoded runtime metadata of the
+			// node before any storage query, a fake would have to replay the
+			// metadata of a specific avail runtime
+			skipBalance: "avail balances are read through the runtime metadata of a live avail node",
+		},
+		{
+			daType: consts.Aptos,
+			handler: func(w http.ResponseWriter, r *http.Request, balance *big.Int) {
+				if r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, "/view") {
+					http.NotFound(w, r)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode([]string{balance.String()})
+			},
+			seed: func(home, url string) (datalayer.DataLayer, error) {
+				cfg := aptos.Aptos{
+					Root:        home,
+					PrivateKey:  testAptosPrivateKey,
+					RpcEndpoint: url,
+					Network:     string(consts.AptosTestnet),
+				}
+				return &cfg, aptos.WriteConfig(home, cfg)
+			},
+			reportsAccData: true,
+		},
+		{
+			daType: consts.Sui,
+			handler: jsonRPC(map[string]func(*big.Int) any{
+				"suix_getBalance": func(b *big.Int) any {
+					return map[string]any{
+						"coinType":        "0x2::sui::SUI",
+						"coinObjectCount": 1,
+						"totalBalance":    b.String(),
+						"lockedBalance":   map[string]any{},
+					}
+				},
+			}),
+			seed: func(home, url string) (datalayer.DataLayer, error) {
+				cfg := sui.Sui{
+					Root:                home,
+					Mnemonic:            "test test test",
+					Address:             testSuiAddress,
+					NoopContractAddress: sui.NoopContractAddressTestnet,
+					RpcEndpoint:         url,
+					ChainID:             sui.DefaultTestnetChainID,
+				}
+				return &cfg, sui.WriteConfig(home, cfg)
+			},
+			checksBalance:      true,
+			endpointInDAConfig: true,
+		},
+		{
+			daType:  consts.LoadNetwork,
+			handler: ethHandler(),
+			seed: func(home, url string) (datalayer.DataLayer, error) {
+				cfg := loadnetwork.LoadNetwork{
+					Root:        home,
+					PrivateKey:  testEvmPrivateKey,
+					RpcEndpoint: url,
+					ChainID:     loadnetwork.DefaultTestnetChainID,
+				}
+				return &cfg, loadnetwork.WriteConfig(home, cfg)
+			},
+			reportsAccData:     true,
+			endpointInDAConfig: true,
+		},
+		{
+			daType:  consts.Bnb,
+			handler: ethHandler(),
+			seed: func(home, url string) (datalayer.DataLayer, error) {
+				cfg := bnb.Bnb{
+					Root:        home,
+					PrivateKey:  testEvmPrivateKey,
+					Address:     testAddress,
+					RpcEndpoint: url,
+					ChainID:     97,
+				}
+				return &cfg, bnb.WriteConfig(home, cfg)
+			},
+			reportsAccData:     true,
+			endpointInDAConfig: true,
+		},
+		{
+			// blobs are paid for by the publisher, the blob owner address
+			// holds no balance roller checks
+			daType:  consts.Walrus,
+			handler: walrusHandler,
+			seed: func(home, url string) (datalayer.DataLayer, error) {
+				cfg := walrus.Walrus{
+					Root:       home,
+					Address:    testAddress,
+					Publisher:  url,
+					Aggregator: url,
+				}
+				return &cfg, walrus.WriteConfig(home, cfg)
+			},
+			endpointInDAConfig: true,
+		},
+		{
+			daType: consts.Solana,
+			handler: jsonRPC(map[string]func(*big.Int) any{
+				"getBalance": func(b *big.Int) any {
+					return map[string]any{"context": map[string]any{"slot": 1}, "value": b.Uint64()}
+				},
+			}),
+			seed: func(home, url string) (datalayer.DataLayer, error) {
+				cfg := solana.Solana{
+					Root:        home,
+					Address:     testAddress,
+					RpcEndpoint: url,
+				}
+				return &cfg, solana.WriteConfig(home, cfg)
+			},
+			checksBalance:      true,
+			reportsAccData:     true,
+			endpointInDAConfig: true,
+		},
+		{
+			daType: consts.Kaspa,
+			handler: func(w http.ResponseWriter, r *http.Request, balance *big.Int) {
+				if r.URL.Path != fmt.Sprintf("/addresses/%s/balance", testAddress) {
+					http.NotFound(w, r)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(map[string]any{
+					"address": testAddress,
+					"balance": balance.Uint64(),
+				})
+			},
+			seed: func(home, url string) (datalayer.DataLayer, error) {
+				cfg := kaspa.Kaspa{
+					Root:        home,
+					Address:     testAddress,
+					GrpcAddress: "127.0.0.1:16210",
+					Network:     "kaspa-testnet-10",
+					ApiUrl:      url,
+				}
+				return &cfg, kaspa.WriteConfig(home, cfg)
+			},
+			checksBalance:      true,
+			endpointInDAConfig: true,
+		},
+		{
+			daType: consts.Local,
+			seed: func(_, _ string) (datalayer.DataLayer, error) {
+				return &damock.DAMock{}, nil
+			},
+		},
+		{
+			daType:  consts.Ethereum,
+			handler: ethHandler(),
+			seed: func(home, url string) (datalayer.DataLayer, error) {
+				cfg := ethereum.Ethereum{
+					Root:        home,
+					PrivateKey:  testEvmPrivateKey,
+					Address:     testAddress,
+					RpcEndpoint: url,
+					ApiEndpoint: url,
+					GasLimit:    100000,
+					ChainID:     11155111,
+				}
+				return &cfg, ethereum.WriteConfig(home, cfg)
+			},
+			reportsAccData:     true,
+			endpointInDAConfig: true,
+		},
+		{
+			daType: consts.Generic,
+			handler: jsonRPC(map[string]func(*big.Int) any{
+				"state.AccountAddress": func(*big.Int) any { return testAddress },
+				"header.NetworkHead":   func(*big.Int) any { return map[string]any{"height": "1"} },
+				"state.Balance": func(b *big.Int) any {
+					return map[string]any{"denom": "utia", "amount": b.String()}
+				},
+			}),
+			seed: func(home, url string) (datalayer.DataLayer, error) {
+				cfg := generic.Generic{
+					Root:          home,
+					RpcEndpoint:   url,
+					NamespaceID:   "0000000000000000000000000000000000000000000000000000000000",
+					GasPrices:     generic.DefaultGasPrices,
+					GasAdjustment: generic.DefaultGasAdjustment,
+					KeyName:       generic.DefaultKeyName,
+				}
+				return &cfg, generic.WriteConfig(home, cfg)
+			},
+			checksBalance:      true,
+			reportsAccData:     true,
+			endpointInDAConfig: true,
+		},
+	}
+}
+
+func TestDataLayerConformance(t *testing.T) {
+	for _, bc := range backendCases() {
+		t.Run(string(bc.daType), func(t *testing.T) {
+			runConformance(t, bc)
+		})
+	}
+}
+
+func TestNewDAManagerUnknownType(t *testing.T) {
+	_, err := datalayer.NewDAManager("unknown", t.TempDir(), consts.SupportedKeyringBackends.Test, "sequencer")
+	if err == nil {
+		t.Fatal("expected an error for an unknown DA type")
+	}
+}
+
+func TestRegisterConstructor(t *testing.T) {
+	injected := &damock.DAMock{}
+	prev := datalayer.RegisterConstructor(
+		consts.Avail,
+		func(string, consts.SupportedKeyringBackend, string) datalayer.DataLayer {
+			return injected
+		},
+	)
+	defer datalayer.RegisterConstructor(consts.Avail, prev)
+
+	dm, err := datalayer.NewDAManager(consts.Avail, t.TempDir(), consts.SupportedKeyringBackends.Test, "sequencer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dm.DataLayer != injected {
+		t.Fatal("NewDAManager did not use the registered constructor")
+	}
+}
+
+func runConformance(t *testing.T, bc backendCase) {
+	fake := &fakeDA{balance: fundedBalance}
+	if bc.cli != nil {
+		fake.stateDir = t.TempDir()
+		fake.writeState()
+		bc.cli(t, fake.stateDir)
+	}
+	url := "http://127.0.0.1:1"
+	if bc.handler != nil {
+		srv := httptest.NewServer(fake.serve(bc.handler))
+		t.Cleanup(srv.Close)
+		url = srv.URL
+	}
+
+	home := t.TempDir()
+	rlpCfg := roller.RollappConfig{
+		Home:           home,
+		KeyringBackend: consts.SupportedKeyringBackends.Test,
+		NodeType:       consts.NodeType.Sequencer,
+		HubData:        consts.HubData{Environment: "playground"},
+		DA: consts.DaData{
+			Backend: bc.daType,
+			RpcUrl:  url,
+			ApiUrl:  url,
+		},
+	}
+	if err := roller.WriteConfig(rlpCfg); err != nil {
+		t.Fatal(err)
+	}
+
+	expected, err := bc.seed(home, url)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newDA := func(t *testing.T) *datalayer.DAManager {
+		t.Helper()
+		dm, err := datalayer.NewDAManager(bc.daType, home, rlpCfg.KeyringBackend, rlpCfg.NodeType)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return dm
+	}
+
+	t.Run("ConfigRoundTrip", func(t *testing.T) {
+		dm := newDA(t)
+		if !reflect.DeepEqual(dm.DataLayer, expected) {
+			t.Fatalf("loaded config differs from the seeded one:\n got: %+v\nwant: %+v", dm.DataLayer, expected)
+		}
+		if root := dm.GetRootDirectory(); root != "" && root != home {
+			t.Fatalf("root directory %q, want %q", root, home)
+		}
+	})
+
+	t.Run("GetSequencerDAConfig", func(t *testing.T) {
+		dm := newDA(t)
+		daCfg := dm.GetSequencerDAConfig(consts.NodeType.Sequencer)
+		if daCfg == "" {
+			return
+		}
+		if !json.Valid([]byte(daCfg)) {
+			t.Fatalf("dymint DA config is not valid JSON: %s", daCfg)
+		}
+		if bc.endpointInDAConfig && !strings.Contains(daCfg, url) {
+			t.Fatalf("dymint DA config does not use the configured endpoint %s: %s", url, daCfg)
+		}
+		if again := newDA(t).GetSequencerDAConfig(consts.NodeType.Sequencer); bc.daType != consts.Celestia && again != daCfg {
+			t.Fatalf("dymint DA config is not stable across loads:\n%s\n%s", daCfg, again)
+		}
+	})
+
+	t.Run("GetStatus", func(t *testing.T) {
+		if status := newDA(t).GetStatus(rlpCfg); status == "" {
+			t.Fatal("empty status")
+		}
+	})
+
+	t.Run("IsHealthy", func(t *testing.T) {
+		if !newDA(t).IsHealthy(rlpCfg) {
+			t.Fatal("DA backed by a reachable fake is reported unhealthy")
+		}
+	})
+
+	t.Run("CheckDABalance", func(t *testing.T) {
+		if bc.skipBalance != "" {
+			t.Skip(bc.skipBalance)
+		}
+		if !bc.checksBalance {
+			before := fake.requestCount()
+			nf, err := newDA(t).CheckDABalance()
+			if err != nil || len(nf) != 0 {
+				t.Fatalf("expected no balance requirements, got %v, %v", nf, err)
+			}
+			if fake.requestCount() != before {
+				t.Fatal("balance check queried the RPC without reporting a requirement")
+			}
+			return
+		}
+
+		fake.set(fundedBalance, false)
+		nf, err := newDA(t).CheckDABalance()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(nf) != 0 {
+			t.Fatalf("funded account reported as not funded: %+v", nf)
+		}
+
+		fake.set(big.NewInt(0), false)
+		nf, err = newDA(t).CheckDABalance()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(nf) != 1 {
+			t.Fatalf("expected one not funded address, got %+v", nf)
+		}
+		if nf[0].Address == "" || nf[0].CurrentBalance == nil || nf[0].RequiredBalance == nil {
+			t.Fatalf("incomplete not funded address data: %+v", nf[0])
+		}
+		if nf[0].CurrentBalance.Sign() != 0 || nf[0].RequiredBalance.Sign() <= 0 {
+			t.Fatalf("unexpected balances: current %s, required %s", nf[0].CurrentBalance, nf[0].RequiredBalance)
+		}
+
+		fake.set(fundedBalance, true)
+		if _, err := newDA(t).CheckDABalance(); err == nil {
+			t.Fatal("expected an error when the RPC fails")
+		}
+	})
+
+	t.Run("GetDAAccData", func(t *testing.T) {
+		if bc.skipBalance != "" {
+			t.Skip(bc.skipBalance)
+		}
+		if !bc.reportsAccData {
+			before := fake.requestCount()
+			data, err := newDA(t).GetDAAccData(rlpCfg)
+			if err != nil || len(data) != 0 {
+				t.Fatalf("expected no account data, got %v, %v", data, err)
+			}
+			if fake.requestCount() != before {
+				t.Fatal("account data lookup queried the RPC without reporting an account")
+			}
+			return
+		}
+
+		fake.set(fundedBalance, false)
+		dm := newDA(t)
+		data, err := dm.GetDAAccData(rlpCfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(data) != 1 {
+			t.Fatalf("expected one account, got %+v", data)
+		}
+		if data[0].Address == "" {
+			t.Fatal("empty account address")
+		}
+		if pk, _ := dm.GetPrivateKey(); pk != "" && data[0].Address == pk {
+			t.Fatal("account address is the private key")
+		}
+		if got := data[0].Balance.Amount.BigInt(); got.Cmp(fundedBalance) != 0 {
+			t.Fatalf("balance %s, want %s", got, fundedBalance)
+		}
+
+		fake.set(fundedBalance, true)
+		if _, err := newDA(t).GetDAAccData(rlpCfg); err == nil {
+			t.Fatal("expected an error when the RPC fails")
+		}
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/dymensionxyz/roller/data_layer/celestia"
 	"github.com/dymensionxyz/roller/data_layer/damock"
 	"github.com/dymensionxyz/roller/data_layer/ethereum"
+	"github.com/dymensionxyz/roller/data_layer/generic"
 	"github.com/dymensionxyz/roller/data_layer/kaspa"
 	loadnetwork "github.com/dymensionxyz/roller/data_layer/loadnetwork"
 	"github.com/dymensionxyz/roller/data_layer/solana"
@@ -33,6 +34,9 @@ type DataLayer interface {
 	SetMetricsEndpoint(endpoint string)
 	GetNetworkName() string
 	GetStatus(c roller.RollappConfig) string
+	// IsHealthy reports whether the DA can be used, GetStatus describes it for
+	// display only
+	IsHealthy(c roller.RollappConfig) bool
 	GetKeyName() string
 	GetPrivateKey() (string, error)
 	GetRootDirectory() string
@@ -45,45 +49,98 @@ type DAManager struct {
 	DataLayer
 }
 
-func NewDAManager(datype consts.DAType, home string, kb consts.SupportedKeyringBackend, nodeType string) *DAManager {
-	var dalayer DataLayer
-
-	switch datype {
-	case consts.Celestia:
-		dalayer = celestia.NewCelestia(home, kb)
-	case consts.Avail:
-		dalayer = avail.NewAvail(home)
-	case consts.Aptos:
-		dalayer = aptos.NewAptos(home)
-	case consts.Sui:
-		dalayer = sui.NewSui(home)
-	case consts.LoadNetwork:
-		dalayer = loadnetwork.NewLoadNetwork(home)
-	case consts.Bnb:
-		dalayer = bnb.NewBnb(home)
-	case consts.Walrus:
-		dalayer = walrus.NewWalrus(home)
-	case consts.Solana:
-		dalayer = solana.NewSolana(home)
-	case consts.Kaspa:
-		dalayer = kaspa.NewKaspa(home)
-	case consts.Local:
-		dalayer = &damock.DAMock{}
-	case consts.Ethereum:
-		dalayer = ethereum.NewEthereum(home)
-	default:
-		panic("Unknown data layer type " + string(datype))
+// Constructor builds the DataLayer of a DA type for the roller home
+type Constructor func(home string, kb consts.SupportedKeyringBackend, nodeType string) DataLayer
+
+var constructors = map[consts.DAType]Constructor{
+	consts.Celestia: func(home string, kb consts.SupportedKeyringBackend, _ string) DataLayer {
+		return celestia.NewCelestia(home, kb)
+	},
+	consts.Avail: func(home string, _ consts.SupportedKeyringBackend, _ string) DataLayer {
+		return avail.NewAvail(home)
+	},
+	consts.Aptos: func(home string, _ consts.SupportedKeyringBackend, _ string) DataLayer {
+		return aptos.NewAptos(home)
+	},
+	consts.Sui: func(home string, _ consts.SupportedKeyringBackend, _ string) DataLayer {
+		return sui.NewSui(home)
+	},
+	consts.LoadNetwork: func(home string, _ consts.SupportedKeyringBackend, _ string) DataLayer {
+		return loadnetwork.NewLoadNetwork(home)
+	},
+	consts.Bnb: func(home string, _ consts.SupportedKeyringBackend, _ string) DataLayer {
+		return bnb.NewBnb(home)
+	},
+	consts.Walrus: func(home string, _ consts.SupportedKeyringBackend, _ string) DataLayer {
+		return walrus.NewWalrus(home)
+	},
+	consts.Solana: func(home string, _ consts.SupportedKeyringBackend, _ string) DataLayer {
+		return solana.NewSolana(home)
+	},
+	consts.Kaspa: func(home string, _ consts.SupportedKeyringBackend, _ string) DataLayer {
+		return kaspa.NewKaspa(home)
+	},
+	consts.Local: func(_ string, _ consts.SupportedKeyringBackend, _ string) DataLayer {
+		return &damock.DAMock{}
+	},
+	consts.Ethereum: func(home string, _ consts.SupportedKeyringBackend, _ string) DataLayer {
+		return ethereum.NewEthereum(home)
+	},
+	consts.Generic: func(home string, _ consts.SupportedKeyringBackend, _ string) DataLayer {
+		return generic.NewGeneric(home)
+	},
+}
+
+// RegisterConstructor replaces the constructor NewDAManager uses for a DA
+// type and returns the previous one, so a fake backend can be injected and
+// restored afterwards
+func RegisterConstructor(datype consts.DAType, c Constructor) Constructor {
+	prev := constructors[datype]
+	if c == nil {
+		delete(constructors, datype)
+	} else {
+		constructors[datype] = c
+	}
+
+	return prev
+}
+
+func NewDAManager(
+	datype consts.DAType,
+	home string,
+	kb consts.SupportedKeyringBackend,
+	nodeType string,
+) (*DAManager, error) {
+	newDataLayer, ok := constructors[datype]
+	if !ok {
+		return nil, fmt.Errorf("unknown data layer type: %s", datype)
 	}
 
 	return &DAManager{
 		DaType:    datype,
-		DataLayer: dalayer,
+		DataLayer: newDataLayer(home, kb, nodeType),
+	}, nil
+}
+
+// GetDymintDALayer returns the da_layer value dymint should be configured
+// with. The generic backend speaks the celestia DA JSON-RPC, so dymint uses
+// its celestia client for it
+func (d *DAManager) GetDymintDALayer() consts.DAType {
+	if d.DaType == consts.Generic {
+		return consts.Celestia
 	}
+
+	return d.DaType
 }
 
 func GetDaInfo(env, daBackend string) (*consts.DaData, error) {
 	var daNetwork string
 
+	if daBackend == string(consts.Generic) {
+		daData := consts.DaNetworks[string(consts.GenericDA)]
+		return &daData, nil
+	}
+
 	switch env {
 	case "playground", "blumbus":
 		switch daBackend {
@@ -20,6 +20,10 @@ func (d *DAMock) GetStatus(c roller.RollappConfig) string {
 	return "Running local DA"
 }
 
+func (d *DAMock) IsHealthy(c roller.RollappConfig) bool {
+	return true
+}
+
 func (d *DAMock) GetRootDirectory() string {
 	return ""
 }
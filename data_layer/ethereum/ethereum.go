@@ -1,9 +1,16 @@
 package ethereum
 
 import (
+	"context"
 	"fmt"
+	"math/big"
 	"os/exec"
 
+	cosmossdkmath "cosmossdk.io/math"
+	cosmossdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+
 	"github.com/dymensionxyz/roller/cmd/consts"
 	"github.com/dymensionxyz/roller/utils/errorhandling"
 	"github.com/dymensionxyz/roller/utils/keys"
@@ -113,7 +120,29 @@ func (e *Ethereum) GetStartDACmd() *exec.Cmd {
 }
 
 func (e *Ethereum) GetDAAccData(cfg roller.RollappConfig) ([]keys.AccountData, error) {
-	return nil, nil
+	balance, err := e.getBalance()
+	if err != nil {
+		return nil, err
+	}
+	return []keys.AccountData{
+		{
+			Address: e.Address,
+			Balance: cosmossdktypes.Coin{
+				Denom:  consts.Denoms.Ethereum,
+				Amount: cosmossdkmath.NewIntFromBigInt(balance),
+			},
+		},
+	}, nil
+}
+
+func (e *Ethereum) getBalance() (*big.Int, error) {
+	client, err := ethclient.Dial(e.RpcEndpoint)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	return client.BalanceAt(context.Background(), common.HexToAddress(e.Address), nil)
 }
 
 func (e *Ethereum) GetSequencerDAConfig(_ string) string {
@@ -142,6 +171,10 @@ func (e *Ethereum) GetStatus(c roller.RollappConfig) string {
 	return "Active"
 }
 
+func (e *Ethereum) IsHealthy(c roller.RollappConfig) bool {
+	return true
+}
+
 func (e *Ethereum) GetKeyName() string {
 	return "ethereum"
 }
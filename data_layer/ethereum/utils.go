@@ -1,12 +1,12 @@
 package ethereum
 
 import (
-	"os"
 	"path/filepath"
 
 	"github.com/pelletier/go-toml"
 
 	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/plan"
 )
 
 func writeConfigToTOML(path string, e Ethereum) error {
@@ -15,10 +15,10 @@ func writeConfigToTOML(path string, e Ethereum) error {
 		return err
 	}
 	dir := filepath.Dir(path)
-	if err := os.MkdirAll(dir, 0o755); err != nil {
+	if err := plan.MkdirAll(dir, 0o755); err != nil {
 		return err
 	}
-	err = os.WriteFile(path, tomlBytes, 0o644)
+	err = plan.WriteFile(path, tomlBytes, 0o644)
 	if err != nil {
 		return err
 	}
@@ -28,7 +28,7 @@ func writeConfigToTOML(path string, e Ethereum) error {
 
 func loadConfigFromTOML(path string) (Ethereum, error) {
 	var config Ethereum
-	tomlBytes, err := os.ReadFile(path)
+	tomlBytes, err := plan.ReadFile(path)
 	if err != nil {
 		return config, err
 	}
@@ -43,3 +43,9 @@ func loadConfigFromTOML(path string) (Ethereum, error) {
 func GetCfgFilePath(rollerHome string) string {
 	return filepath.Join(rollerHome, consts.ConfigDirName.DALightNode, ConfigFileName)
 }
+
+// WriteConfig persists the ethereum DA config in the roller home. NewEthereum loads an
+// existing config without prompting, so this can be used to pre-seed it
+func WriteConfig(rollerHome string, cfg Ethereum) error {
+	return writeConfigToTOML(GetCfgFilePath(rollerHome), cfg)
+}
@@ -0,0 +1,272 @@
+package generic
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os/exec"
+	"strconv"
+	"time"
+
+	cosmossdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pterm/pterm"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/keys"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+const (
+	ConfigFileName       = "generic.toml"
+	DefaultGasPrices     = 0.02
+	DefaultGasAdjustment = 1.3
+	DefaultKeyName       = "generic_da_key"
+)
+
+var minBalance = big.NewInt(1)
+
+// Generic is a DA backend for any endpoint that speaks the celestia-node
+// compatible DA JSON-RPC used by dymint. The DA node itself is not managed by
+// roller, only its endpoint is configured
+type Generic struct {
+	Root          string  `toml:"root"`
+	RpcEndpoint   string  `toml:"rpc_endpoint"`
+	AuthToken     string  `toml:"auth_token"`
+	NamespaceID   string  `toml:"namespace_id"`
+	GasPrices     float64 `toml:"gas_prices"`
+	GasAdjustment float64 `toml:"gas_adjustment"`
+	Denom         string  `toml:"denom"`
+	KeyName       string  `toml:"key_name"`
+}
+
+// NewGeneric loads the generic DA configuration from the roller home. When
+// the configuration file does not exist yet, it is populated from the DA
+// section of roller.toml and written on InitializeLightNodeConfig
+func NewGeneric(root string) *Generic {
+	cfg, err := loadConfigFromTOML(GetCfgFilePath(root))
+	if err == nil {
+		cfg.Root = root
+		return &cfg
+	}
+
+	cfg = Generic{
+		Root:          root,
+		GasPrices:     DefaultGasPrices,
+		GasAdjustment: DefaultGasAdjustment,
+		KeyName:       DefaultKeyName,
+	}
+
+	rollerData, err := roller.LoadConfig(root)
+	if err == nil {
+		cfg.RpcEndpoint = rollerData.DA.RpcUrl
+		if gp, err := strconv.ParseFloat(rollerData.DA.GasPrice, 64); err == nil {
+			cfg.GasPrices = gp
+		}
+	}
+
+	return &cfg
+}
+
+func (g *Generic) GetPrivateKey() (string, error) {
+	return "", fmt.Errorf("the generic DA key is managed by the DA node")
+}
+
+func (g *Generic) SetMetricsEndpoint(endpoint string) {
+}
+
+func (g *Generic) InitializeLightNodeConfig() (string, error) {
+	if g.RpcEndpoint == "" {
+		g.RpcEndpoint, _ = pterm.DefaultInteractiveTextInput.WithDefaultText(
+			"> Enter the DA JSON-RPC endpoint",
+		).Show()
+	}
+
+	if g.AuthToken == "" {
+		g.AuthToken, _ = pterm.DefaultInteractiveTextInput.WithDefaultText(
+			"> Enter the DA JSON-RPC auth token (leave empty if not required)",
+		).WithMask("*").Show()
+	}
+
+	if g.NamespaceID == "" {
+		nID, err := generateRandNamespaceID()
+		if err != nil {
+			return "", err
+		}
+		g.NamespaceID = nID
+	}
+
+	err := writeConfigToTOML(GetCfgFilePath(g.Root), *g)
+	if err != nil {
+		return "", err
+	}
+
+	return "", nil
+}
+
+func (g *Generic) GetDAAccountAddress() (*keys.KeyInfo, error) {
+	var address string
+	err := g.call("state.AccountAddress", &address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &keys.KeyInfo{
+		Name:    g.GetKeyName(),
+		Address: address,
+	}, nil
+}
+
+func (g *Generic) getBalance() (*cosmossdktypes.Coin, error) {
+	var balance cosmossdktypes.Coin
+	err := g.call("state.Balance", &balance)
+	if err != nil {
+		return nil, err
+	}
+
+	return &balance, nil
+}
+
+func (g *Generic) GetRootDirectory() string {
+	return g.Root
+}
+
+func (g *Generic) CheckDABalance() ([]keys.NotFundedAddressData, error) {
+	accData, err := g.GetDAAccData(roller.RollappConfig{})
+	if err != nil {
+		return nil, err
+	}
+
+	var insufficientBalances []keys.NotFundedAddressData
+	if accData[0].Balance.Amount.BigInt().Cmp(minBalance) < 0 {
+		insufficientBalances = append(insufficientBalances, keys.NotFundedAddressData{
+			KeyName:         g.GetKeyName(),
+			Address:         accData[0].Address,
+			CurrentBalance:  accData[0].Balance.Amount.BigInt(),
+			RequiredBalance: minBalance,
+			Denom:           accData[0].Balance.Denom,
+			Network:         g.GetNetworkName(),
+		})
+	}
+
+	return insufficientBalances, nil
+}
+
+// GetStartDACmd returns nil, the generic DA node is not run by roller
+func (g *Generic) GetStartDACmd() *exec.Cmd {
+	return nil
+}
+
+func (g *Generic) GetDAAccData(_ roller.RollappConfig) ([]keys.AccountData, error) {
+	ki, err := g.GetDAAccountAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := g.getBalance()
+	if err != nil {
+		return nil, err
+	}
+
+	if g.Denom != "" && balance.Denom == "" {
+		balance.Denom = g.Denom
+	}
+
+	return []keys.AccountData{
+		{
+			Address: ki.Address,
+			Balance: *balance,
+		},
+	}, nil
+}
+
+// sequencerDAConfig is the dymint DA config of the generic backend
+type sequencerDAConfig struct {
+	BaseURL       string        `json:"base_url"`
+	Timeout       time.Duration `json:"timeout"`
+	GasPrices     float64       `json:"gas_prices"`
+	GasAdjustment float64       `json:"gas_adjustment"`
+	NamespaceID   string        `json:"namespace_id"`
+	AuthToken     string        `json:"auth_token"`
+	Backoff       backoffConfig `json:"backoff"`
+	RetryAttempts int           `json:"retry_attempts"`
+	RetryDelay    time.Duration `json:"retry_delay"`
+}
+
+type backoffConfig struct {
+	InitialDelay time.Duration `json:"initial_delay"`
+	MaxDelay     time.Duration `json:"max_delay"`
+	GrowthFactor int           `json:"growth_factor"`
+}
+
+func (g *Generic) GetSequencerDAConfig(_ string) string {
+	b, err := json.Marshal(sequencerDAConfig{
+		BaseURL:       g.RpcEndpoint,
+		Timeout:       60 * time.Second,
+		GasPrices:     g.GasPrices,
+		GasAdjustment: g.GasAdjustment,
+		NamespaceID:   g.NamespaceID,
+		AuthToken:     g.AuthToken,
+		Backoff: backoffConfig{
+			InitialDelay: 6 * time.Second,
+			MaxDelay:     6 * time.Second,
+			GrowthFactor: 2,
+		},
+		RetryAttempts: 4,
+		RetryDelay:    3 * time.Second,
+	})
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+func (g *Generic) SetRPCEndpoint(rpc string) {
+	g.RpcEndpoint = rpc
+}
+
+func (g *Generic) GetLightNodeEndpoint() string {
+	return g.RpcEndpoint
+}
+
+func (g *Generic) GetNetworkName() string {
+	return string(consts.Generic)
+}
+
+func (g *Generic) GetStatus(c roller.RollappConfig) string {
+	if !g.IsHealthy(c) {
+		return "Unreachable"
+	}
+	return "Active"
+}
+
+// IsHealthy reports whether the DA node returns its network head
+func (g *Generic) IsHealthy(c roller.RollappConfig) bool {
+	var head map[string]any
+	return g.call("header.NetworkHead", &head) == nil
+}
+
+func (g *Generic) GetKeyName() string {
+	if g.KeyName == "" {
+		return DefaultKeyName
+	}
+	return g.KeyName
+}
+
+func (g *Generic) GetNamespaceID() string {
+	return g.NamespaceID
+}
+
+func (g *Generic) GetAppID() uint32 {
+	return 0
+}
+
+func generateRandNamespaceID() (string, error) {
+	nID := make([]byte, 10)
+	_, err := rand.Read(nID)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(nID), nil
+}
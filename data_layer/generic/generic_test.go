@@ -0,0 +1,24 @@
+package generic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGetSequencerDAConfigEscapesValues(t *testing.T) {
+	g := &Generic{
+		RpcEndpoint:   `http://da.example/"rpc\`,
+		AuthToken:     `to"ken\`,
+		NamespaceID:   "0000ab",
+		GasPrices:     0.02,
+		GasAdjustment: 1.3,
+	}
+
+	var cfg sequencerDAConfig
+	if err := json.Unmarshal([]byte(g.GetSequencerDAConfig("")), &cfg); err != nil {
+		t.Fatalf("invalid dymint DA config: %v", err)
+	}
+	if cfg.BaseURL != g.RpcEndpoint || cfg.AuthToken != g.AuthToken {
+		t.Fatalf("values changed: %+v", cfg)
+	}
+}
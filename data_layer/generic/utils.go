@@ -0,0 +1,152 @@
+package generic
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"github.com/pelletier/go-toml"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/plan"
+)
+
+const rpcTimeout = 30 * time.Second
+
+type rpcRequest struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      int    `json:"id"`
+	Method  string `json:"method"`
+	Params  []any  `json:"params"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *rpcError       `json:"error"`
+}
+
+// call invokes a DA JSON-RPC method and decodes its result into result
+func (g *Generic) call(method string, result any, params ...any) error {
+	if g.RpcEndpoint == "" {
+		return fmt.Errorf("generic DA rpc endpoint is not configured")
+	}
+
+	if params == nil {
+		params = []any{}
+	}
+
+	reqBody, err := json.Marshal(rpcRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, g.RpcEndpoint, bytes.NewReader(reqBody))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if g.AuthToken != "" {
+		req.Header.Set("Authorization", "Bearer "+g.AuthToken)
+	}
+
+	client := http.Client{Timeout: rpcTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call %s: %w", method, err)
+	}
+	// nolint:errcheck
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to call %s: bad status %s", method, resp.Status)
+	}
+
+	var rpcResp rpcResponse
+	if err := json.Unmarshal(body, &rpcResp); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", method, err)
+	}
+
+	if rpcResp.Error != nil {
+		return fmt.Errorf("%s returned an error: %s", method, rpcResp.Error.Message)
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.Unmarshal(rpcResp.Result, result)
+}
+
+func writeConfigToTOML(path string, g Generic) error {
+	tomlBytes, err := toml.Marshal(g)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(path)
+	// nolint:gofumpt
+	if err := plan.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	// nolint:gofumpt
+	err = plan.WriteFile(path, tomlBytes, 0o600)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func loadConfigFromTOML(path string) (Generic, error) {
+	var config Generic
+	tomlBytes, err := plan.ReadFile(path)
+	if err != nil {
+		return config, err
+	}
+	err = toml.Unmarshal(tomlBytes, &config)
+	if err != nil {
+		return config, err
+	}
+
+	return config, nil
+}
+
+func GetCfgFilePath(rollerHome string) string {
+	return filepath.Join(rollerHome, consts.ConfigDirName.DALightNode, ConfigFileName)
+}
+
+// SetRPCEndpoint persists a new DA JSON-RPC endpoint in the generic DA config
+func SetRPCEndpoint(rollerHome, endpoint string) error {
+	cfgPath := GetCfgFilePath(rollerHome)
+	cfg, err := loadConfigFromTOML(cfgPath)
+	if err != nil {
+		return err
+	}
+
+	cfg.RpcEndpoint = endpoint
+	return writeConfigToTOML(cfgPath, cfg)
+}
+
+// WriteConfig persists the generic DA config in the roller home. NewGeneric loads an
+// existing config without prompting, so this can be used to pre-seed it
+func WriteConfig(rollerHome string, cfg Generic) error {
+	return writeConfigToTOML(GetCfgFilePath(rollerHome), cfg)
+}
@@ -189,6 +189,10 @@ func (k *Kaspa) GetStatus(c roller.RollappConfig) string {
 	return "Active"
 }
 
+func (k *Kaspa) IsHealthy(c roller.RollappConfig) bool {
+	return true
+}
+
 func (k *Kaspa) GetKeyName() string {
 	return "kaspa"
 }
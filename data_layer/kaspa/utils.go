@@ -8,6 +8,7 @@ import (
 	"github.com/pelletier/go-toml"
 
 	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/plan"
 )
 
 const mnemonicEnvFileName = "kaspa_mnemonic.env"
@@ -19,11 +20,11 @@ func writeConfigToTOML(path string, c Kaspa) error {
 	}
 	dir := filepath.Dir(path)
 	// nolint:gofumpt
-	if err := os.MkdirAll(dir, 0o755); err != nil {
+	if err := plan.MkdirAll(dir, 0o755); err != nil {
 		return err
 	}
 	// nolint:gofumpt
-	err = os.WriteFile(path, tomlBytes, 0o644)
+	err = plan.WriteFile(path, tomlBytes, 0o644)
 	if err != nil {
 		return err
 	}
@@ -33,7 +34,7 @@ func writeConfigToTOML(path string, c Kaspa) error {
 
 func loadConfigFromTOML(path string) (Kaspa, error) {
 	var config Kaspa
-	tomlBytes, err := os.ReadFile(path)
+	tomlBytes, err := plan.ReadFile(path)
 	if err != nil {
 		return config, err
 	}
@@ -70,3 +71,9 @@ func EnsureMnemonicEnvFile(rollerHome string) (string, error) {
 	}
 	return envPath, nil
 }
+
+// WriteConfig persists the kaspa DA config in the roller home. NewKaspa loads an
+// existing config without prompting, so this can be used to pre-seed it
+func WriteConfig(rollerHome string, cfg Kaspa) error {
+	return writeConfigToTOML(GetCfgFilePath(rollerHome), cfg)
+}
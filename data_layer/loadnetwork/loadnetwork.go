@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"os/exec"
 	"strconv"
+	"strings"
 
 	cosmossdkmath "cosmossdk.io/math"
 	cosmossdktypes "github.com/cosmos/cosmos-sdk/types"
@@ -167,7 +168,7 @@ func (w *LoadNetwork) GetStartDACmd() *exec.Cmd {
 }
 
 func (w *LoadNetwork) GetDAAccData(cfg roller.RollappConfig) ([]keys.AccountData, error) {
-	balance, err := GetBalance(cfg.DA.ApiUrl, w.PrivateKey)
+	balance, err := getBalance(cfg.DA.ApiUrl, w.PrivateKey)
 	if err != nil {
 		return nil, err
 	}
@@ -175,17 +176,12 @@ func (w *LoadNetwork) GetDAAccData(cfg roller.RollappConfig) ([]keys.AccountData
 	if err != nil {
 		return nil, err
 	}
-
-	balanceInt, ok := cosmossdkmath.NewIntFromString(balance)
-	if !ok {
-		return nil, fmt.Errorf("Can not convert from String to Int")
-	}
 	return []keys.AccountData{
 		{
 			Address: address.String(),
 			Balance: cosmossdktypes.Coin{
 				Denom:  consts.Denoms.LoadNetwork,
-				Amount: balanceInt,
+				Amount: cosmossdkmath.NewIntFromBigInt(balance),
 			},
 		},
 	}, nil
@@ -216,6 +212,10 @@ func (w *LoadNetwork) GetStatus(c roller.RollappConfig) string {
 	return "Active"
 }
 
+func (w *LoadNetwork) IsHealthy(c roller.RollappConfig) bool {
+	return true
+}
+
 func (w *LoadNetwork) GetKeyName() string {
 	return "loadnetwork"
 }
@@ -252,10 +252,22 @@ func getAddressFromPrivateKey(privKey string) (common.Address, *ecdsa.PrivateKey
 }
 
 func GetBalance(jsonRPCURL, key string) (string, error) {
-	address, _, err := getAddressFromPrivateKey(key)
+	balance, err := getBalance(jsonRPCURL, key)
 	if err != nil {
 		return "", err
 	}
+
+	ethBalance := new(big.Float).Quo(new(big.Float).SetInt(balance), big.NewFloat(1e18))
+	return ethBalance.Text('f', 6), nil
+}
+
+// getBalance returns the balance of the account in wei
+func getBalance(jsonRPCURL, key string) (*big.Int, error) {
+	address, _, err := getAddressFromPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
 	payload := RequestPayload{
 		JSONRPC: "2.0",
 		Method:  "eth_getBalance",
@@ -265,31 +277,30 @@ func GetBalance(jsonRPCURL, key string) (string, error) {
 
 	jsonData, err := json.Marshal(payload)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	resp, err := http.Post(jsonRPCURL, "application/json", bytes.NewBuffer(jsonData))
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	var response EthBalanceResponse
-
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	err = json.Unmarshal(body, &response)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	balance := new(big.Int)
-	balance.SetString(response.Result[2:], 16)
-
-	ethBalance := new(big.Float).Quo(new(big.Float).SetInt(balance), big.NewFloat(1e18))
+	balance, ok := new(big.Int).SetString(strings.TrimPrefix(response.Result, "0x"), 16)
+	if !ok {
+		return nil, fmt.Errorf("invalid balance in eth_getBalance response: %q", response.Result)
+	}
 
-	return ethBalance.Text('f', 6), nil
+	return balance, nil
 }
@@ -1,12 +1,12 @@
 package loadnetwork
 
 import (
-	"os"
 	"path/filepath"
 
 	"github.com/pelletier/go-toml"
 
 	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/plan"
 )
 
 func writeConfigToTOML(path string, w LoadNetwork) error {
@@ -16,11 +16,11 @@ func writeConfigToTOML(path string, w LoadNetwork) error {
 	}
 	dir := filepath.Dir(path)
 	// nolint:gofumpt
-	if err := os.MkdirAll(dir, 0o755); err != nil {
+	if err := plan.MkdirAll(dir, 0o755); err != nil {
 		return err
 	}
 	// nolint:gofumpt
-	err = os.WriteFile(path, tomlBytes, 0o644)
+	err = plan.WriteFile(path, tomlBytes, 0o644)
 	if err != nil {
 		return err
 	}
@@ -30,7 +30,7 @@ func writeConfigToTOML(path string, w LoadNetwork) error {
 
 func loadConfigFromTOML(path string) (LoadNetwork, error) {
 	var config LoadNetwork
-	tomlBytes, err := os.ReadFile(path)
+	tomlBytes, err := plan.ReadFile(path)
 	if err != nil {
 		return config, err
 	}
@@ -45,3 +45,9 @@ func loadConfigFromTOML(path string) (LoadNetwork, error) {
 func GetCfgFilePath(rollerHome string) string {
 	return filepath.Join(rollerHome, consts.ConfigDirName.DALightNode, ConfigFileName)
 }
+
+// WriteConfig persists the loadnetwork DA config in the roller home. NewLoadNetwork loads an
+// existing config without prompting, so this can be used to pre-seed it
+func WriteConfig(rollerHome string, cfg LoadNetwork) error {
+	return writeConfigToTOML(GetCfgFilePath(rollerHome), cfg)
+}
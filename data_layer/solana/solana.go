@@ -187,6 +187,10 @@ func (s *Solana) GetStatus(c roller.RollappConfig) string {
 	return "Active"
 }
 
+func (s *Solana) IsHealthy(c roller.RollappConfig) bool {
+	return true
+}
+
 func (s *Solana) GetKeyName() string {
 	return "solana"
 }
@@ -1,17 +1,17 @@
 package solana
 
 import (
-	"os"
 	"path/filepath"
 
 	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/plan"
 	"github.com/pelletier/go-toml/v2"
 )
 
 func writeConfigToTOML(filePath string, config Solana) error {
 	// Create directory if it doesn't exist
 	dir := filepath.Dir(filePath)
-	if err := os.MkdirAll(dir, 0o755); err != nil {
+	if err := plan.MkdirAll(dir, 0o755); err != nil {
 		return err
 	}
 
@@ -22,12 +22,12 @@ func writeConfigToTOML(filePath string, config Solana) error {
 	}
 
 	// Write to file
-	return os.WriteFile(filePath, data, 0o644)
+	return plan.WriteFile(filePath, data, 0o644)
 }
 
 func loadConfigFromTOML(path string) (Solana, error) {
 	var config Solana
-	tomlBytes, err := os.ReadFile(path)
+	tomlBytes, err := plan.ReadFile(path)
 	if err != nil {
 		return config, err
 	}
@@ -42,3 +42,9 @@ func loadConfigFromTOML(path string) (Solana, error) {
 func GetCfgFilePath(rollerHome string) string {
 	return filepath.Join(rollerHome, consts.ConfigDirName.DALightNode, ConfigFileName)
 }
+
+// WriteConfig persists the solana DA config in the roller home. NewSolana loads an
+// existing config without prompting, so this can be used to pre-seed it
+func WriteConfig(rollerHome string, cfg Solana) error {
+	return writeConfigToTOML(GetCfgFilePath(rollerHome), cfg)
+}
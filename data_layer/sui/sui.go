@@ -205,6 +205,10 @@ func (s *Sui) GetStatus(c roller.RollappConfig) string {
 	return "Active"
 }
 
+func (s *Sui) IsHealthy(c roller.RollappConfig) bool {
+	return true
+}
+
 func (s *Sui) GetKeyName() string {
 	return "sui"
 }
@@ -118,6 +118,10 @@ func (w *Walrus) GetStatus(c roller.RollappConfig) string {
 	return "Active"
 }
 
+func (w *Walrus) IsHealthy(c roller.RollappConfig) bool {
+	return true
+}
+
 func (w *Walrus) GetKeyName() string {
 	return "walrus"
 }
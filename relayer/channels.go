@@ -23,36 +23,26 @@ func (r *Relayer) LoadActiveChannel(
 	spinner, _ := pterm.DefaultSpinner.Start("loading active IBC channels")
 	defer spinner.Stop()
 
-	gacCmd := r.getQueryChannelsRollappCmd(raData)
-
-	gacOut, err := bash.ExecCommandWithStdout(gacCmd)
-	if err != nil {
-		return err
-	}
-
-	var gacResponse QueryChannelsResponse
-	err = json.Unmarshal(gacOut.Bytes(), &gacResponse)
+	channels, err := r.QueryRollappChannels(raData)
 	if err != nil {
 		return err
 	}
 
-	if len(gacResponse.Channels) == 0 {
+	if len(channels) == 0 {
 		return ErrNoOpenChannel
 	}
 
 	raIbcChanIndex := slices.IndexFunc(
-		gacResponse.Channels, func(ibcChan Channel) bool {
-			return ibcChan.State == "STATE_OPEN"
+		channels, func(ibcChan Channel) bool {
+			return ibcChan.State == "STATE_OPEN" && r.channelAllowed(ibcChan)
 		},
 	)
 
-	fmt.Println(raIbcChanIndex)
-
 	if raIbcChanIndex == -1 {
 		return ErrNoOpenChannel
 	}
 
-	raChan := gacResponse.Channels[raIbcChanIndex]
+	raChan := channels[raIbcChanIndex]
 	r.SrcChannel = raChan.Counterparty.ChannelID
 	r.DstChannel = raChan.ChannelID
 	r.DstConnectionID = raChan.ConnectionHops[0]
@@ -178,6 +168,22 @@ func (r *Relayer) LoadActiveChannel(
 // 	return r.SrcChannel, r.DstChannel, nil
 // }
 
+// QueryRollappChannels returns the IBC channels of the rollapp, as seen on
+// the rollapp chain
+func (r *Relayer) QueryRollappChannels(raData consts.RollappData) ([]Channel, error) {
+	out, err := bash.ExecCommandWithStdout(r.getQueryChannelsRollappCmd(raData))
+	if err != nil {
+		return nil, err
+	}
+
+	var resp QueryChannelsResponse
+	if err := json.Unmarshal(out.Bytes(), &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Channels, nil
+}
+
 func (r *Relayer) getQueryChannelsRollappCmd(raData consts.RollappData) *exec.Cmd {
 	args := []string{"q", "ibc", "channel", "channels"}
 	args = append(args, "--node", raData.RpcUrl, "--chain-id", raData.ID, "-o", "json")
@@ -206,6 +212,12 @@ func (r *Relayer) queryChannelsHubCmd(hd consts.HubData) *exec.Cmd {
 	return cmd
 }
 
+// channelAllowed reports whether a rollapp channel passes the channel filter
+// of the path
+func (r *Relayer) channelAllowed(c Channel) bool {
+	return len(r.ChannelFilter) == 0 || slices.Contains(r.ChannelFilter, c.Counterparty.ChannelID)
+}
+
 func (r *Relayer) ChannelReady() bool {
 	return r.SrcChannel != "" && r.DstChannel != ""
 }
@@ -296,7 +308,7 @@ func (r *Relayer) HandleIbcChannelCreation(
 			return
 		}
 
-		dymintutils.WaitForHealthyRollApp("http://localhost:26657/health")
+		dymintutils.WaitForHealthyRollApp(roller.LocalRollappRPC() + "/health")
 	}()
 
 	seq := sequencer.GetInstance(rollappChainData)
@@ -308,7 +320,7 @@ func (r *Relayer) HandleIbcChannelCreation(
 		return err
 	}
 
-	dymintutils.WaitForHealthyRollApp("http://localhost:26657/health")
+	dymintutils.WaitForHealthyRollApp(roller.LocalRollappRPC() + "/health")
 	err = WaitForValidRollappHeight(seq)
 	if err != nil {
 		pterm.Error.Printf("rollapp did not reach valid height: %v\n", err)
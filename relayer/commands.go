@@ -27,7 +27,8 @@ func (r *Relayer) GetRelayPacketsCmd() *exec.Cmd {
 
 // @20240319 the flags `--max-msgs` and `--flush-interval` improve the relayer performance
 // a better solution should be implemented as a part of https://github.com/dymensionxyz/roller/issues/769
-func (r *Relayer) GetStartCmd() *exec.Cmd {
+// extraPaths are relayed by the same process along with the path of the relayer
+func (r *Relayer) GetStartCmd(extraPaths ...string) *exec.Cmd {
 	args := []string{
 		"start",
 		"--block-history",
@@ -40,13 +41,15 @@ func (r *Relayer) GetStartCmd() *exec.Cmd {
 		"--log-format",
 		"json",
 	}
-	args = append(args, r.getRelayerDefaultArgs()...)
+	args = append(args, r.Path)
+	args = append(args, extraPaths...)
+	args = append(args, "--home", filepath.Join(r.RollerHome, consts.ConfigDirName.Relayer))
 	return exec.Command(consts.Executables.Relayer, args...)
 }
 
 func (r *Relayer) getArgsWithSrcChannel() []string {
 	return []string{
-		consts.DefaultRelayerPath,
+		r.Path,
 		r.DstChannel,
 		"--home",
 		filepath.Join(r.RollerHome, consts.ConfigDirName.Relayer),
@@ -2,10 +2,12 @@ package relayer
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 
 	"github.com/pterm/pterm"
 	yaml "gopkg.in/yaml.v3"
@@ -13,6 +15,7 @@ import (
 	"github.com/dymensionxyz/roller/cmd/consts"
 	"github.com/dymensionxyz/roller/utils"
 	"github.com/dymensionxyz/roller/utils/config/yamlconfig"
+	"github.com/dymensionxyz/roller/utils/plan"
 	"github.com/dymensionxyz/roller/utils/roller"
 )
 
@@ -47,26 +50,36 @@ type RelayerChainConfig struct {
 // configuration file
 type Config struct {
 	Chains map[string]RelayerFileChainConfig `yaml:"chains"`
-	Paths  *Paths                            `yaml:"paths"`
-}
-
-type Paths struct {
-	HubRollapp *struct {
-		Dst *struct {
-			ChainID      string `yaml:"chain-id"`
-			ClientID     string `yaml:"client-id"`
-			ConnectionID string `yaml:"connection-id"`
-		} `yaml:"dst"`
-		Src *struct {
-			ChainID      string `yaml:"chain-id"`
-			ClientID     string `yaml:"client-id"`
-			ConnectionID string `yaml:"connection-id"`
-		} `yaml:"src"`
-		SrcChannelFilter *struct {
-			ChannelList []string `yaml:"channel-list"`
-			Rule        string   `yaml:"rule"`
-		} `yaml:"src-channel-filter"`
-	} `yaml:"hub-rollapp"`
+	Paths  map[string]*Path                  `yaml:"paths"`
+}
+
+// Path is an rly path between the hub (src) and a rollapp (dst). Several paths
+// can be relayed by the same relayer, e.g. for a second rollapp or for a
+// specific channel of the same rollapp
+type Path struct {
+	Dst              *PathEnd       `yaml:"dst"`
+	Src              *PathEnd       `yaml:"src"`
+	SrcChannelFilter *ChannelFilter `yaml:"src-channel-filter"`
+}
+
+type PathEnd struct {
+	ChainID      string `yaml:"chain-id"`
+	ClientID     string `yaml:"client-id"`
+	ConnectionID string `yaml:"connection-id"`
+}
+
+// ChannelFilter restricts the hub channels relayed on a path
+type ChannelFilter struct {
+	ChannelList []string `yaml:"channel-list"`
+	Rule        string   `yaml:"rule"`
+}
+
+// Channels returns the allowed hub channels of the path, none means all
+func (p *Path) Channels() []string {
+	if p.SrcChannelFilter == nil || p.SrcChannelFilter.Rule != "allowlist" {
+		return nil
+	}
+	return p.SrcChannelFilter.ChannelList
 }
 
 func (c *Config) GetChains(cfgPath string) ([]string, error) {
@@ -83,8 +96,8 @@ func (c *Config) GetChains(cfgPath string) ([]string, error) {
 }
 
 func (c *Config) Load(rlyConfigPath string) error {
-	fmt.Println("loading config from", rlyConfigPath)
-	data, err := os.ReadFile(rlyConfigPath)
+	pterm.Debug.Println("loading config from", rlyConfigPath)
+	data, err := plan.ReadFile(rlyConfigPath)
 	if err != nil {
 		return err
 	}
@@ -97,17 +110,36 @@ func (c *Config) Load(rlyConfigPath string) error {
 	return nil
 }
 
-func (c *Config) GetPath() *Paths {
-	if c.Paths == nil || c.Paths.HubRollapp == nil {
+// GetPath returns the path with the given name, or nil when it does not
+// exist or is incomplete
+func (c *Config) GetPath(name string) *Path {
+	p, ok := c.Paths[name]
+	if !ok || p == nil || p.Src == nil || p.Dst == nil {
 		return nil
 	}
 
-	return c.Paths
+	return p
+}
+
+// PathNames returns the names of the configured paths, the default path first
+func (c *Config) PathNames() []string {
+	names := make([]string, 0, len(c.Paths))
+	for name := range c.Paths {
+		if name != consts.DefaultRelayerPath && c.GetPath(name) != nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	if c.GetPath(consts.DefaultRelayerPath) != nil {
+		names = append([]string{consts.DefaultRelayerPath}, names...)
+	}
+	return names
 }
 
-func (c *Config) CreatePath(rlpCfg roller.RollappConfig) error {
+func (c *Config) CreatePath(rlpCfg roller.RollappConfig, name string) error {
 	relayerHome := filepath.Join(rlpCfg.Home, consts.ConfigDirName.Relayer)
-	pterm.Info.Printf("creating new ibc path from %s to %s\n", rlpCfg.HubData.ID, rlpCfg.RollappID)
+	pterm.Info.Printf("creating new ibc path %s from %s to %s\n", name, rlpCfg.HubData.ID, rlpCfg.RollappID)
 
 	newPathCmd := exec.Command(
 		consts.Executables.Relayer,
@@ -115,10 +147,13 @@ func (c *Config) CreatePath(rlpCfg roller.RollappConfig) error {
 		"new",
 		rlpCfg.HubData.ID,
 		rlpCfg.RollappID,
-		consts.DefaultRelayerPath,
+		name,
 		"--home",
 		relayerHome,
 	)
+	if plan.Intercept(newPathCmd) {
+		return nil
+	}
 	if err := newPathCmd.Run(); err != nil {
 		return err
 	}
@@ -138,6 +173,9 @@ func DeletePath(rlpCfg roller.RollappConfig) error {
 		"--home",
 		relayerHome,
 	)
+	if plan.Intercept(newPathCmd) {
+		return nil
+	}
 	if err := newPathCmd.Run(); err != nil {
 		return err
 	}
@@ -145,6 +183,16 @@ func DeletePath(rlpCfg roller.RollappConfig) error {
 	return nil
 }
 
+// SetChannelFilter restricts a path to the given hub channels
+func SetChannelFilter(relayerHome, name string, channels []string) error {
+	updates := map[string]interface{}{
+		fmt.Sprintf("paths.%s.src-channel-filter.rule", name):         "allowlist",
+		fmt.Sprintf("paths.%s.src-channel-filter.channel-list", name): channels,
+	}
+
+	return yamlconfig.UpdateNestedYAML(GetConfigFilePath(relayerHome), updates)
+}
+
 type ChainConfig struct {
 	ID            string
 	RPC           string
@@ -165,7 +213,7 @@ func UpdateRlyConfigValue(
 		"config.yaml",
 	)
 
-	data, err := os.ReadFile(rlyConfigPath)
+	data, err := plan.ReadFile(rlyConfigPath)
 	if err != nil {
 		return fmt.Errorf("failed to load %s: %v", rlyConfigPath, err)
 	}
@@ -187,12 +235,12 @@ func UpdateRlyConfigValue(
 	}
 
 	// nolint:gofumpt
-	return os.WriteFile(rlyConfigPath, newData, 0o644)
+	return plan.WriteFile(rlyConfigPath, newData, 0o644)
 }
 
 func ReadRlyConfig(homeDir string) (map[interface{}]interface{}, error) {
 	rlyConfigPath := filepath.Join(homeDir, consts.ConfigDirName.Relayer, "config", "config.yaml")
-	data, err := os.ReadFile(rlyConfigPath)
+	data, err := plan.ReadFile(rlyConfigPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load %s: %v", rlyConfigPath, err)
 	}
@@ -211,20 +259,16 @@ func WriteRlyConfig(homeDir string, rlyCfg map[interface{}]interface{}) error {
 		return fmt.Errorf("failed to marshal config: %v", err)
 	}
 	// nolint:gofumpt
-	return os.WriteFile(rlyConfigPath, data, 0o644)
+	return plan.WriteFile(rlyConfigPath, data, 0o644)
 }
 
-func writeTmpChainConfig(chainConfig RelayerFileChainConfig, fileName string) (string, error) {
+func writeTmpChainConfig(chainConfig RelayerFileChainConfig, filePath string) error {
 	file, err := json.Marshal(chainConfig)
 	if err != nil {
-		return "", err
+		return err
 	}
-	filePath := filepath.Join(os.TempDir(), fileName)
 	// nolint:gofumpt
-	if err := os.WriteFile(filePath, file, 0o644); err != nil {
-		return "", err
-	}
-	return filePath, nil
+	return os.WriteFile(filePath, file, 0o644)
 }
 
 func getRelayerFileChainConfig(relayerChainConfig RelayerChainConfig) RelayerFileChainConfig {
@@ -247,11 +291,29 @@ func getRelayerFileChainConfig(relayerChainConfig RelayerChainConfig) RelayerFil
 	}
 }
 
-func addChainToRelayer(fileChainConfig RelayerFileChainConfig, relayerHome string) error {
-	chainFilePath, err := writeTmpChainConfig(fileChainConfig, "chain.json")
-	if err != nil {
+// AddChain adds a chain to an initialized relayer unless it is already
+// configured, e.g. when a path to a second rollapp is created. In dry-run
+// mode the relayer config may only be planned, the chain is then planned too
+func AddChain(chainConfig ChainConfig, keyName, relayerHome string) error {
+	var cfg Config
+	err := cfg.Load(GetConfigFilePath(relayerHome))
+	if err != nil && !(plan.Enabled() && errors.Is(err, os.ErrNotExist)) {
 		return err
 	}
+	if _, ok := cfg.Chains[chainConfig.ID]; ok {
+		return nil
+	}
+
+	pterm.Info.Printf("adding %s to the relayer config\n", chainConfig.ID)
+	return addChainToRelayer(getRelayerFileChainConfig(RelayerChainConfig{
+		ChainConfig: chainConfig,
+		GasPrices:   chainConfig.GasPrices + chainConfig.Denom,
+		KeyName:     keyName,
+	}), relayerHome)
+}
+
+func addChainToRelayer(fileChainConfig RelayerFileChainConfig, relayerHome string) error {
+	chainFilePath := filepath.Join(os.TempDir(), "chain.json")
 	addChainCmd := exec.Command(
 		consts.Executables.Relayer,
 		"chains",
@@ -262,6 +324,12 @@ func addChainToRelayer(fileChainConfig RelayerFileChainConfig, relayerHome strin
 		"--file",
 		chainFilePath,
 	)
+	if plan.Intercept(addChainCmd) {
+		return nil
+	}
+	if err := writeTmpChainConfig(fileChainConfig, chainFilePath); err != nil {
+		return err
+	}
 	if err := addChainCmd.Run(); err != nil {
 		return err
 	}
@@ -276,6 +344,9 @@ func initRelayer(relayerHome string) error {
 		"--home",
 		relayerHome,
 	)
+	if plan.Intercept(initRelayerConfigCmd) {
+		return nil
+	}
 	return initRelayerConfigCmd.Run()
 }
 
@@ -326,27 +397,73 @@ func InitializeConfig(
 	return nil
 }
 
-func (c *Config) RollappID() string {
-	return c.Paths.HubRollapp.Dst.ChainID
+func (c *Config) RollappID() (string, error) {
+	p := c.GetPath(consts.DefaultRelayerPath)
+	if p == nil {
+		return "", fmt.Errorf("unknown path %q", consts.DefaultRelayerPath)
+	}
+	return p.Dst.ChainID, nil
+}
+
+func (c *Config) HubDataFromRelayerConfig() (*consts.HubData, error) {
+	return c.HubDataForPath(consts.DefaultRelayerPath)
+}
+
+func (c *Config) RaDataFromRelayerConfig() (*consts.RollappData, error) {
+	return c.RaDataForPath(consts.DefaultRelayerPath)
 }
 
-func (c *Config) HubDataFromRelayerConfig() *consts.HubData {
+func (c *Config) HubDataForPath(name string) (*consts.HubData, error) {
+	p := c.GetPath(name)
+	if p == nil {
+		return nil, fmt.Errorf("unknown path %q", name)
+	}
+
+	chainID := p.Src.ChainID
 	hd := consts.HubData{
-		ID:     c.Paths.HubRollapp.Src.ChainID,
-		RpcUrl: c.Chains[c.Paths.HubRollapp.Src.ChainID].Value.RpcAddr,
-		ApiUrl: c.Chains[c.Paths.HubRollapp.Src.ChainID].Value.ApiAddr,
+		ID:     chainID,
+		RpcUrl: c.Chains[chainID].Value.RpcAddr,
+		ApiUrl: c.Chains[chainID].Value.ApiAddr,
 	}
 
-	return &hd
+	return &hd, nil
 }
 
-func (c *Config) RaDataFromRelayerConfig() *consts.RollappData {
+func (c *Config) RaDataForPath(name string) (*consts.RollappData, error) {
+	p := c.GetPath(name)
+	if p == nil {
+		return nil, fmt.Errorf("unknown path %q", name)
+	}
+
+	chainID := p.Dst.ChainID
 	raData := consts.RollappData{
-		ID:     c.Paths.HubRollapp.Dst.ChainID,
-		RpcUrl: c.Chains[c.Paths.HubRollapp.Dst.ChainID].Value.RpcAddr,
+		ID:     chainID,
+		RpcUrl: c.Chains[chainID].Value.RpcAddr,
+	}
+
+	return &raData, nil
+}
+
+// NewRelayerForPath returns a relayer for a path of the config
+func (c *Config) NewRelayerForPath(home, name string) (*Relayer, error) {
+	p := c.GetPath(name)
+	if p == nil {
+		return nil, fmt.Errorf("path %s does not exist, run 'roller relayer setup --path %s'", name, name)
+	}
+
+	raData, err := c.RaDataForPath(name)
+	if err != nil {
+		return nil, err
+	}
+	hd, err := c.HubDataForPath(name)
+	if err != nil {
+		return nil, err
 	}
 
-	return &raData
+	rly := NewRelayer(home, *raData, *hd)
+	rly.Path = name
+	rly.ChannelFilter = p.Channels()
+	return rly, nil
 }
 
 func (r *Relayer) UpdateConfigWithDefaultValues(rollerData roller.RollappConfig) error {
@@ -51,15 +51,16 @@ func (r *Relayer) RaIbcConnections(
 	return &raIbcConnections, nil
 }
 
-func (r *Relayer) UpdateDefaultPath() error {
+// UpdatePath writes the clients and connections of the relayer to its path
+func (r *Relayer) UpdatePath() error {
 	updates := map[string]interface{}{
 		// hub
-		fmt.Sprintf("paths.%s.src.client-id", consts.DefaultRelayerPath):     r.SrcClientID,
-		fmt.Sprintf("paths.%s.src.connection-id", consts.DefaultRelayerPath): r.SrcConnectionID,
+		fmt.Sprintf("paths.%s.src.client-id", r.Path):     r.SrcClientID,
+		fmt.Sprintf("paths.%s.src.connection-id", r.Path): r.SrcConnectionID,
 
 		// ra
-		fmt.Sprintf("paths.%s.dst.client-id", consts.DefaultRelayerPath):     r.DstClientID,
-		fmt.Sprintf("paths.%s.dst.connection-id", consts.DefaultRelayerPath): r.DstConnectionID,
+		fmt.Sprintf("paths.%s.dst.client-id", r.Path):     r.DstClientID,
+		fmt.Sprintf("paths.%s.dst.connection-id", r.Path): r.DstConnectionID,
 	}
 	err := yamlconfig.UpdateNestedYAML(r.ConfigFilePath, updates)
 	if err != nil {
@@ -194,6 +195,9 @@ func (r *Relayer) GetActiveConnections(raData consts.RollappData, hd consts.HubD
 			return conn.ID == hubConnectionID
 		},
 	)
+	if hubConnIndex == -1 {
+		return nil, nil, fmt.Errorf("connection %s not found on the hub", hubConnectionID)
+	}
 
 	hubConnection := hubIbcConnections.Connections[hubConnIndex]
 
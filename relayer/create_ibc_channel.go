@@ -210,7 +210,7 @@ func (r *Relayer) getCreateChannelCmd(override bool) *exec.Cmd {
 
 func (r *Relayer) getRelayerDefaultArgs() []string {
 	return []string{
-		consts.DefaultRelayerPath,
+		r.Path,
 		"--home",
 		filepath.Join(r.RollerHome, consts.ConfigDirName.Relayer),
 	}
@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 
 	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/plan"
 	"github.com/dymensionxyz/roller/utils/roller"
 )
 
@@ -19,6 +20,10 @@ type Relayer struct {
 
 	Rollapp consts.RollappData
 	Hub     consts.HubData
+	// Path is the name of the rly path relayed
+	Path string
+	// ChannelFilter are the hub channels the path is restricted to, if any
+	ChannelFilter []string
 	// channels
 	SrcChannel string
 	DstChannel string
@@ -42,6 +47,7 @@ func NewRelayer(home string, raData consts.RollappData, hd consts.HubData) *Rela
 
 		Rollapp: raData,
 		Hub:     hd,
+		Path:    consts.DefaultRelayerPath,
 
 		logger: log.New(io.Discard, "", 0),
 	}
@@ -70,6 +76,9 @@ func (r *Relayer) GetRelayerStatus(roller.RollappConfig) string {
 }
 
 func (r *Relayer) WriteRelayerStatus(status string) error {
+	if plan.Enabled() {
+		return plan.WriteFile(r.StatusFilePath(), []byte(status), 0o644)
+	}
 	// nolint:gofumpt
 	return os.WriteFile(r.StatusFilePath(), []byte(status), 0o644)
 }
@@ -0,0 +1,315 @@
+package relayer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/bash"
+)
+
+const (
+	// clientExpiryWarning is the share of the trusting period left below which
+	// a client is reported as expiring
+	clientExpiryWarning = 0.25
+	// stuckAfter is how long packets can be pending without any client update
+	// before the relayer is reported as stuck
+	stuckAfter = time.Hour
+)
+
+// ClientStatus is the state of an IBC light client as stored on the chain
+// that hosts it
+type ClientStatus struct {
+	Chain          string        `json:"chain" yaml:"chain"`
+	ClientID       string        `json:"client_id" yaml:"client_id"`
+	TrackedChain   string        `json:"tracked_chain" yaml:"tracked_chain"`
+	LatestHeight   int64         `json:"latest_height" yaml:"latest_height"`
+	LastUpdate     time.Time     `json:"last_update" yaml:"last_update"`
+	TrustingPeriod time.Duration `json:"trusting_period" yaml:"trusting_period"`
+	ExpiresAt      time.Time     `json:"expires_at" yaml:"expires_at"`
+	Expired        bool          `json:"expired" yaml:"expired"`
+}
+
+// Expiring reports whether less than a quarter of the trusting period is left
+func (c ClientStatus) Expiring() bool {
+	return time.Until(c.ExpiresAt) < time.Duration(float64(c.TrustingPeriod)*clientExpiryWarning)
+}
+
+type EndState struct {
+	Chain        string `json:"chain" yaml:"chain"`
+	ID           string `json:"id" yaml:"id"`
+	State        string `json:"state" yaml:"state"`
+	Counterparty string `json:"counterparty" yaml:"counterparty"`
+}
+
+// PendingPackets are the packets and acknowledgements sent by one chain that
+// were not relayed to the other one yet
+type PendingPackets struct {
+	Direction string `json:"direction" yaml:"direction"`
+	Packets   int    `json:"packets" yaml:"packets"`
+	Acks      int    `json:"acks" yaml:"acks"`
+}
+
+// ChainStatus is the state of the relayer path between the hub and the rollapp,
+// as seen on both chains
+type ChainStatus struct {
+	Clients     []ClientStatus   `json:"clients" yaml:"clients"`
+	Connections []EndState       `json:"connections" yaml:"connections"`
+	Channels    []EndState       `json:"channels" yaml:"channels"`
+	Pending     []PendingPackets `json:"pending" yaml:"pending"`
+	// LastRelayedAt is the time of the most recent client update on either chain
+	LastRelayedAt time.Time `json:"last_relayed_at" yaml:"last_relayed_at"`
+	Issues        []string  `json:"issues" yaml:"issues"`
+}
+
+// QueryChainStatus collects the state of the clients, connections, channels
+// and pending packets of the relayer path. Failing queries are reported as
+// issues rather than errors, so that a partial status can be shown
+func (r *Relayer) QueryChainStatus(raData consts.RollappData, hd consts.HubData) *ChainStatus {
+	s := &ChainStatus{
+		Clients:     []ClientStatus{},
+		Connections: []EndState{},
+		Channels:    []EndState{},
+		Pending:     []PendingPackets{},
+		Issues:      []string{},
+	}
+
+	raConn, hubConn, err := r.GetActiveConnections(raData, hd)
+	switch {
+	case err != nil:
+		s.Issues = append(s.Issues, fmt.Sprintf("failed to query connections: %v", err))
+	case raConn == nil || hubConn == nil:
+		s.Issues = append(s.Issues, "no open connection between the hub and the rollapp")
+	default:
+		s.Connections = append(s.Connections,
+			EndState{Chain: raData.ID, ID: raConn.ID, State: raConn.State, Counterparty: hubConn.ID},
+			EndState{Chain: hd.ID, ID: hubConn.ID, State: hubConn.State, Counterparty: raConn.ID},
+		)
+
+		clients, errs := queryClients(raData, hd, raConn, hubConn)
+		for _, err := range errs {
+			s.Issues = append(s.Issues, err.Error())
+		}
+		for _, cs := range clients {
+			s.Clients = append(s.Clients, cs)
+
+			switch {
+			case cs.Expired:
+				s.Issues = append(s.Issues, fmt.Sprintf("client %s on %s is expired", cs.ClientID, cs.Chain))
+			case cs.Expiring():
+				s.Issues = append(s.Issues, fmt.Sprintf(
+					"client %s on %s expires in %s",
+					cs.ClientID, cs.Chain, time.Until(cs.ExpiresAt).Round(time.Minute),
+				))
+			}
+			if cs.LastUpdate.After(s.LastRelayedAt) {
+				s.LastRelayedAt = cs.LastUpdate
+			}
+		}
+	}
+
+	channels, err := r.QueryRollappChannels(raData)
+	if err != nil {
+		s.Issues = append(s.Issues, fmt.Sprintf("failed to query channels: %v", err))
+	}
+	for _, c := range channels {
+		if !r.channelAllowed(c) {
+			continue
+		}
+		s.Channels = append(s.Channels, EndState{
+			Chain:        raData.ID,
+			ID:           c.ChannelID,
+			State:        c.State,
+			Counterparty: c.Counterparty.ChannelID,
+		})
+		if c.State == "STATE_OPEN" && r.DstChannel == "" {
+			r.SrcChannel = c.Counterparty.ChannelID
+			r.DstChannel = c.ChannelID
+		}
+	}
+	if err == nil && !r.ChannelReady() {
+		s.Issues = append(s.Issues, "no open channel between the hub and the rollapp")
+	}
+
+	if r.ChannelReady() {
+		packets, pErr := r.QueryUnrelayedPackets(r.SrcChannel)
+		acks, aErr := r.QueryUnrelayedAcks(r.SrcChannel)
+		if pErr != nil || aErr != nil {
+			s.Issues = append(s.Issues, fmt.Sprintf("failed to query pending packets: %v", firstErr(pErr, aErr)))
+		} else {
+			s.Pending = append(s.Pending,
+				PendingPackets{Direction: hd.ID + " -> " + raData.ID, Packets: len(packets.Src), Acks: len(acks.Src)},
+				PendingPackets{Direction: raData.ID + " -> " + hd.ID, Packets: len(packets.Dst), Acks: len(acks.Dst)},
+			)
+		}
+	}
+
+	pending := 0
+	for _, p := range s.Pending {
+		pending += p.Packets + p.Acks
+	}
+	if pending > 0 && !s.LastRelayedAt.IsZero() && time.Since(s.LastRelayedAt) > stuckAfter {
+		s.Issues = append(s.Issues, fmt.Sprintf(
+			"%d packets and acks are pending and no client was updated for %s, the relayer looks stuck",
+			pending,
+			time.Since(s.LastRelayedAt).Round(time.Minute),
+		))
+	}
+
+	return s
+}
+
+// QueryClients returns the state of the light clients of the active
+// connection on both chains
+func (r *Relayer) QueryClients(raData consts.RollappData, hd consts.HubData) ([]ClientStatus, error) {
+	raConn, hubConn, err := r.GetActiveConnections(raData, hd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query connections: %w", err)
+	}
+	if raConn == nil || hubConn == nil {
+		return nil, errors.New("no open connection between the hub and the rollapp")
+	}
+
+	clients, errs := queryClients(raData, hd, raConn, hubConn)
+	return clients, errors.Join(errs...)
+}
+
+func queryClients(
+	raData consts.RollappData,
+	hd consts.HubData,
+	raConn, hubConn *ConnectionInfo,
+) ([]ClientStatus, []error) {
+	clients := []struct {
+		binary, node, chain, tracked, clientID string
+	}{
+		{consts.Executables.Dymension, hd.RpcUrl, hd.ID, raData.ID, hubConn.ClientID},
+		{consts.Executables.RollappEVM, raData.RpcUrl, raData.ID, hd.ID, raConn.ClientID},
+	}
+
+	var statuses []ClientStatus
+	var errs []error
+	for _, c := range clients {
+		cs, err := queryClientStatus(c.binary, c.node, c.chain, c.clientID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to query client %s on %s: %w", c.clientID, c.chain, err))
+			continue
+		}
+		cs.TrackedChain = c.tracked
+		statuses = append(statuses, *cs)
+	}
+	return statuses, errs
+}
+
+type clientStateResponse struct {
+	ClientState struct {
+		ChainID        string `json:"chain_id"`
+		TrustingPeriod string `json:"trusting_period"`
+		LatestHeight   struct {
+			RevisionHeight string `json:"revision_height"`
+		} `json:"latest_height"`
+	} `json:"client_state"`
+}
+
+type consensusStateResponse struct {
+	ConsensusState struct {
+		Timestamp time.Time `json:"timestamp"`
+	} `json:"consensus_state"`
+}
+
+func queryClientStatus(binary, node, chainID, clientID string) (*ClientStatus, error) {
+	common := []string{"--node", node, "--chain-id", chainID, "-o", "json"}
+
+	out, err := bash.ExecCommandWithStdoutFiltered(exec.Command(
+		binary, append([]string{"q", "ibc", "client", "state", clientID}, common...)...,
+	))
+	if err != nil {
+		return nil, err
+	}
+	var csResp clientStateResponse
+	if err := json.Unmarshal(out.Bytes(), &csResp); err != nil {
+		return nil, err
+	}
+
+	out, err = bash.ExecCommandWithStdoutFiltered(exec.Command(
+		binary,
+		append([]string{"q", "ibc", "client", "consensus-state", clientID, "--latest-height"}, common...)...,
+	))
+	if err != nil {
+		return nil, err
+	}
+	var consResp consensusStateResponse
+	if err := json.Unmarshal(out.Bytes(), &consResp); err != nil {
+		return nil, err
+	}
+
+	height, err := strconv.ParseInt(csResp.ClientState.LatestHeight.RevisionHeight, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid latest height: %w", err)
+	}
+	trustingPeriod, err := time.ParseDuration(csResp.ClientState.TrustingPeriod)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trusting period: %w", err)
+	}
+
+	cs := &ClientStatus{
+		Chain:          chainID,
+		ClientID:       clientID,
+		LatestHeight:   height,
+		LastUpdate:     consResp.ConsensusState.Timestamp,
+		TrustingPeriod: trustingPeriod,
+		ExpiresAt:      consResp.ConsensusState.Timestamp.Add(trustingPeriod),
+	}
+	cs.Expired = time.Now().After(cs.ExpiresAt)
+
+	return cs, nil
+}
+
+// UnrelayedSequences is the output of the rly unrelayed queries. Src holds
+// the sequences on the source chain of the path, i.e. the hub
+type UnrelayedSequences struct {
+	Src []uint64 `json:"src"`
+	Dst []uint64 `json:"dst"`
+}
+
+// QueryUnrelayedPackets returns the sequences of the packets sent on either
+// end of a hub channel that were not received by the counterparty
+func (r *Relayer) QueryUnrelayedPackets(hubChannel string) (*UnrelayedSequences, error) {
+	return r.queryUnrelayed("unrelayed-packets", hubChannel)
+}
+
+// QueryUnrelayedAcks returns the sequences of the packets whose
+// acknowledgement, written on either end of a hub channel, was not relayed
+// back to the sender
+func (r *Relayer) QueryUnrelayedAcks(hubChannel string) (*UnrelayedSequences, error) {
+	return r.queryUnrelayed("unrelayed-acknowledgements", hubChannel)
+}
+
+func (r *Relayer) queryUnrelayed(query, hubChannel string) (*UnrelayedSequences, error) {
+	args := []string{"q", query, r.Path, hubChannel}
+	args = append(args, "--home", filepath.Join(r.RollerHome, consts.ConfigDirName.Relayer))
+
+	out, err := bash.ExecCommandWithStdout(exec.Command(consts.Executables.Relayer, args...))
+	if err != nil {
+		return nil, err
+	}
+
+	var seqs UnrelayedSequences
+	if err := json.Unmarshal(out.Bytes(), &seqs); err != nil {
+		return nil, err
+	}
+	return &seqs, nil
+}
+
+func firstErr(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,374 @@
+package relayer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/bash"
+	"github.com/dymensionxyz/roller/utils/notify"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+const (
+	watchdogStateFileName = "watchdog_state.json"
+
+	DefaultWatchdogInterval        = 10 * time.Minute
+	DefaultWatchdogUpdateThreshold = "50%"
+	alertExpired                   = "expired"
+	// minUpdateInterval avoids retrying a failing client update on every check
+	minUpdateInterval = 30 * time.Minute
+)
+
+var DefaultWatchdogAlertThresholds = []string{"50%", "25%", "10%"}
+
+// Threshold is an amount of time left before a client expires, given either
+// as a share of its trusting period or as a fixed duration
+type Threshold struct {
+	Raw      string
+	share    float64
+	duration time.Duration
+}
+
+func ParseThreshold(s string) (Threshold, error) {
+	t := Threshold{Raw: s}
+
+	if p, ok := strings.CutSuffix(s, "%"); ok {
+		share, err := strconv.ParseFloat(p, 64)
+		if err != nil || share <= 0 || share >= 100 {
+			return t, fmt.Errorf("invalid threshold %q, expected a percentage between 0 and 100", s)
+		}
+		t.share = share / 100
+		return t, nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return t, fmt.Errorf("invalid threshold %q, expected a percentage or a duration", s)
+	}
+	t.duration = d
+	return t, nil
+}
+
+// Left is the time left before expiry at which a client crosses the threshold
+func (t Threshold) Left(trustingPeriod time.Duration) time.Duration {
+	if t.duration > 0 {
+		return t.duration
+	}
+	return time.Duration(float64(trustingPeriod) * t.share)
+}
+
+// WatchedClient is a client tracked by the watchdog, along with the lowest
+// alert threshold it crossed since its last update
+type WatchedClient struct {
+	ClientStatus `yaml:",inline"`
+	Alerted      string `json:"alerted,omitempty" yaml:"alerted,omitempty"`
+}
+
+// WatchdogAlert is raised when a client crosses an alert threshold
+type WatchdogAlert struct {
+	Time      time.Time `json:"time" yaml:"time"`
+	Chain     string    `json:"chain" yaml:"chain"`
+	ClientID  string    `json:"client_id" yaml:"client_id"`
+	Threshold string    `json:"threshold" yaml:"threshold"`
+	Message   string    `json:"message" yaml:"message"`
+}
+
+// WatchdogState is persisted in the relayer home after every check, so that
+// the relayer status can report it
+type WatchdogState struct {
+	CheckedAt       time.Time       `json:"checked_at" yaml:"checked_at"`
+	CheckError      string          `json:"check_error,omitempty" yaml:"check_error,omitempty"`
+	Clients         []WatchedClient `json:"clients" yaml:"clients"`
+	UpdateThreshold string          `json:"update_threshold" yaml:"update_threshold"`
+	LastUpdateAt    time.Time       `json:"last_update_at,omitempty" yaml:"last_update_at,omitempty"`
+	LastUpdateError string          `json:"last_update_error,omitempty" yaml:"last_update_error,omitempty"`
+	Alerts          []WatchdogAlert `json:"alerts" yaml:"alerts"`
+}
+
+// maxAlerts is the number of alerts kept in the watchdog state
+const maxAlerts = 20
+
+// Watchdog tracks the trusting period expiry of the light clients of a
+// relayer path, raises alerts at the configured thresholds and updates the
+// clients before they expire
+type Watchdog struct {
+	rly             *Relayer
+	interval        time.Duration
+	alertThresholds []Threshold
+	updateThreshold Threshold
+	state           WatchdogState
+	notifier        *notify.Dispatcher
+}
+
+func NewWatchdog(rly *Relayer, cfg roller.ClientWatchdogConfig) (*Watchdog, error) {
+	w := &Watchdog{rly: rly, interval: DefaultWatchdogInterval}
+
+	if cfg.Interval != "" {
+		d, err := time.ParseDuration(cfg.Interval)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid watchdog interval %q", cfg.Interval)
+		}
+		w.interval = d
+	}
+
+	alertThresholds := cfg.AlertThresholds
+	if len(alertThresholds) == 0 {
+		alertThresholds = DefaultWatchdogAlertThresholds
+	}
+	for _, s := range alertThresholds {
+		t, err := ParseThreshold(s)
+		if err != nil {
+			return nil, err
+		}
+		w.alertThresholds = append(w.alertThresholds, t)
+	}
+
+	updateThreshold := cfg.UpdateThreshold
+	if updateThreshold == "" {
+		updateThreshold = DefaultWatchdogUpdateThreshold
+	}
+	t, err := ParseThreshold(updateThreshold)
+	if err != nil {
+		return nil, err
+	}
+	w.updateThreshold = t
+
+	state, err := LoadWatchdogState(rly.RelayerHome, rly.Path)
+	if err != nil {
+		return nil, err
+	}
+	if state != nil {
+		w.state = *state
+	}
+	w.state.UpdateThreshold = updateThreshold
+
+	return w, nil
+}
+
+// WithNotifier sends the alerts of the watchdog to the notification channels
+func (w *Watchdog) WithNotifier(d *notify.Dispatcher) *Watchdog {
+	w.notifier = d
+	return w
+}
+
+// Run checks the clients every interval until the context is cancelled
+func (w *Watchdog) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.Check()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check queries the clients, raises the alerts of the newly crossed
+// thresholds and updates the clients when the update threshold is crossed
+func (w *Watchdog) Check() WatchdogState {
+	r := w.rly
+	now := time.Now().UTC()
+	w.state.CheckedAt = now
+
+	clients, err := r.QueryClients(r.Rollapp, r.Hub)
+	if err != nil {
+		w.state.CheckError = err.Error()
+		w.log(err)
+	} else {
+		w.state.CheckError = ""
+	}
+
+	if len(clients) > 0 && w.needsUpdate(clients) && now.Sub(w.state.LastUpdateAt) > minUpdateInterval {
+		w.log("updating clients ahead of expiry")
+		w.state.LastUpdateAt = now
+		_, err := bash.ExecCommandWithStdout(r.GetUpdateClientsCmd())
+		if err != nil {
+			w.state.LastUpdateError = err.Error()
+			w.log("failed to update clients:", err)
+		} else {
+			w.state.LastUpdateError = ""
+			if updated, err := r.QueryClients(r.Rollapp, r.Hub); err == nil {
+				clients = updated
+			}
+		}
+	}
+
+	w.track(clients, now)
+	if err := w.save(); err != nil {
+		w.log("failed to save state:", err)
+	}
+
+	return w.state
+}
+
+func (w *Watchdog) needsUpdate(clients []ClientStatus) bool {
+	for _, c := range clients {
+		// an expired client can only be recovered through governance
+		if !c.Expired && time.Until(c.ExpiresAt) <= w.updateThreshold.Left(c.TrustingPeriod) {
+			return true
+		}
+	}
+	return false
+}
+
+// track replaces the watched clients and raises an alert for every client
+// that crossed a lower threshold than the one it was last alerted for. The
+// alert level is reset when the client expiry moves, i.e. it was updated
+func (w *Watchdog) track(clients []ClientStatus, now time.Time) {
+	previous := map[string]WatchedClient{}
+	for _, c := range w.state.Clients {
+		previous[c.Chain+"/"+c.ClientID] = c
+	}
+
+	watched := []WatchedClient{}
+	for _, c := range clients {
+		wc := WatchedClient{ClientStatus: c}
+		if prev, ok := previous[c.Chain+"/"+c.ClientID]; ok && prev.ExpiresAt.Equal(c.ExpiresAt) {
+			wc.Alerted = prev.Alerted
+		}
+
+		if a := w.alertFor(c, wc.Alerted, now); a != nil {
+			wc.Alerted = a.Threshold
+			w.alert(*a)
+		}
+
+		watched = append(watched, wc)
+	}
+
+	if len(clients) > 0 {
+		w.state.Clients = watched
+	}
+}
+
+// alertFor returns the alert to raise for a client that was last alerted at
+// the given level, if it expired or crossed a lower threshold since
+func (w *Watchdog) alertFor(c ClientStatus, alerted string, now time.Time) *WatchdogAlert {
+	a := &WatchdogAlert{Time: now, Chain: c.Chain, ClientID: c.ClientID}
+
+	if c.Expired {
+		if alerted == alertExpired {
+			return nil
+		}
+		a.Threshold = alertExpired
+		a.Message = fmt.Sprintf(
+			"client %s on %s tracking %s is expired, it can only be recovered through governance",
+			c.ClientID, c.Chain, c.TrackedChain,
+		)
+		return a
+	}
+
+	crossed := w.crossed(c)
+	if crossed == nil {
+		return nil
+	}
+	if alerted != "" {
+		prev, err := ParseThreshold(alerted)
+		if err == nil && crossed.Left(c.TrustingPeriod) >= prev.Left(c.TrustingPeriod) {
+			return nil
+		}
+	}
+
+	a.Threshold = crossed.Raw
+	a.Message = fmt.Sprintf(
+		"client %s on %s tracking %s expires in %s",
+		c.ClientID, c.Chain, c.TrackedChain, time.Until(c.ExpiresAt).Round(time.Minute),
+	)
+	return a
+}
+
+// crossed returns the lowest alert threshold crossed by a client
+func (w *Watchdog) crossed(c ClientStatus) *Threshold {
+	left := time.Until(c.ExpiresAt)
+
+	var lowest *Threshold
+	for i, t := range w.alertThresholds {
+		if left > t.Left(c.TrustingPeriod) {
+			continue
+		}
+		if lowest == nil || t.Left(c.TrustingPeriod) < lowest.Left(c.TrustingPeriod) {
+			lowest = &w.alertThresholds[i]
+		}
+	}
+	return lowest
+}
+
+func (w *Watchdog) alert(a WatchdogAlert) {
+	w.log("alert:", a.Message)
+
+	severity := notify.SeverityWarning
+	if a.Threshold == alertExpired {
+		severity = notify.SeverityCritical
+	}
+	err := w.notifier.Emit(notify.Event{
+		Time:     a.Time,
+		Source:   notify.SourceRelayer,
+		Check:    fmt.Sprintf("client/%s/%s", a.Chain, a.ClientID),
+		Severity: severity,
+		Title:    fmt.Sprintf("IBC client %s on %s crossed the %s expiry threshold", a.ClientID, a.Chain, a.Threshold),
+		Message:  a.Message,
+		Fields:   map[string]string{"path": w.rly.Path},
+	})
+	if err != nil {
+		w.log("failed to send notification:", err)
+	}
+
+	w.state.Alerts = append(w.state.Alerts, a)
+	sort.Slice(w.state.Alerts, func(i, j int) bool { return w.state.Alerts[i].Time.Before(w.state.Alerts[j].Time) })
+	if len(w.state.Alerts) > maxAlerts {
+		w.state.Alerts = w.state.Alerts[len(w.state.Alerts)-maxAlerts:]
+	}
+}
+
+func (w *Watchdog) log(v ...any) {
+	w.rly.logger.Println(append([]any{fmt.Sprintf("client watchdog %s:", w.rly.Path)}, v...)...)
+}
+
+func (w *Watchdog) save() error {
+	b, err := json.MarshalIndent(w.state, "", "  ")
+	if err != nil {
+		return err
+	}
+	// nolint:gofumpt
+	return os.WriteFile(watchdogStatePath(w.rly.RelayerHome, w.rly.Path), b, 0o644)
+}
+
+// watchdogStatePath returns the state file of the watchdog of a path, the
+// default path keeps the file it used before several paths were relayed
+func watchdogStatePath(relayerHome, path string) string {
+	name := watchdogStateFileName
+	if path != "" && path != consts.DefaultRelayerPath {
+		name = fmt.Sprintf("watchdog_state_%s.json", path)
+	}
+	return filepath.Join(relayerHome, name)
+}
+
+// LoadWatchdogState returns the state saved by the last watchdog check of a
+// path, or nil when the watchdog never ran for it
+func LoadWatchdogState(relayerHome, path string) (*WatchdogState, error) {
+	b, err := os.ReadFile(watchdogStatePath(relayerHome, path))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var state WatchdogState
+	if err := json.Unmarshal(b, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse watchdog state: %w", err)
+	}
+	return &state, nil
+}
@@ -3,7 +3,6 @@ package sequencer
 import (
 	"encoding/json"
 	"fmt"
-	"os"
 	"path/filepath"
 	"strings"
 
@@ -13,7 +12,6 @@ import (
 
 	"github.com/dymensionxyz/roller/cmd/consts"
 	datalayer "github.com/dymensionxyz/roller/data_layer"
-	"github.com/dymensionxyz/roller/data_layer/celestia"
 	"github.com/dymensionxyz/roller/utils/config/tomlconfig"
 	"github.com/dymensionxyz/roller/utils/genesis"
 	"github.com/dymensionxyz/roller/utils/roller"
@@ -24,7 +22,7 @@ type ConfigEntry map[string]interface{}
 
 func SetDefaultDymintConfig(rlpCfg roller.RollappConfig) error {
 	dymintTomlPath := sequencerutils.GetDymintFilePath(rlpCfg.Home)
-	dymintCfg, err := toml.LoadFile(dymintTomlPath)
+	dymintCfg, err := tomlconfig.LoadFile(dymintTomlPath)
 	if err != nil {
 		return err
 	}
@@ -50,20 +48,22 @@ func SetDefaultDymintConfig(rlpCfg roller.RollappConfig) error {
 	dymintCfg.Set("keyring_backend", string(rlpCfg.KeyringBackend))
 	dymintCfg.Set("gas_prices", rlpCfg.HubData.GasPrice+consts.Denoms.Hub)
 	dymintCfg.Set("instrumentation.prometheus", true)
-	dymintCfg.Set("instrumentation.prometheus_listen_addr", ":2112")
+	dymintCfg.Set(
+		"p2p_listen_address",
+		fmt.Sprintf("/ip4/0.0.0.0/tcp/%d", roller.ProfilePort(consts.DefaultPorts.RollappP2P)),
+	)
+	dymintCfg.Set(
+		"instrumentation.prometheus_listen_addr",
+		fmt.Sprintf(":%d", roller.ProfilePort(consts.DefaultPorts.RollappMetrics)),
+	)
 	dymintCfg.Set("batch_submit_time", "1h0m0s")
 
-	file, err := os.Create(dymintTomlPath)
-	if err != nil {
-		return err
-	}
-	_, err = file.WriteString(dymintCfg.String())
-	return err
+	return tomlconfig.WriteTomlTreeToFile(dymintCfg, dymintTomlPath)
 }
 
 func UpdateDymintDAConfig(rlpCfg roller.RollappConfig) error {
 	dymintTomlPath := sequencerutils.GetDymintFilePath(rlpCfg.Home)
-	dymintCfg, err := toml.LoadFile(dymintTomlPath)
+	dymintCfg, err := tomlconfig.LoadFile(dymintTomlPath)
 	if err != nil {
 		return err
 	}
@@ -74,18 +74,14 @@ func UpdateDymintDAConfig(rlpCfg roller.RollappConfig) error {
 }
 
 func updateDaConfigInToml(rlpCfg roller.RollappConfig, dymintCfg *toml.Tree) error {
-	damanager := datalayer.NewDAManager(rlpCfg.DA.Backend, rlpCfg.Home, rlpCfg.KeyringBackend, rlpCfg.NodeType)
+	damanager, err := datalayer.NewDAManager(rlpCfg.DA.Backend, rlpCfg.Home, rlpCfg.KeyringBackend, rlpCfg.NodeType)
+	if err != nil {
+		return err
+	}
 	dymintCfg.Set("da_layer", "mock")
 
-	if rlpCfg.DA.Backend == consts.Celestia {
-		celDAManager, ok := damanager.DataLayer.(*celestia.Celestia)
-		if !ok {
-			return fmt.Errorf(
-				"invalid damanager type, expected *celestia.Celestia, got %T",
-				damanager.DataLayer,
-			)
-		}
-		dymintCfg.Set("namespace_id", celDAManager.NamespaceID)
+	if rlpCfg.DA.Backend == consts.Celestia || rlpCfg.DA.Backend == consts.Generic {
+		dymintCfg.Set("namespace_id", damanager.GetNamespaceID())
 	}
 
 	if rlpCfg.DA.Backend == consts.Local {
@@ -97,7 +93,7 @@ func updateDaConfigInToml(rlpCfg roller.RollappConfig, dymintCfg *toml.Tree) err
 
 func UpdateDymintDANodeConfig(rlpCfg roller.RollappConfig, key string) error {
 	dymintTomlPath := sequencerutils.GetDymintFilePath(rlpCfg.Home)
-	dymintCfg, err := toml.LoadFile(dymintTomlPath)
+	dymintCfg, err := tomlconfig.LoadFile(dymintTomlPath)
 	if err != nil {
 		return err
 	}
@@ -140,7 +136,7 @@ func SetAppConfig(rlpCfg roller.RollappConfig) error {
 		sequencerutils.GetSequencerConfigDir(rlpCfg.Home),
 		"app.toml",
 	)
-	appCfg, err := toml.LoadFile(appConfigFilePath)
+	appCfg, err := tomlconfig.LoadFile(appConfigFilePath)
 	if err != nil {
 		return fmt.Errorf("failed to load %s: %v", appConfigFilePath, err)
 	}
@@ -170,10 +166,26 @@ func SetAppConfig(rlpCfg roller.RollappConfig) error {
 	appCfg.Set("gas-adjustment", 1.3)
 	appCfg.Set("api.enable", true)
 	appCfg.Set("api.enabled-unsafe-cors", true)
+	appCfg.Set(
+		"api.address",
+		fmt.Sprintf("tcp://0.0.0.0:%d", roller.ProfilePort(consts.DefaultPorts.RollappAPI)),
+	)
+	if appCfg.Has("grpc") {
+		appCfg.Set(
+			"grpc.address",
+			fmt.Sprintf("0.0.0.0:%d", roller.ProfilePort(consts.DefaultPorts.RollappGRPC)),
+		)
+	}
 
 	if appCfg.Has("json-rpc") {
-		appCfg.Set("json-rpc.address", "0.0.0.0:8545")
-		appCfg.Set("json-rpc.ws-address", "0.0.0.0:8546")
+		appCfg.Set(
+			"json-rpc.address",
+			fmt.Sprintf("0.0.0.0:%d", roller.ProfilePort(consts.DefaultPorts.RollappJsonRPC)),
+		)
+		appCfg.Set(
+			"json-rpc.ws-address",
+			fmt.Sprintf("0.0.0.0:%d", roller.ProfilePort(consts.DefaultPorts.RollappJsonRPCWS)),
+		)
 	}
 	return tomlconfig.WriteTomlTreeToFile(appCfg, appConfigFilePath)
 }
@@ -183,12 +195,15 @@ func SetTMConfig(rlpCfg roller.RollappConfig) error {
 		sequencerutils.GetSequencerConfigDir(rlpCfg.Home),
 		"config.toml",
 	)
-	tomlCfg, err := toml.LoadFile(configFilePath)
+	tomlCfg, err := tomlconfig.LoadFile(configFilePath)
 	if err != nil {
 		return fmt.Errorf("failed to load %s: %v", configFilePath, err)
 	}
 
-	tomlCfg.Set("rpc.laddr", "tcp://0.0.0.0:26657")
+	tomlCfg.Set(
+		"rpc.laddr",
+		fmt.Sprintf("tcp://0.0.0.0:%d", roller.ProfilePort(consts.DefaultPorts.RollappRPC)),
+	)
 	tomlCfg.Set("rpc.timeout_broadcast_tx_commit", "30s")
 	tomlCfg.Set("rpc.max_subscriptions_per_client", "10")
 	tomlCfg.Set("log_level", "debug")
@@ -206,7 +221,7 @@ func (seq *Sequencer) ReadPorts() error {
 	}
 
 	seq.RPCPort = getPortFromAddress(rpcAddr)
-	appCfg, err := toml.LoadFile(
+	appCfg, err := tomlconfig.LoadFile(
 		filepath.Join(sequencerutils.GetSequencerConfigDir(seq.RlpCfg.Home), "app.toml"),
 	)
 	if err != nil {
@@ -225,7 +240,7 @@ func (seq *Sequencer) GetConfigValue(key string) (string, error) {
 		sequencerutils.GetSequencerConfigDir(seq.RlpCfg.Home),
 		"config.toml",
 	)
-	tomlCfg, err := toml.LoadFile(configFilePath)
+	tomlCfg, err := tomlconfig.LoadFile(configFilePath)
 	if err != nil {
 		return "", fmt.Errorf("failed to load %s: %v", configFilePath, err)
 	}
@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 
 	"github.com/dymensionxyz/roller/utils/bash"
+	"github.com/dymensionxyz/roller/utils/binstore"
 	dependencytypes "github.com/dymensionxyz/roller/utils/dependencies/types"
 )
 
@@ -68,9 +69,15 @@ func ExtractTarGz(path string, data io.ReadCloser, dep dependencytypes.Dependenc
 }
 
 // MoveBinaryIntoPlaceAndMakeExecutable is a fantastic function name, ik
+// Binaries kept in the binary store are installed as a new version of dest
 func MoveBinaryIntoPlaceAndMakeExecutable(
 	src, dest string,
 ) error {
+	if binstore.IsTracked(dest) {
+		_, err := binstore.Install(src, dest)
+		return err
+	}
+
 	err := bash.ExecCommandWithInteractions(
 		"sudo",
 		"mv",
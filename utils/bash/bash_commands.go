@@ -18,6 +18,7 @@ import (
 	"github.com/pterm/pterm"
 
 	"github.com/dymensionxyz/roller/utils/errorhandling"
+	"github.com/dymensionxyz/roller/utils/plan"
 )
 
 func RunCommandEvery(
@@ -108,6 +109,10 @@ func RunCmdAsync(
 }
 
 func ExecCommandWithStdout(cmd *exec.Cmd) (*bytes.Buffer, error) {
+	if plan.Intercept(cmd) {
+		return &bytes.Buffer{}, nil
+	}
+
 	var stderr bytes.Buffer
 	var stdout bytes.Buffer
 	cmd.Stderr = &stderr
@@ -122,6 +127,10 @@ func ExecCommandWithStdout(cmd *exec.Cmd) (*bytes.Buffer, error) {
 // ExecCommandWithStdoutFiltered executes a command and returns stdout,
 // filtering out "duplicate proto type registered" warnings from stderr
 func ExecCommandWithStdoutFiltered(cmd *exec.Cmd) (*bytes.Buffer, error) {
+	if plan.Intercept(cmd) {
+		return &bytes.Buffer{}, nil
+	}
+
 	var stderr bytes.Buffer
 	var stdout bytes.Buffer
 	cmd.Stderr = &stderr
@@ -136,6 +145,10 @@ func ExecCommandWithStdoutFiltered(cmd *exec.Cmd) (*bytes.Buffer, error) {
 }
 
 func ExecCommandWithStdErr(cmd *exec.Cmd) (*bytes.Buffer, error) {
+	if plan.Intercept(cmd) {
+		return &bytes.Buffer{}, nil
+	}
+
 	var stderr bytes.Buffer
 	var stdout bytes.Buffer
 	cmd.Stderr = &stderr
@@ -150,6 +163,10 @@ func ExecCommandWithStdErr(cmd *exec.Cmd) (*bytes.Buffer, error) {
 // ExecCommandWithStdErrFiltered executes a command and returns stderr,
 // filtering out "duplicate proto type registered" warnings
 func ExecCommandWithStdErrFiltered(cmd *exec.Cmd) (*bytes.Buffer, error) {
+	if plan.Intercept(cmd) {
+		return &bytes.Buffer{}, nil
+	}
+
 	var stderr bytes.Buffer
 	var stdout bytes.Buffer
 	cmd.Stderr = &stderr
@@ -168,6 +185,9 @@ func ExecCmd(cmd *exec.Cmd, options ...CommandOption) error {
 	for _, option := range options {
 		option(cmd)
 	}
+	if plan.Intercept(cmd) {
+		return nil
+	}
 	err := cmd.Run()
 	if err != nil {
 		return fmt.Errorf("command execution failed: %v", err)
@@ -282,6 +302,9 @@ func ExecCmdFollow(
 func ExecCommandWithInteractions(cmdName string, args ...string) error {
 	ctx := context.Background()
 	cmd := exec.CommandContext(ctx, cmdName, args...)
+	if plan.Intercept(cmd) {
+		return nil
+	}
 
 	// Use the current process's standard input, output, and error
 	cmd.Stdin = os.Stdin
@@ -312,6 +335,9 @@ func ExecCommandWithInput(
 	} else {
 		pt = promptText[0]
 	}
+	if plan.Intercept(cmd) {
+		return "", nil
+	}
 	stdin, err := cmd.StdinPipe()
 	if err != nil {
 		return "", fmt.Errorf("error creating stdin pipe: %w", err)
@@ -404,6 +430,9 @@ func ExecuteCommandWithPrompts(
 	promptResponses map[string]string,
 ) (*bytes.Buffer, error) {
 	cmd := exec.Command(command, args...)
+	if plan.Intercept(cmd) {
+		return &bytes.Buffer{}, nil
+	}
 	// Create pipes
 	stdin, err := cmd.StdinPipe()
 	if err != nil {
@@ -432,6 +461,9 @@ func ExecuteCommandWithPromptsStdout(
 	promptResponses map[string]string,
 ) (*bytes.Buffer, error) {
 	cmd := exec.Command(command, args...)
+	if plan.Intercept(cmd) {
+		return &bytes.Buffer{}, nil
+	}
 	// Create pipes
 	stdin, err := cmd.StdinPipe()
 	if err != nil {
@@ -478,6 +510,9 @@ func ExecuteCommandWithPromptHandler(
 	manualPrompts map[string]string,
 ) (*bytes.Buffer, error) {
 	cmd := exec.Command(command, args...)
+	if plan.Intercept(cmd) {
+		return &bytes.Buffer{}, nil
+	}
 
 	// Create pipes
 	stdin, err := cmd.StdinPipe()
@@ -563,6 +598,9 @@ func ExecuteCommandWithPromptHandlerFiltered(
 	manualPrompts map[string]string,
 ) (*bytes.Buffer, error) {
 	cmd := exec.Command(command, args...)
+	if plan.Intercept(cmd) {
+		return &bytes.Buffer{}, nil
+	}
 
 	// Create pipes
 	stdin, err := cmd.StdinPipe()
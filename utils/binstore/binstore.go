@@ -0,0 +1,394 @@
+// Package binstore keeps the installed versions of each binary side by side.
+// The path roller and the services run a binary from is a symlink to the
+// active version, switching versions only swaps the symlink
+package binstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/bash"
+	"github.com/dymensionxyz/roller/utils/plan"
+)
+
+const (
+	// previousLink is the symlink of a binary directory pointing to the version
+	// that was active before the current one
+	previousLink   = "previous"
+	versionTimeout = 10 * time.Second
+)
+
+// Dir is the root of the store, versions are kept in <Dir>/<binary>/<version>
+var Dir = filepath.Join(consts.InternalBinsDir, "versions")
+
+// Binaries are the binaries whose versions are kept in the store
+var Binaries = []string{
+	consts.Executables.RollappEVM,
+	consts.Executables.Dymension,
+	consts.Executables.Celestia,
+	consts.Executables.CelKey,
+	consts.Executables.CelestiaApp,
+	consts.Executables.Relayer,
+	consts.Executables.Eibc,
+	consts.Executables.PriceOracle,
+	consts.Executables.RngOracle,
+	consts.Executables.RngOracleRandomService,
+	consts.Executables.Solc,
+	consts.Executables.AlertAgent,
+}
+
+var semver = regexp.MustCompile(`v?\d+\.\d+\.\d+[0-9A-Za-z.+-]*`)
+
+var token = regexp.MustCompile(`^[0-9A-Za-z.+-]+$`)
+
+type Version struct {
+	ID       string    `json:"id"`
+	Path     string    `json:"path"`
+	Active   bool      `json:"active"`
+	Previous bool      `json:"previous"`
+	Added    time.Time `json:"added"`
+}
+
+type Binary struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+	// Active is the active version, empty when the binary is not installed
+	Active string `json:"active,omitempty"`
+	// Managed is false for binaries installed before the store was used,
+	// they are moved into the store on the next install or switch
+	Managed  bool      `json:"managed"`
+	Previous string    `json:"previous,omitempty"`
+	Versions []Version `json:"versions"`
+}
+
+// IsTracked returns whether dest is the path of one of the Binaries, whose
+// versions are kept in the store once installed with Install. It doesn't tell
+// whether the binary at dest was installed through the store
+func IsTracked(dest string) bool {
+	bin, ok := lookup(filepath.Base(dest))
+	return ok && filepath.Clean(dest) == bin
+}
+
+// Install moves the binary at src into the store and makes it the active
+// version of dest. The version it replaces is recorded as the previous one
+// and is returned
+func Install(src, dest string) (string, error) {
+	name := filepath.Base(dest)
+	if plan.Enabled() {
+		plan.Record(plan.KindCommand, "install %s into %s and link it to %s", name, filepath.Join(Dir, name), dest)
+		return "", nil
+	}
+
+	current, err := adopt(dest)
+	if err != nil {
+		return "", err
+	}
+
+	id, err := newVersionID(src, name)
+	if err != nil {
+		return "", err
+	}
+
+	err = store(src, name, id)
+	if err == nil {
+		err = activate(name, id, current)
+	}
+	if err != nil {
+		// keep the binary runnable when it was moved into the store
+		if current != "" {
+			_ = activate(name, current, "")
+		}
+		return "", err
+	}
+	if current == id {
+		return "", nil
+	}
+	return current, nil
+}
+
+// Use makes the installed version id of the binary the active one
+func Use(name, id string) error {
+	b, err := Get(name)
+	if err != nil {
+		return err
+	}
+	if !b.HasVersion(id) {
+		return fmt.Errorf("%s %s is not installed, run `roller binaries list` for the installed versions", name, id)
+	}
+	if b.Active == id && b.Managed {
+		return nil
+	}
+	if plan.Enabled() {
+		plan.Record(plan.KindCommand, "link %s to %s %s", b.Path, name, id)
+		return nil
+	}
+
+	current, err := adopt(b.Path)
+	if err != nil {
+		return err
+	}
+	return activate(name, id, current)
+}
+
+// Rollback makes the previous version of the binary the active one and returns
+// it, rolling back twice restores the initial version
+func Rollback(name string) (string, error) {
+	b, err := Get(name)
+	if err != nil {
+		return "", err
+	}
+	if b.Previous == "" {
+		return "", fmt.Errorf("no previous version of %s is recorded", name)
+	}
+	return b.Previous, Use(name, b.Previous)
+}
+
+// List returns the binaries that are installed on this host
+func List() ([]Binary, error) {
+	binaries := []Binary{}
+	for _, bin := range Binaries {
+		b, err := Get(filepath.Base(bin))
+		if err != nil {
+			return nil, err
+		}
+		if b.Active == "" && len(b.Versions) == 0 {
+			continue
+		}
+		binaries = append(binaries, *b)
+	}
+	return binaries, nil
+}
+
+// Get returns the versions of the binary installed on this host
+func Get(name string) (*Binary, error) {
+	if _, ok := lookup(name); !ok {
+		return nil, fmt.Errorf("unknown binary %s, expected one of: %s", name, strings.Join(names(), ", "))
+	}
+
+	b := &Binary{Name: name, Path: destination(name), Versions: []Version{}}
+	b.Active, b.Managed = activeVersion(b.Path)
+
+	if target, err := os.Readlink(filepath.Join(Dir, name, previousLink)); err == nil {
+		b.Previous = filepath.Base(target)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(Dir, name))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		v := Version{
+			ID:       e.Name(),
+			Path:     versionPath(name, e.Name()),
+			Active:   b.Managed && e.Name() == b.Active,
+			Previous: e.Name() == b.Previous,
+		}
+		if info, err := e.Info(); err == nil {
+			v.Added = info.ModTime()
+		}
+		b.Versions = append(b.Versions, v)
+	}
+	sort.Slice(b.Versions, func(i, j int) bool {
+		return b.Versions[i].Added.Before(b.Versions[j].Added)
+	})
+
+	return b, nil
+}
+
+func (b Binary) HasVersion(id string) bool {
+	for _, v := range b.Versions {
+		if v.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// BinaryVersion returns the first line of the version output of a binary,
+// empty when it has none
+func BinaryVersion(bin string) string {
+	out := versionOutput(bin)
+	line, _, _ := strings.Cut(out, "\n")
+	return strings.TrimSpace(line)
+}
+
+// adopt moves a binary installed at dest before the store was used into the
+// store. It returns the active version of dest, empty when it is not installed
+func adopt(dest string) (string, error) {
+	id, managed := activeVersion(dest)
+	if id == "" || managed {
+		return id, nil
+	}
+
+	name := filepath.Base(dest)
+	id, err := newVersionID(dest, name)
+	if err != nil {
+		return "", err
+	}
+	if err := store(dest, name, id); err != nil {
+		return "", fmt.Errorf("failed to move %s into the binary store: %w", dest, err)
+	}
+	return id, nil
+}
+
+// store moves the binary at src into the store as the version id of name
+func store(src, name, id string) error {
+	target := versionPath(name, id)
+	if err := sudo("mkdir", "-p", filepath.Dir(target)); err != nil {
+		return err
+	}
+	if err := sudo("mv", src, target); err != nil {
+		return err
+	}
+	return sudo("chmod", "+x", target)
+}
+
+// activate links dest to the version id of the binary and records previous
+func activate(name, id, previous string) error {
+	dest := destination(name)
+	if err := sudo("ln", "-sfn", versionPath(name, id), dest); err != nil {
+		return fmt.Errorf("failed to link %s: %w", dest, err)
+	}
+	if previous == "" || previous == id {
+		return nil
+	}
+	return sudo("ln", "-sfn", filepath.Join(Dir, name, previous), filepath.Join(Dir, name, previousLink))
+}
+
+// activeVersion returns the version dest links to and whether it is a link
+// into the store. Binaries installed outside of the store are identified by
+// their version output
+func activeVersion(dest string) (string, bool) {
+	info, err := os.Lstat(dest)
+	if err != nil {
+		return "", false
+	}
+	if info.Mode()&os.ModeSymlink != 0 {
+		target, err := os.Readlink(dest)
+		if err == nil && strings.HasPrefix(target, Dir+string(os.PathSeparator)) {
+			return filepath.Base(filepath.Dir(target)), true
+		}
+	}
+
+	id, err := versionID(dest)
+	if err != nil {
+		return "", false
+	}
+	return id, false
+}
+
+// newVersionID returns the id the binary at src is stored under, a version
+// already stored with different content is kept by suffixing the id with
+// the digest
+func newVersionID(src, name string) (string, error) {
+	id, err := versionID(src)
+	if err != nil {
+		return "", err
+	}
+
+	existing := versionPath(name, id)
+	if _, err := os.Stat(existing); err != nil {
+		return id, nil
+	}
+	want, err := fileSHA256(src)
+	if err != nil {
+		return "", err
+	}
+	got, err := fileSHA256(existing)
+	if err != nil || got == want {
+		return id, nil
+	}
+	return fmt.Sprintf("%s-%s", id, want[:8]), nil
+}
+
+// versionID returns the version reported by the binary, or its digest when
+// it doesn't report one
+func versionID(bin string) (string, error) {
+	out := versionOutput(bin)
+	if v := semver.FindString(out); v != "" {
+		return v, nil
+	}
+	if line, _, _ := strings.Cut(out, "\n"); token.MatchString(strings.TrimSpace(line)) {
+		return strings.TrimSpace(line), nil
+	}
+
+	digest, err := fileSHA256(bin)
+	if err != nil {
+		return "", err
+	}
+	return "sha256-" + digest[:12], nil
+}
+
+func versionOutput(bin string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), versionTimeout)
+	defer cancel()
+
+	arg := "version"
+	if filepath.Base(bin) == filepath.Base(consts.Executables.Solc) {
+		arg = "--version"
+	}
+	out, err := exec.CommandContext(ctx, bin, arg).CombinedOutput()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func versionPath(name, id string) string {
+	return filepath.Join(Dir, name, id, name)
+}
+
+func lookup(name string) (string, bool) {
+	for _, bin := range Binaries {
+		if filepath.Base(bin) == name {
+			return bin, true
+		}
+	}
+	return "", false
+}
+
+func destination(name string) string {
+	bin, _ := lookup(name)
+	return bin
+}
+
+func names() []string {
+	var n []string
+	for _, bin := range Binaries {
+		n = append(n, filepath.Base(bin))
+	}
+	return n
+}
+
+func sudo(args ...string) error {
+	return bash.ExecCommandWithInteractions("sudo", args...)
+}
+
+func fileSHA256(file string) (string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	// nolint: errcheck
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
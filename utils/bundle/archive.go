@@ -0,0 +1,166 @@
+package bundle
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// IsArchive returns whether the bundle path is a tarball rather than a
+// directory
+func IsArchive(p string) bool {
+	return strings.HasSuffix(p, ".tar.gz") || strings.HasSuffix(p, ".tgz") ||
+		strings.HasSuffix(p, ".tar")
+}
+
+// Archive packs the bundle in dir into a gzipped tarball
+func Archive(dir, out string) error {
+	f, err := os.Create(out)
+	if err != nil {
+		return err
+	}
+	// nolint: errcheck
+	defer f.Close()
+
+	gw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gw)
+
+	err = filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil || rel == "." {
+			return err
+		}
+
+		hdr, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(rel)
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		src, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		// nolint: errcheck
+		defer src.Close()
+		_, err = io.Copy(tw, src)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	if err := gw.Close(); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// Open returns the directory of the bundle at p. Tarballs are extracted into a
+// temporary directory removed by the returned cleanup function
+func Open(p string) (string, func(), error) {
+	info, err := os.Stat(p)
+	if err != nil {
+		return "", nil, err
+	}
+	if info.IsDir() {
+		return p, func() {}, nil
+	}
+
+	dir, err := os.MkdirTemp("", "roller-bundle")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup := func() { _ = os.RemoveAll(dir) }
+
+	if err := extract(p, dir); err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("failed to extract bundle: %w", err)
+	}
+	return dir, cleanup, nil
+}
+
+func extract(archive, dir string) error {
+	f, err := os.Open(archive)
+	if err != nil {
+		return err
+	}
+	// nolint: errcheck
+	defer f.Close()
+
+	br := bufio.NewReader(f)
+	var r io.Reader = br
+	if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gr, err := gzip.NewReader(br)
+		if err != nil {
+			return err
+		}
+		// nolint: errcheck
+		defer gr.Close()
+		r = gr
+	}
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		target := filepath.Join(dir, filepath.FromSlash(hdr.Name))
+		if target == filepath.Clean(dir) {
+			continue
+		}
+		if !strings.HasPrefix(target, filepath.Clean(dir)+string(os.PathSeparator)) {
+			return fmt.Errorf("invalid path in bundle: %s", hdr.Name)
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			// nolint: gofumpt
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			// nolint: gofumpt
+			if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				return err
+			}
+			out, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, os.FileMode(hdr.Mode)&0o755)
+			if err != nil {
+				return err
+			}
+			// nolint: gosec
+			if _, err := io.Copy(out, tr); err != nil {
+				// nolint: errcheck
+				out.Close()
+				return err
+			}
+			if err := out.Close(); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unsupported entry in bundle: %s", hdr.Name)
+		}
+	}
+}
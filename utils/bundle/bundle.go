@@ -0,0 +1,313 @@
+// Package bundle packs the binaries, version manifest, genesis file and
+// contract artifacts of a rollapp node into a bundle that can be installed
+// without network access
+package bundle
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/config"
+	"github.com/dymensionxyz/roller/utils/binstore"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/rollapp"
+	"github.com/dymensionxyz/roller/utils/versions"
+)
+
+const (
+	ManifestFileName = "manifest.json"
+	// InstallDir is the directory of the roller home the version manifest and
+	// the genesis file of an installed bundle are kept in
+	InstallDir = "bundle"
+
+	binDir          = "bin"
+	genesisDir      = "genesis"
+	contractsDir    = "contracts"
+	genesisFileName = "genesis.json"
+)
+
+// Artifact is a file of the bundle, its path is relative to the bundle root
+type Artifact struct {
+	Name    string `json:"name"`
+	Path    string `json:"path"`
+	SHA256  string `json:"sha256"`
+	Version string `json:"version,omitempty"`
+}
+
+// Versions is the version manifest the bundle was created from
+type Versions struct {
+	DrsVersion    string `json:"drs_version,omitempty"`
+	RollappCommit string `json:"rollapp_commit,omitempty"`
+	Dymd          string `json:"dymd,omitempty"`
+	Relayer       string `json:"relayer,omitempty"`
+	EibcClient    string `json:"eibc_client,omitempty"`
+	CelestiaNode  string `json:"celestia_node,omitempty"`
+	CelestiaApp   string `json:"celestia_app,omitempty"`
+}
+
+type Manifest struct {
+	CreatedAt time.Time  `json:"created_at"`
+	OS        string     `json:"os"`
+	Arch      string     `json:"arch"`
+	Env       string     `json:"env"`
+	HubID     string     `json:"hub_id"`
+	RollappID string     `json:"rollapp_id,omitempty"`
+	VMType    string     `json:"vm_type,omitempty"`
+	Versions  Versions   `json:"versions"`
+	Binaries  []Artifact `json:"binaries"`
+	Genesis   *Artifact  `json:"genesis,omitempty"`
+	Contracts []Artifact `json:"contracts,omitempty"`
+}
+
+type CreateOptions struct {
+	Env       string
+	Hub       consts.HubData
+	RollappID string
+	Contracts bool
+}
+
+// Create packs the binaries installed on this host into dir, along with the
+// version manifest of the environment and, when a rollapp is set, its genesis
+// file. The bundle can only be installed on hosts of the same OS and arch
+func Create(dir string, opts CreateOptions) (*Manifest, error) {
+	m := &Manifest{
+		CreatedAt: time.Now().UTC(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		Env:       opts.Env,
+		HubID:     opts.Hub.ID,
+		RollappID: opts.RollappID,
+	}
+
+	// nolint: gofumpt
+	if err := os.MkdirAll(filepath.Join(dir, binDir), 0o755); err != nil {
+		return nil, err
+	}
+
+	for _, bin := range binstore.Binaries {
+		if _, err := os.Stat(bin); err != nil {
+			continue
+		}
+
+		a := Artifact{Name: filepath.Base(bin), Path: path.Join(binDir, filepath.Base(bin))}
+		if err := copyFile(bin, filepath.Join(dir, a.Path), 0o755); err != nil {
+			return nil, fmt.Errorf("failed to copy %s: %w", bin, err)
+		}
+		a.Version = binstore.BinaryVersion(bin)
+		if err := a.digest(dir); err != nil {
+			return nil, err
+		}
+		m.Binaries = append(m.Binaries, a)
+	}
+	if len(m.Binaries) == 0 {
+		return nil, fmt.Errorf("no binaries are installed on this host, install them with `roller rollapp init` first")
+	}
+
+	bvi, err := versions.GetDependencyVersions(opts.Env)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch binary versions: %w", err)
+	}
+	m.Versions = Versions{
+		Dymd:         bvi.Dymd,
+		Relayer:      bvi.Relayer,
+		EibcClient:   bvi.EibcClient,
+		CelestiaNode: bvi.CelestiaNode,
+		CelestiaApp:  bvi.CelestiaApp,
+	}
+
+	if opts.RollappID != "" {
+		if err := addRollapp(dir, m, opts); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.Contracts {
+		// nolint: gofumpt
+		if err := os.MkdirAll(filepath.Join(dir, contractsDir), 0o755); err != nil {
+			return nil, err
+		}
+		for _, url := range consts.OracleContractURLs {
+			a := Artifact{Name: path.Base(url), Path: path.Join(contractsDir, path.Base(url))}
+			if err := download(url, filepath.Join(dir, a.Path)); err != nil {
+				return nil, fmt.Errorf("failed to download contract %s: %w", a.Name, err)
+			}
+			if err := a.digest(dir); err != nil {
+				return nil, err
+			}
+			m.Contracts = append(m.Contracts, a)
+		}
+	}
+
+	b, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	// nolint: gofumpt
+	if err := os.WriteFile(filepath.Join(dir, ManifestFileName), b, 0o644); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func addRollapp(dir string, m *Manifest, opts CreateOptions) error {
+	raResp, err := rollapp.GetMetadataFromChain(opts.RollappID, opts.Hub)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve rollapp %s: %w", opts.RollappID, err)
+	}
+	m.VMType = strings.ToLower(raResp.Rollapp.VmType)
+
+	drsVersion, err := rollapp.GetDrsVersionFromChain(opts.RollappID, opts.Hub)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve rollapp drs version from chain: %w", err)
+	}
+	m.Versions.DrsVersion = drsVersion
+
+	drsInfo, err := versions.GetLatestDrsVersionCommit(drsVersion, opts.Env)
+	if err != nil {
+		return err
+	}
+	switch m.VMType {
+	case "evm":
+		m.Versions.RollappCommit = drsInfo.EvmCommit
+	case "wasm":
+		m.Versions.RollappCommit = drsInfo.WasmCommit
+	}
+	if com := config.Config.RollappCommit; com != "" {
+		m.Versions.RollappCommit = com
+	}
+
+	a := &Artifact{Name: genesisFileName, Path: path.Join(genesisDir, genesisFileName)}
+	err = filesystem.DownloadGenesisFile(raResp.Rollapp.Metadata.GenesisUrl, filepath.Join(dir, a.Path))
+	if err != nil {
+		return fmt.Errorf("failed to download genesis file: %w", err)
+	}
+	if err := a.digest(dir); err != nil {
+		return err
+	}
+	m.Genesis = a
+
+	return nil
+}
+
+// LoadManifest reads the manifest of the bundle extracted into dir
+func LoadManifest(dir string) (*Manifest, error) {
+	b, err := os.ReadFile(filepath.Join(dir, ManifestFileName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bundle manifest: %w", err)
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse bundle manifest: %w", err)
+	}
+	return &m, nil
+}
+
+// Verify checks that the bundle extracted into dir was created for this OS and
+// arch, and that every artifact matches its digest in the manifest
+func Verify(dir string, m *Manifest) error {
+	if m.OS != runtime.GOOS || m.Arch != runtime.GOARCH {
+		return fmt.Errorf(
+			"the bundle was created for %s/%s, this host is %s/%s",
+			m.OS, m.Arch, runtime.GOOS, runtime.GOARCH,
+		)
+	}
+
+	for _, a := range m.artifacts() {
+		digest, err := fileSHA256(filepath.Join(dir, filepath.FromSlash(a.Path)))
+		if err != nil {
+			return fmt.Errorf("%s: %w", a.Path, err)
+		}
+		if !strings.EqualFold(digest, a.SHA256) {
+			return fmt.Errorf("%s: sha256 mismatch, expected %s, got %s", a.Path, a.SHA256, digest)
+		}
+	}
+	return nil
+}
+
+func (m *Manifest) artifacts() []Artifact {
+	artifacts := append([]Artifact{}, m.Binaries...)
+	if m.Genesis != nil {
+		artifacts = append(artifacts, *m.Genesis)
+	}
+	return append(artifacts, m.Contracts...)
+}
+
+func (a *Artifact) digest(dir string) error {
+	digest, err := fileSHA256(filepath.Join(dir, filepath.FromSlash(a.Path)))
+	if err != nil {
+		return err
+	}
+	a.SHA256 = digest
+	return nil
+}
+
+func download(url, destination string) error {
+	resp, err := http.Get(url) // nolint: gosec
+	if err != nil {
+		return err
+	}
+	// nolint: errcheck
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("bad status: %s", resp.Status)
+	}
+
+	f, err := os.Create(destination)
+	if err != nil {
+		return err
+	}
+	// nolint: errcheck
+	defer f.Close()
+
+	_, err = io.Copy(f, resp.Body)
+	return err
+}
+
+func copyFile(src, dst string, perm os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	// nolint: errcheck
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		// nolint: errcheck
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func fileSHA256(file string) (string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	// nolint: errcheck
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,109 @@
+package bundle
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/archives"
+	"github.com/dymensionxyz/roller/utils/bash"
+	"github.com/dymensionxyz/roller/utils/binstore"
+	"github.com/dymensionxyz/roller/utils/plan"
+)
+
+// GenesisPath is where the genesis file of an installed bundle is kept
+func GenesisPath(home string) string {
+	return filepath.Join(home, InstallDir, genesisFileName)
+}
+
+// ManifestPath is where the manifest of an installed bundle is kept
+func ManifestPath(home string) string {
+	return filepath.Join(home, InstallDir, ManifestFileName)
+}
+
+// Install verifies the bundle extracted into dir and installs its binaries
+// into their usual location. The genesis file and the manifest are kept in
+// the bundle directory of the roller home, the contracts in its contracts
+// directory where the oracle deployers look for them
+func Install(dir string, m *Manifest, home string) error {
+	if err := Verify(dir, m); err != nil {
+		return err
+	}
+
+	destinations := map[string]string{}
+	for _, bin := range binstore.Binaries {
+		destinations[filepath.Base(bin)] = bin
+	}
+	for _, a := range m.Binaries {
+		if _, ok := destinations[a.Name]; !ok {
+			return fmt.Errorf("the bundle contains an unknown binary: %s", a.Name)
+		}
+	}
+
+	if plan.Enabled() {
+		for _, a := range m.Binaries {
+			plan.Record(plan.KindCommand, "install %s to %s", strings.TrimSpace(a.Name+" "+a.Version), destinations[a.Name])
+		}
+		if m.Genesis != nil {
+			plan.Record(plan.KindFile, "copy the genesis file of %s to %s", m.RollappID, GenesisPath(home))
+		}
+		for _, a := range m.Contracts {
+			plan.Record(plan.KindFile, "copy contract %s to %s", a.Name, filepath.Join(home, consts.ConfigDirName.Contracts))
+		}
+		return nil
+	}
+
+	c := exec.Command("sudo", "mkdir", "-p", consts.InternalBinsDir)
+	if _, err := bash.ExecCommandWithStdout(c); err != nil {
+		return fmt.Errorf("failed to create %s: %w", consts.InternalBinsDir, err)
+	}
+
+	tmp, err := os.MkdirTemp("", "roller-bundle-bin")
+	if err != nil {
+		return err
+	}
+	// nolint: errcheck
+	defer os.RemoveAll(tmp)
+
+	for _, a := range m.Binaries {
+		// copy first, the bundle directory is left untouched
+		staged := filepath.Join(tmp, a.Name)
+		if err := copyFile(filepath.Join(dir, filepath.FromSlash(a.Path)), staged, 0o755); err != nil {
+			return err
+		}
+		err := archives.MoveBinaryIntoPlaceAndMakeExecutable(staged, destinations[a.Name])
+		if err != nil {
+			return fmt.Errorf("failed to install %s: %w", a.Name, err)
+		}
+	}
+
+	// nolint: gofumpt
+	if err := os.MkdirAll(filepath.Join(home, InstallDir), 0o755); err != nil {
+		return err
+	}
+	if m.Genesis != nil {
+		err := copyFile(filepath.Join(dir, filepath.FromSlash(m.Genesis.Path)), GenesisPath(home), 0o644)
+		if err != nil {
+			return fmt.Errorf("failed to install genesis file: %w", err)
+		}
+	}
+
+	if len(m.Contracts) > 0 {
+		contractsPath := filepath.Join(home, consts.ConfigDirName.Contracts)
+		// nolint: gofumpt
+		if err := os.MkdirAll(contractsPath, 0o755); err != nil {
+			return err
+		}
+		for _, a := range m.Contracts {
+			err := copyFile(filepath.Join(dir, filepath.FromSlash(a.Path)), filepath.Join(contractsPath, a.Name), 0o644)
+			if err != nil {
+				return fmt.Errorf("failed to install contract %s: %w", a.Name, err)
+			}
+		}
+	}
+
+	return copyFile(filepath.Join(dir, ManifestFileName), ManifestPath(home), 0o644)
+}
@@ -0,0 +1,338 @@
+// Package backup makes config file writes atomic and versioned. Every process
+// that changes config files records the previous content of the files it
+// touches into a new backup generation under the roller home, so that the
+// config can be restored to the state before any change set
+package backup
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+)
+
+const (
+	metadataFileName = "generation.json"
+	filesDirName     = "files"
+
+	// maxGenerations is the number of generations kept, older ones are pruned
+	maxGenerations = 50
+)
+
+// File is a file backed up in a generation. Files that did not exist before
+// the change set are removed on rollback
+type File struct {
+	Path    string `json:"path" yaml:"path"`
+	Existed bool   `json:"existed" yaml:"existed"`
+}
+
+// Generation is the backup of the files changed by a single roller command
+type Generation struct {
+	ID        int       `json:"generation" yaml:"generation"`
+	CreatedAt time.Time `json:"created_at" yaml:"created_at"`
+	Command   string    `json:"command" yaml:"command"`
+	Files     []File    `json:"files" yaml:"files"`
+}
+
+// State is the content a file had before a generation was created
+type State struct {
+	Path    string
+	Exists  bool
+	Content []byte
+}
+
+var session = struct {
+	sync.Mutex
+	home    string
+	command string
+	gen     *Generation
+	saved   map[string]bool
+}{saved: map[string]bool{}}
+
+// Begin starts the change set of the current process. Files written after it
+// are backed up into a new generation under home, which is created on the
+// first write
+func Begin(home, command string) {
+	session.Lock()
+	defer session.Unlock()
+
+	session.home = filepath.Clean(home)
+	session.command = command
+	session.gen = nil
+	session.saved = map[string]bool{}
+}
+
+func GetDir(home string) string {
+	return filepath.Join(home, consts.BackupsDirName)
+}
+
+func getGenerationDir(home string, id int) string {
+	return filepath.Join(GetDir(home), strconv.Itoa(id))
+}
+
+func getBackupPath(home string, id int, path string) string {
+	return filepath.Join(
+		getGenerationDir(home, id),
+		filesDirName,
+		strings.TrimPrefix(filepath.Clean(path), string(filepath.Separator)),
+	)
+}
+
+// WriteFile backs up the current content of a file and replaces it
+// atomically, so that readers see either the old or the new content
+func WriteFile(path string, data []byte, perm os.FileMode) error {
+	if err := save(path); err != nil {
+		return fmt.Errorf("failed to back up %s: %w", path, err)
+	}
+
+	return writeAtomic(path, data, perm)
+}
+
+// Remove backs up a file and removes it, a missing file is not an error
+func Remove(path string) error {
+	if err := save(path); err != nil {
+		return fmt.Errorf("failed to back up %s: %w", path, err)
+	}
+
+	err := os.Remove(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func writeAtomic(path string, data []byte, perm os.FileMode) error {
+	if fi, err := os.Stat(path); err == nil {
+		perm = fi.Mode().Perm()
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	// nolint: errcheck
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		// nolint: errcheck
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		// nolint: errcheck
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// save copies the content of a file into the generation of the current
+// process, once per file
+func save(path string) error {
+	session.Lock()
+	defer session.Unlock()
+
+	if session.home == "" {
+		return nil
+	}
+
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	if session.saved[path] || strings.HasPrefix(path, GetDir(session.home)+string(filepath.Separator)) {
+		return nil
+	}
+
+	if session.gen == nil {
+		gen, err := create(session.home, session.command)
+		if err != nil {
+			return err
+		}
+		session.gen = gen
+	}
+
+	f := File{Path: path}
+	content, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		f.Existed = true
+		dst := getBackupPath(session.home, session.gen.ID, path)
+		if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(dst, content, 0o600); err != nil {
+			return err
+		}
+	case errors.Is(err, os.ErrNotExist):
+	default:
+		return err
+	}
+
+	session.gen.Files = append(session.gen.Files, f)
+	if err := writeMetadata(session.home, *session.gen); err != nil {
+		return err
+	}
+	session.saved[path] = true
+
+	return nil
+}
+
+// create allocates the next generation and prunes the oldest ones
+func create(home, command string) (*Generation, error) {
+	if err := os.MkdirAll(GetDir(home), 0o755); err != nil {
+		return nil, err
+	}
+
+	gens, err := List(home)
+	if err != nil {
+		return nil, err
+	}
+
+	id := 1
+	if len(gens) > 0 {
+		id = gens[len(gens)-1].ID + 1
+	}
+
+	// another roller process may allocate the same generation concurrently
+	for {
+		err := os.Mkdir(getGenerationDir(home, id), 0o755)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, err
+		}
+		id++
+	}
+
+	gen := &Generation{ID: id, CreatedAt: time.Now().UTC(), Command: command, Files: []File{}}
+	if err := writeMetadata(home, *gen); err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < len(gens)+1-maxGenerations; i++ {
+		if err := os.RemoveAll(getGenerationDir(home, gens[i].ID)); err != nil {
+			return nil, err
+		}
+	}
+
+	return gen, nil
+}
+
+func writeMetadata(home string, gen Generation) error {
+	b, err := json.MarshalIndent(gen, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return writeAtomic(filepath.Join(getGenerationDir(home, gen.ID), metadataFileName), b, 0o644)
+}
+
+// List returns the backup generations of a roller home, oldest first
+func List(home string) ([]Generation, error) {
+	entries, err := os.ReadDir(GetDir(home))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var gens []Generation
+	for _, e := range entries {
+		id, err := strconv.Atoi(e.Name())
+		if err != nil || !e.IsDir() {
+			continue
+		}
+
+		gen, err := Load(home, id)
+		if err != nil {
+			// the generation is being created by another process
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		gens = append(gens, gen)
+	}
+
+	sort.Slice(gens, func(i, j int) bool { return gens[i].ID < gens[j].ID })
+	return gens, nil
+}
+
+func Load(home string, id int) (Generation, error) {
+	var gen Generation
+
+	b, err := os.ReadFile(filepath.Join(getGenerationDir(home, id), metadataFileName))
+	if err != nil {
+		return gen, err
+	}
+
+	if err := json.Unmarshal(b, &gen); err != nil {
+		return gen, fmt.Errorf("failed to parse generation %d: %w", id, err)
+	}
+	return gen, nil
+}
+
+// StateBefore returns the content every backed up file had before the given
+// generation was created. A file changed by several later generations takes
+// the content from the oldest of them
+func StateBefore(home string, id int) ([]State, error) {
+	gens, err := List(home)
+	if err != nil {
+		return nil, err
+	}
+
+	found := false
+	seen := map[string]bool{}
+	var states []State
+	for _, gen := range gens {
+		if gen.ID < id {
+			continue
+		}
+		if gen.ID == id {
+			found = true
+		}
+		if !found {
+			break
+		}
+
+		for _, f := range gen.Files {
+			if seen[f.Path] {
+				continue
+			}
+			seen[f.Path] = true
+
+			s := State{Path: f.Path, Exists: f.Existed}
+			if f.Existed {
+				s.Content, err = os.ReadFile(getBackupPath(home, gen.ID, f.Path))
+				if err != nil {
+					return nil, fmt.Errorf("generation %d is incomplete: %w", gen.ID, err)
+				}
+			}
+			states = append(states, s)
+		}
+	}
+
+	if !found {
+		return nil, fmt.Errorf("generation %d does not exist", id)
+	}
+
+	return states, nil
+}
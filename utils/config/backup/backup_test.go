@@ -0,0 +1,108 @@
+package backup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStateBeforeAndRollback(t *testing.T) {
+	home := t.TempDir()
+	existing := filepath.Join(home, "roller.toml")
+	created := filepath.Join(home, "da-light-node", "config.toml")
+
+	if err := os.WriteFile(existing, []byte("v0"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(created), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	Begin(home, "roller first")
+	if err := WriteFile(existing, []byte("v1"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteFile(existing, []byte("v1 again"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteFile(created, []byte("new"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	Begin(home, "roller second")
+	if err := WriteFile(existing, []byte("v2"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	gens, err := List(home)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(gens) != 2 || gens[0].Command != "roller first" || len(gens[0].Files) != 2 {
+		t.Fatalf("unexpected generations: %+v", gens)
+	}
+
+	states, err := StateBefore(home, gens[1].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(states) != 1 || string(states[0].Content) != "v1 again" {
+		t.Fatalf("unexpected state before generation %d: %+v", gens[1].ID, states)
+	}
+
+	states, err = StateBefore(home, gens[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]State{
+		existing: {Path: existing, Exists: true, Content: []byte("v0")},
+		created:  {Path: created},
+	}
+	if len(states) != len(want) {
+		t.Fatalf("got %d states, want %d", len(states), len(want))
+	}
+	for _, s := range states {
+		w, ok := want[s.Path]
+		if !ok || s.Exists != w.Exists || string(s.Content) != string(w.Content) {
+			t.Fatalf("unexpected state %+v", s)
+		}
+	}
+
+	// the rollback is a change set of its own, as in roller config rollback
+	Begin(home, "roller config rollback")
+	for _, s := range states {
+		if !s.Exists {
+			err = Remove(s.Path)
+		} else {
+			err = WriteFile(s.Path, s.Content, 0o644)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if b, err := os.ReadFile(existing); err != nil || string(b) != "v0" {
+		t.Fatalf("roller.toml was not restored: %q, %v", b, err)
+	}
+	if _, err := os.Stat(created); !os.IsNotExist(err) {
+		t.Fatalf("created file was not removed: %v", err)
+	}
+
+	// reverting the rollback restores the latest content
+	gens, err = List(home)
+	if err != nil {
+		t.Fatal(err)
+	}
+	states, err = StateBefore(home, gens[len(gens)-1].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range states {
+		if s.Path == existing && string(s.Content) != "v2" {
+			t.Fatalf("rollback generation holds %q, want v2", s.Content)
+		}
+		if s.Path == created && string(s.Content) != "new" {
+			t.Fatalf("rollback generation holds %q, want new", s.Content)
+		}
+	}
+}
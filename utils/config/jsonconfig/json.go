@@ -1,17 +1,16 @@
 package jsonconfig
 
 import (
-	"os"
-
 	"github.com/pterm/pterm"
 	"github.com/tidwall/sjson"
 
 	"github.com/dymensionxyz/roller/utils/config"
+	"github.com/dymensionxyz/roller/utils/plan"
 )
 
 // TODO(#130): fix to support epochs
 func UpdateJSONParams(jsonFilePath string, params []config.PathValue) error {
-	jsonFileContent, err := os.ReadFile(jsonFilePath)
+	jsonFileContent, err := plan.ReadFile(jsonFilePath)
 	if err != nil {
 		return err
 	}
@@ -25,7 +24,7 @@ func UpdateJSONParams(jsonFilePath string, params []config.PathValue) error {
 	}
 
 	// nolint:gofumpt
-	err = os.WriteFile(jsonFilePath, []byte(jsonFileContentString), 0o644)
+	err = plan.WriteFile(jsonFilePath, []byte(jsonFileContentString), 0o644)
 	if err != nil {
 		return err
 	}
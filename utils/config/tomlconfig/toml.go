@@ -2,14 +2,15 @@ package tomlconfig
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/pelletier/go-toml"
 	"github.com/pterm/pterm"
+
+	"github.com/dymensionxyz/roller/utils/plan"
 )
 
 func Load(path string) ([]byte, error) {
-	tomlBytes, err := os.ReadFile(path)
+	tomlBytes, err := plan.ReadFile(path)
 	if err != nil {
 		return tomlBytes, err
 	}
@@ -17,23 +18,23 @@ func Load(path string) ([]byte, error) {
 	return tomlBytes, nil
 }
 
-func WriteTomlTreeToFile(tomlConfig *toml.Tree, path string) error {
-	file, err := os.Create(path)
+// LoadFile parses a TOML file, it sees the planned content of the file in
+// dry-run mode
+func LoadFile(path string) (*toml.Tree, error) {
+	tomlBytes, err := Load(path)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	// nolint: errcheck
-	defer file.Close()
 
-	_, err = file.WriteString(tomlConfig.String())
-	if err != nil {
-		return err
-	}
-	return nil
+	return toml.LoadBytes(tomlBytes)
+}
+
+func WriteTomlTreeToFile(tomlConfig *toml.Tree, path string) error {
+	return plan.WriteFile(path, []byte(tomlConfig.String()), 0o644)
 }
 
 func GetKeyFromFile(tmlFilePath, key string) (string, error) {
-	tomlTree, err := toml.LoadFile(tmlFilePath)
+	tomlTree, err := LoadFile(tmlFilePath)
 	if err != nil {
 		return "", err
 	}
@@ -43,7 +44,7 @@ func GetKeyFromFile(tmlFilePath, key string) (string, error) {
 
 // TODO: improve
 func UpdateFieldInFile(tmlFilePath, key string, value any) error {
-	tomlCfg, err := toml.LoadFile(tmlFilePath)
+	tomlCfg, err := LoadFile(tmlFilePath)
 	if err != nil {
 		return fmt.Errorf("failed to load %s: %v", tmlFilePath, err)
 	}
@@ -74,7 +75,7 @@ func UpdateFieldsInFile(tmlFilePath string, fields map[string]interface{}) error
 }
 
 func RemoveFieldFromFile(tmlFilePath, keyPath string) error {
-	tomlCfg, err := toml.LoadFile(tmlFilePath)
+	tomlCfg, err := LoadFile(tmlFilePath)
 	if err != nil {
 		return fmt.Errorf("failed to load %s: %v", tmlFilePath, err)
 	}
@@ -92,7 +93,7 @@ func RemoveFieldFromFile(tmlFilePath, keyPath string) error {
 }
 
 func ReplaceFieldInFile(tmlFilePath, oldPath, newPath string, value any) error {
-	tomlCfg, err := toml.LoadFile(tmlFilePath)
+	tomlCfg, err := LoadFile(tmlFilePath)
 	if err != nil {
 		return fmt.Errorf("failed to load %s: %v", tmlFilePath, err)
 	}
@@ -133,7 +134,7 @@ func WriteConfigToTOML(config interface{}, filePath string) error {
 	}
 
 	// Write the TOML data to a file
-	err = os.WriteFile(filePath, tomlData, 0o644)
+	err = plan.WriteFile(filePath, tomlData, 0o644)
 	if err != nil {
 		return err
 	}
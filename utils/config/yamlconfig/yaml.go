@@ -2,15 +2,16 @@ package yamlconfig
 
 import (
 	"fmt"
-	"os"
 	"strings"
 
 	"gopkg.in/yaml.v3"
+
+	"github.com/dymensionxyz/roller/utils/plan"
 )
 
 func UpdateNestedYAML(filename string, updates map[string]interface{}) error {
 	// Read YAML file
-	data, err := os.ReadFile(filename)
+	data, err := plan.ReadFile(filename)
 	if err != nil {
 		return err
 	}
@@ -38,7 +39,7 @@ func UpdateNestedYAML(filename string, updates map[string]interface{}) error {
 	}
 
 	// Write updated YAML back to file
-	return os.WriteFile(filename, updatedData, 0o644)
+	return plan.WriteFile(filename, updatedData, 0o644)
 }
 
 func setNestedValue(data map[string]interface{}, keys []string, value interface{}) error {
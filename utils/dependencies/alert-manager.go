@@ -12,6 +12,7 @@ func DefaultAlertAgentDependency() types.Dependency {
 		RepositoryName:  "alert-agent",
 		RepositoryUrl:   "https://github.com/dymensionxyz/alert-agent",
 		Release:         "v0.1.0-alpha-rc03",
+		ChecksumsFile:   GoreleaserChecksumsFile,
 		Binaries: []types.BinaryPathPair{
 			{
 				Binary:            "alert-agent",
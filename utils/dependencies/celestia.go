@@ -5,7 +5,7 @@ import (
 
 	"github.com/dymensionxyz/roller/cmd/consts"
 	"github.com/dymensionxyz/roller/utils/dependencies/types"
-	"github.com/dymensionxyz/roller/utils/firebase"
+	"github.com/dymensionxyz/roller/utils/versions"
 )
 
 const (
@@ -41,7 +41,7 @@ func DefaultCelestiaNodeDependency() types.Dependency {
 	}
 }
 
-func CelestiaNodeDependency(bvi firebase.BinaryVersionInfo) types.Dependency {
+func CelestiaNodeDependency(bvi versions.BinaryVersionInfo) types.Dependency {
 	return types.Dependency{
 		DependencyName:  "celestia",
 		RepositoryOwner: "celestiaorg",
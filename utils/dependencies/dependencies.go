@@ -21,9 +21,10 @@ import (
 	"github.com/dymensionxyz/roller/utils/archives"
 	"github.com/dymensionxyz/roller/utils/bash"
 	"github.com/dymensionxyz/roller/utils/dependencies/types"
-	firebaseutils "github.com/dymensionxyz/roller/utils/firebase"
 	genesisutils "github.com/dymensionxyz/roller/utils/genesis"
+	"github.com/dymensionxyz/roller/utils/plan"
 	"github.com/dymensionxyz/roller/utils/rollapp"
+	"github.com/dymensionxyz/roller/utils/versions"
 )
 
 func InstallBinaries(withMockDA bool, raResp rollapp.ShowRollappResponse, hd consts.HubData, env string) (
@@ -72,7 +73,7 @@ func InstallBinaries(withMockDA bool, raResp rollapp.ShowRollappResponse, hd con
 		}
 		pterm.Info.Println("RollApp drs version fetched from chain: ", drsVersion)
 
-		drsInfo, err := firebaseutils.GetLatestDrsVersionCommit(drsVersion, env)
+		drsInfo, err := versions.GetLatestDrsVersionCommit(drsVersion, env)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -150,13 +151,27 @@ func InstallBinaries(withMockDA bool, raResp rollapp.ShowRollappResponse, hd con
 			libName,
 		)
 
+		// wasmvm publishes the digests of its libraries in a checksums.txt
+		libDep := types.Dependency{
+			DependencyName: "wasmvm",
+			RepositoryUrl:  "https://github.com/CosmWasm/wasmvm",
+			Release:        libVersion,
+			ChecksumsFile:  GoreleaserChecksumsFile,
+		}
+		libPath, err := downloadVerified(libDep, downloadPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		// nolint: errcheck
+		defer os.Remove(libPath)
+
 		fsc := exec.Command("sudo", "mkdir", "-p", outputPath)
-		_, err := bash.ExecCommandWithStdout(fsc)
+		_, err = bash.ExecCommandWithStdout(fsc)
 		if err != nil {
 			return nil, nil, err
 		}
 
-		c := exec.Command("sudo", "wget", "-O", filepath.Join(outputPath, libName), downloadPath)
+		c := exec.Command("sudo", "cp", libPath, filepath.Join(outputPath, libName))
 		_, err = bash.ExecCommandWithStdout(c)
 		if err != nil {
 			return nil, nil, err
@@ -169,6 +184,7 @@ func InstallBinaries(withMockDA bool, raResp rollapp.ShowRollappResponse, hd con
 				RepositoryName:  "rollapp-evm",
 				RepositoryUrl:   "https://github.com/dymensionxyz/rollapp-evm",
 				Release:         "v3.1.0-drs6-rc02",
+				ChecksumsFile:   GoreleaserChecksumsFile,
 				Binaries: []types.BinaryPathPair{
 					{
 						Binary:            "rollappd",
@@ -183,6 +199,7 @@ func InstallBinaries(withMockDA bool, raResp rollapp.ShowRollappResponse, hd con
 				RepositoryName:  "rollapp-wasm",
 				RepositoryUrl:   "https://github.com/dymensionxyz/rollapp-wasm",
 				Release:         "v2.1.0-drs9",
+				ChecksumsFile:   GoreleaserChecksumsFile,
 				Binaries: []types.BinaryPathPair{
 					{
 						Binary:            "rollappd",
@@ -217,6 +234,10 @@ func InstallBinaryFromRepo(dep types.Dependency, td string) error {
 	if config.Config.SkipCelestiaBinary && dep.DependencyName == "celestia" {
 		return nil
 	}
+	if plan.Enabled() {
+		plan.Record(plan.KindCommand, "build %s %s from %s", dep.DependencyName, dep.Release, dep.RepositoryUrl)
+		return nil
+	}
 
 	originalDir, err := os.Getwd()
 	if err != nil {
@@ -301,8 +322,8 @@ func InstallBinaryFromRepo(dep types.Dependency, td string) error {
 			return err
 		}
 
-		c := exec.Command("sudo", "mv", binary.Binary, binary.BinaryDestination)
-		if _, err := bash.ExecCommandWithStdout(c); err != nil {
+		err = archives.MoveBinaryIntoPlaceAndMakeExecutable(binary.Binary, binary.BinaryDestination)
+		if err != nil {
 			spinner.Fail("failed to install")
 			return err
 		}
@@ -316,6 +337,11 @@ func InstallBinaryFromRepo(dep types.Dependency, td string) error {
 }
 
 func InstallBinaryFromRelease(dep types.Dependency) error {
+	if plan.Enabled() {
+		plan.Record(plan.KindCommand, "install %s %s release", dep.DependencyName, dep.Release)
+		return nil
+	}
+
 	spinner, _ := pterm.DefaultSpinner.Start(
 		fmt.Sprintf("[%s] installing", dep.DependencyName),
 	)
@@ -365,6 +391,9 @@ func InstallBinaryFromRelease(dep types.Dependency) error {
 	return nil
 }
 
+// DownloadRelease downloads the release archive, verifies its digest and
+// extracts its binaries into place. Nothing is installed when the
+// verification fails
 func DownloadRelease(
 	url, destination string,
 	dep types.Dependency,
@@ -384,31 +413,47 @@ func DownloadRelease(
 	// nolint: errcheck
 	defer resp.Body.Close()
 
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download %s: %s", url, resp.Status)
+	}
+
+	f, err := os.CreateTemp("", "release-")
+	if err != nil {
+		return err
+	}
+	// nolint: errcheck
+	defer os.Remove(f.Name())
+
 	// Create a progress bar
 	bar := progressbar.DefaultBytes(
 		resp.ContentLength,
 		"Downloading",
 	)
 
-	// Create a reader that will update the progress bar
-	reader := progressbar.NewReader(resp.Body, bar)
+	// nolint: errcheck,gosec
+	spinner.Stop()
+	_, err = io.Copy(io.MultiWriter(f, bar), resp.Body)
+	if err != nil {
+		// nolint: errcheck
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary file: %w", err)
+	}
 
-	// Create a pointer to the reader
-	readerPtr := &reader
+	err = VerifyArtifact(dep, url, f.Name())
+	if err != nil {
+		return fmt.Errorf("refusing to install %s: %w", dep.DependencyName, err)
+	}
 
-	// Create a wrapper that implements io.ReadCloser
-	readCloserWrapper := struct {
-		io.Reader
-		io.Closer
-	}{
-		Reader: readerPtr,
-		Closer: resp.Body,
+	archive, err := os.Open(f.Name())
+	if err != nil {
+		return err
 	}
 
-	// nolint: errcheck,gosec
-	spinner.Stop()
-	// Extract the tar.gz file with progress
-	err = archives.ExtractTarGz(destination, readCloserWrapper, dep)
+	// Extract the tar.gz file
+	err = archives.ExtractTarGz(destination, archive, dep)
 	if err != nil {
 		return err
 	}
@@ -12,7 +12,7 @@ import (
 	"github.com/dymensionxyz/roller/cmd/consts"
 	"github.com/dymensionxyz/roller/utils/bash"
 	"github.com/dymensionxyz/roller/utils/dependencies/types"
-	"github.com/dymensionxyz/roller/utils/firebase"
+	"github.com/dymensionxyz/roller/utils/versions"
 )
 
 func customDymdDependency(dymdCommit string) types.Dependency {
@@ -93,7 +93,7 @@ func InstallCustomDymdVersion(dymdCommit string) error {
 }
 
 func DefaultDymdDependency(env string) types.Dependency {
-	bvi, err := firebase.GetDependencyVersions(env)
+	bvi, err := versions.GetDependencyVersions(env)
 	if err != nil {
 		pterm.Error.Println("failed to fetch binary versions: ", err)
 		return types.Dependency{}
@@ -105,6 +105,7 @@ func DefaultDymdDependency(env string) types.Dependency {
 		RepositoryName:  "dymension",
 		RepositoryUrl:   "https://github.com/dymensionxyz/dymension",
 		Release:         bvi.Dymd,
+		ChecksumsFile:   GoreleaserChecksumsFile,
 		Binaries: []types.BinaryPathPair{
 			{
 				Binary:            "dymd",
@@ -5,11 +5,11 @@ import (
 
 	"github.com/dymensionxyz/roller/cmd/consts"
 	"github.com/dymensionxyz/roller/utils/dependencies/types"
-	"github.com/dymensionxyz/roller/utils/firebase"
+	"github.com/dymensionxyz/roller/utils/versions"
 )
 
 func DefaultEibcClientPrebuiltDependencies(env string) map[string]types.Dependency {
-	bvi, err := firebase.GetDependencyVersions(env)
+	bvi, err := versions.GetDependencyVersions(env)
 	if err != nil {
 		pterm.Error.Println("failed to fetch binary versions: ", err)
 		return nil
@@ -22,6 +22,7 @@ func DefaultEibcClientPrebuiltDependencies(env string) map[string]types.Dependen
 			RepositoryName:  "eibc-client",
 			RepositoryUrl:   "https://github.com/dymensionxyz/eibc-client",
 			Release:         bvi.EibcClient,
+			ChecksumsFile:   GoreleaserChecksumsFile,
 			Binaries: []types.BinaryPathPair{
 				{
 					Binary:            "eibc-client",
@@ -10,12 +10,11 @@ import (
 	"runtime"
 	"strings"
 
-	firebase "firebase.google.com/go"
 	"github.com/pterm/pterm"
-	"google.golang.org/api/option"
 
 	"github.com/dymensionxyz/roller/cmd/consts"
 	"github.com/dymensionxyz/roller/utils/archives"
+	"github.com/dymensionxyz/roller/utils/versions"
 )
 
 const (
@@ -30,43 +29,8 @@ type BinaryInstallConfig struct {
 	InstallDir  string
 }
 
-type OracleBinaryVersionInfo struct {
-	PriceEvmOracle      string `firestore:"price-evm"`
-	PriceWasmOracle     string `firestore:"price-wasm"`
-	RngEvmOracle        string `firestore:"rng-evm"`
-	RngEvmRandomService string `firestore:"rng-evm-random-service"`
-}
-
-func GetOracleBinaryVersion(vmt consts.VMType) (*OracleBinaryVersionInfo, error) {
-	ctx := context.Background()
-	conf := &firebase.Config{ProjectID: "drs-metadata"}
-	app, err := firebase.NewApp(ctx, conf, option.WithoutAuthentication())
-	if err != nil {
-		return nil, fmt.Errorf("failed to initialize firebase app: %v", err)
-	}
-
-	client, err := app.Firestore(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create firestore client: %v", err)
-	}
-	defer client.Close()
-
-	// Fetch DRS version information using the nested collection path
-	// Path format: versions/{version}/revisions/{revision}
-	drsDoc := client.Collection("tool-versions").
-		Doc("oracle-client-binaries")
-
-	docSnapshot, err := drsDoc.Get(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get DRS version info: %v", err)
-	}
-
-	var bvi OracleBinaryVersionInfo
-	if err := docSnapshot.DataTo(&bvi); err != nil {
-		return nil, fmt.Errorf("failed to parse DRS version info: %v", err)
-	}
-
-	return &bvi, nil
+func GetOracleBinaryVersion(vmt consts.VMType) (*versions.OracleVersionInfo, error) {
+	return versions.GetOracleVersions()
 }
 
 func InstallOracleBinary(ctx context.Context, config BinaryInstallConfig, oracleType string) error {
@@ -5,11 +5,11 @@ import (
 
 	"github.com/dymensionxyz/roller/cmd/consts"
 	"github.com/dymensionxyz/roller/utils/dependencies/types"
-	"github.com/dymensionxyz/roller/utils/firebase"
+	"github.com/dymensionxyz/roller/utils/versions"
 )
 
 func DefaultRelayerPrebuiltDependencies(env string) map[string]types.Dependency {
-	bvi, err := firebase.GetDependencyVersions(env)
+	bvi, err := versions.GetDependencyVersions(env)
 	if err != nil {
 		pterm.Error.Println("failed to fetch binary versions: ", err)
 		return nil
@@ -22,6 +22,7 @@ func DefaultRelayerPrebuiltDependencies(env string) map[string]types.Dependency
 			RepositoryName:  "go-relayer",
 			RepositoryUrl:   "https://github.com/dymensionxyz/go-relayer",
 			Release:         bvi.Relayer,
+			ChecksumsFile:   GoreleaserChecksumsFile,
 			Binaries: []types.BinaryPathPair{
 				{
 					Binary:            "rly",
@@ -8,7 +8,7 @@ import (
 
 	"github.com/dymensionxyz/roller/cmd/consts"
 	"github.com/dymensionxyz/roller/utils/dependencies/types"
-	"github.com/dymensionxyz/roller/utils/firebase"
+	"github.com/dymensionxyz/roller/utils/versions"
 )
 
 type RollappBinaryInfo struct {
@@ -33,7 +33,7 @@ func DefaultRollappBuildableDependencies(
 	deps := map[string]types.Dependency{}
 
 	if da == "celestia" {
-		bvi, err := firebase.GetDependencyVersions(env)
+		bvi, err := versions.GetDependencyVersions(env)
 		if err != nil {
 			pterm.Error.Printfln("failed to retrieve binary version for celestia light client: %v", err)
 			return nil
@@ -101,6 +101,7 @@ func DefaultCelestiaPrebuiltDependencies() map[string]types.Dependency {
 			DependencyName: "celestia-app",
 			RepositoryUrl:  "https://github.com/celestiaorg/celestia-app",
 			Release:        "v2.1.2",
+			ChecksumsFile:  GoreleaserChecksumsFile,
 			Binaries: []types.BinaryPathPair{
 				{
 					Binary:            "celestia-appd",
@@ -1,20 +1,43 @@
 package dependencies
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"os"
 	"os/exec"
 	"runtime"
+	"strings"
 
 	"github.com/pterm/pterm"
 
 	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/archives"
 	"github.com/dymensionxyz/roller/utils/dependencies/types"
 )
 
 const (
 	solcVersion = "0.8.20" // Latest stable version as of @20250121
+	// solcBinariesURL hosts the solc builds with a list.json of their digests
+	solcBinariesURL = "https://binaries.soliditylang.org"
 )
 
+// solcBuild is a build listed in the list.json of a solc platform
+type solcBuild struct {
+	Path    string `json:"path"`
+	Version string `json:"version"`
+	Sha256  string `json:"sha256"`
+}
+
+// getSolcPlatform returns the platform directory of the solc builds based on
+// the OS
+func getSolcPlatform() string {
+	if runtime.GOOS == "darwin" {
+		return "macosx-amd64"
+	}
+	return "linux-amd64"
+}
+
 // getSolcBinaryName returns the appropriate solc binary name based on the OS
 func getSolcBinaryName() string {
 	if runtime.GOOS == "darwin" {
@@ -49,28 +72,111 @@ func InstallSolidityDependencies() error {
 	return nil
 }
 
+// getSolcBuild returns the build of a solc version listed in the list.json of
+// the platform at baseURL
+func getSolcBuild(baseURL, version string) (*solcBuild, error) {
+	url := fmt.Sprintf("%s/%s/list.json", baseURL, getSolcPlatform())
+	resp, err := http.Get(url) // nolint: gosec
+	if err != nil {
+		return nil, err
+	}
+	// nolint: errcheck
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download %s: %s", url, resp.Status)
+	}
+
+	var list struct {
+		Builds []solcBuild `json:"builds"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", url, err)
+	}
+
+	for _, b := range list.Builds {
+		if b.Version == version {
+			return &b, nil
+		}
+	}
+	return nil, fmt.Errorf("solc %s is not listed in %s", version, url)
+}
+
 // since solc doesn't follow the release artifact naming scheme, these
-// are separate implementations for their binaries
+// are separate implementations for their binaries. The build is downloaded
+// from binaries.soliditylang.org and verified against the sha256 of its
+// list.json
 func installSolcFromRelease(dep types.Dependency) error {
 	spinner, _ := pterm.DefaultSpinner.Start(
 		fmt.Sprintf("[%s] installing", dep.DependencyName),
 	)
 
-	url := fmt.Sprintf(
-		"%s/releases/download/v%s/%s",
-		dep.RepositoryUrl,
-		dep.Release,
-		dep.Binaries[0].Binary,
-	)
+	build, err := getSolcBuild(solcBinariesURL, dep.Release)
+	if err != nil {
+		spinner.Fail("failed to find release")
+		return err
+	}
+
+	url := fmt.Sprintf("%s/%s/%s", solcBinariesURL, getSolcPlatform(), build.Path)
 
 	spinner.UpdateText(fmt.Sprintf("[%s] downloading %s", dep.DependencyName, dep.Release))
-	err := DownloadBinary(url, consts.Executables.Solc)
+	binPath, err := downloadSolcBuild(url, *build)
 	if err != nil {
 		spinner.Fail("failed to download release")
 		return err
 	}
+	// nolint: errcheck
+	defer os.Remove(binPath)
 	spinner.UpdateText(fmt.Sprintf("[%s] downloaded successfully", dep.DependencyName))
 
+	err = archives.MoveBinaryIntoPlaceAndMakeExecutable(binPath, consts.Executables.Solc)
+	if err != nil {
+		spinner.Fail("failed to install release")
+		return err
+	}
+
 	spinner.Success(fmt.Sprintf("[%s] installed\n", dep.DependencyName))
 	return nil
 }
+
+// downloadSolcBuild downloads a solc build into a temporary file and checks
+// it against the sha256 of list.json. The binaries host has no checksums file
+// next to the builds, so VerifyArtifact doesn't apply
+func downloadSolcBuild(url string, build solcBuild) (string, error) {
+	f, err := os.CreateTemp("", "solc-")
+	if err != nil {
+		return "", err
+	}
+	// nolint: errcheck
+	f.Close()
+
+	err = downloadFile(url, f.Name())
+	if err == nil {
+		err = verifySolcBuild(f.Name(), build)
+	}
+	if err != nil {
+		// nolint: errcheck
+		os.Remove(f.Name())
+		return "", fmt.Errorf("refusing to install solc: %w", err)
+	}
+
+	return f.Name(), nil
+}
+
+func verifySolcBuild(file string, build solcBuild) error {
+	digest, err := fileSHA256(file)
+	if err != nil {
+		return err
+	}
+
+	want := strings.TrimPrefix(build.Sha256, "0x")
+	if !strings.EqualFold(want, digest) {
+		return fmt.Errorf(
+			"%s: sha256 mismatch with list.json, expected %s, got %s",
+			build.Path,
+			want,
+			digest,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package dependencies
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSolcBuild(t *testing.T) {
+	content := []byte("solc")
+	sum := sha256.Sum256(content)
+	digest := hex.EncodeToString(sum[:])
+
+	file := filepath.Join(t.TempDir(), "solc")
+	if err := os.WriteFile(file, content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/"+getSolcPlatform()+"/list.json" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintf(w, `{"builds": [
+			{"path": "solc-v0.8.19", "version": "0.8.19", "sha256": "0x%s"},
+			{"path": "solc-v0.8.20", "version": "0.8.20", "sha256": "0x%s"}
+		]}`, strings.Repeat("0", len(digest)), digest)
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name    string
+		version string
+		wantErr string
+	}{
+		{name: "listed", version: "0.8.20"},
+		{name: "mismatch", version: "0.8.19", wantErr: "sha256 mismatch with list.json"},
+		{name: "unlisted", version: "0.8.30", wantErr: "solc 0.8.30 is not listed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			build, err := getSolcBuild(srv.URL, tt.version)
+			if err == nil {
+				err = verifySolcBuild(file, *build)
+			}
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
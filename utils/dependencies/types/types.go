@@ -14,6 +14,27 @@ type PersistFile struct {
 	Target string
 }
 
+type SignatureType string
+
+const (
+	SignatureTypeCosign   SignatureType = "cosign"
+	SignatureTypeMinisign SignatureType = "minisign"
+)
+
+// Signature describes how the checksums file of a release is signed. The
+// signature is published next to the checksums file, as <file>.sig (and
+// <file>.pem for keyless cosign signatures) or <file>.minisig
+type Signature struct {
+	Type SignatureType
+	// PublicKey is the path of the public key, or the key itself for minisign.
+	// Cosign signatures are verified keyless when it is empty
+	PublicKey string
+	// CertificateIdentity and CertificateOIDCIssuer pin the signer of keyless
+	// cosign signatures
+	CertificateIdentity   string
+	CertificateOIDCIssuer string
+}
+
 type Dependency struct {
 	DependencyName  string
 	RepositoryOwner string
@@ -22,4 +43,11 @@ type Dependency struct {
 	Release         string
 	Binaries        []BinaryPathPair
 	PersistFiles    []PersistFile
+	// Checksums are the expected sha256 digests of the release artifacts,
+	// keyed by artifact name
+	Checksums map[string]string
+	// ChecksumsFile is the name of the checksums file published with the
+	// release, SHA256SUMS when empty
+	ChecksumsFile string
+	Signature     *Signature
 }
@@ -0,0 +1,296 @@
+package dependencies
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/dymensionxyz/roller/config"
+	"github.com/dymensionxyz/roller/utils/bash"
+	"github.com/dymensionxyz/roller/utils/dependencies/types"
+)
+
+const (
+	DefaultChecksumsFile = "SHA256SUMS"
+	// GoreleaserChecksumsFile is the checksums file of the releases built with
+	// goreleaser, as the roller release
+	GoreleaserChecksumsFile = "checksums.txt"
+)
+
+var errNotPublished = errors.New("not published")
+
+// VerifyArtifact checks the sha256 digest of the artifact downloaded from url
+// into file against the digest recorded in the dependency and the checksums
+// file published with the release. The checksums file signature is verified
+// first when the dependency has one
+func VerifyArtifact(dep types.Dependency, url, file string) error {
+	artifact := path.Base(url)
+
+	digest, err := fileSHA256(file)
+	if err != nil {
+		return err
+	}
+
+	var verified bool
+	if want, ok := dep.Checksums[artifact]; ok {
+		if !strings.EqualFold(want, digest) {
+			return fmt.Errorf(
+				"%s: sha256 mismatch, expected %s, got %s",
+				artifact,
+				want,
+				digest,
+			)
+		}
+		verified = true
+	}
+
+	sums, err := releaseChecksums(dep, url[:strings.LastIndex(url, "/")])
+	switch {
+	case errors.Is(err, errNotPublished):
+	case err != nil:
+		return err
+	default:
+		want, ok := sums[artifact]
+		if !ok {
+			return fmt.Errorf("%s is not listed in %s", artifact, checksumsFile(dep))
+		}
+		if !strings.EqualFold(want, digest) {
+			return fmt.Errorf(
+				"%s: sha256 mismatch with %s, expected %s, got %s",
+				artifact,
+				checksumsFile(dep),
+				want,
+				digest,
+			)
+		}
+		verified = true
+	}
+
+	if !verified {
+		if config.Config.AllowUnverifiedBinaries {
+			return nil
+		}
+		return fmt.Errorf(
+			"%s can't be verified: no digest is recorded and %s is not published with %s, set ROLLER_ALLOW_UNVERIFIED_BINARIES=true to install it anyway",
+			artifact,
+			checksumsFile(dep),
+			dep.Release,
+		)
+	}
+
+	return nil
+}
+
+// downloadVerified downloads url into a temporary file and verifies it with
+// VerifyArtifact. It returns the path of the file, the caller removes it
+func downloadVerified(dep types.Dependency, url string) (string, error) {
+	f, err := os.CreateTemp("", "artifact-")
+	if err != nil {
+		return "", err
+	}
+	// nolint: errcheck
+	f.Close()
+
+	err = downloadFile(url, f.Name())
+	if errors.Is(err, errNotPublished) {
+		err = fmt.Errorf("%s is not published", url)
+	}
+	if err == nil {
+		err = VerifyArtifact(dep, url, f.Name())
+		if err != nil {
+			err = fmt.Errorf("refusing to install %s: %w", dep.DependencyName, err)
+		}
+	}
+	if err != nil {
+		// nolint: errcheck
+		os.Remove(f.Name())
+		return "", err
+	}
+
+	return f.Name(), nil
+}
+
+func checksumsFile(dep types.Dependency) string {
+	if dep.ChecksumsFile != "" {
+		return dep.ChecksumsFile
+	}
+	return DefaultChecksumsFile
+}
+
+// releaseChecksums downloads and parses the checksums file of the release at
+// baseURL, verifying its signature when the dependency has one
+func releaseChecksums(dep types.Dependency, baseURL string) (map[string]string, error) {
+	dir, err := os.MkdirTemp("", "checksums")
+	if err != nil {
+		return nil, err
+	}
+	// nolint: errcheck
+	defer os.RemoveAll(dir)
+
+	name := checksumsFile(dep)
+	sumsPath := filepath.Join(dir, name)
+	err = downloadFile(baseURL+"/"+name, sumsPath)
+	if err != nil {
+		if errors.Is(err, errNotPublished) && dep.Signature != nil {
+			return nil, fmt.Errorf("%s is signed but %s is not published with %s", dep.DependencyName, name, dep.Release)
+		}
+		return nil, err
+	}
+
+	if dep.Signature != nil {
+		err = verifySignature(*dep.Signature, baseURL, sumsPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify the signature of %s: %w", name, err)
+		}
+	}
+
+	f, err := os.Open(sumsPath)
+	if err != nil {
+		return nil, err
+	}
+	// nolint: errcheck
+	defer f.Close()
+
+	return ParseChecksums(f)
+}
+
+// ParseChecksums parses a sha256sum style file, one "<digest>  <name>" entry
+// per line
+func ParseChecksums(r io.Reader) (map[string]string, error) {
+	sums := map[string]string{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid checksums line: %q", line)
+		}
+		// binary mode entries are prefixed with '*'
+		name := path.Base(strings.TrimPrefix(fields[1], "*"))
+		sums[name] = strings.ToLower(fields[0])
+	}
+	return sums, scanner.Err()
+}
+
+func verifySignature(sig types.Signature, baseURL, sumsPath string) error {
+	dir := filepath.Dir(sumsPath)
+	name := filepath.Base(sumsPath)
+
+	// the signature files are required, unlike the checksums file
+	fetch := func(file string) (string, error) {
+		p := filepath.Join(dir, file)
+		err := downloadFile(baseURL+"/"+file, p)
+		if errors.Is(err, errNotPublished) {
+			return "", fmt.Errorf("%s is not published with the release", file)
+		}
+		return p, err
+	}
+
+	var c *exec.Cmd
+	switch sig.Type {
+	case types.SignatureTypeCosign:
+		sigPath, err := fetch(name + ".sig")
+		if err != nil {
+			return err
+		}
+
+		args := []string{"verify-blob", "--signature", sigPath}
+		if sig.PublicKey != "" {
+			args = append(args, "--key", sig.PublicKey)
+		} else {
+			if sig.CertificateIdentity == "" || sig.CertificateOIDCIssuer == "" {
+				return errors.New(
+					"keyless cosign verification requires a certificate identity and OIDC issuer",
+				)
+			}
+			certPath, err := fetch(name + ".pem")
+			if err != nil {
+				return err
+			}
+			args = append(
+				args,
+				"--certificate", certPath,
+				"--certificate-identity", sig.CertificateIdentity,
+				"--certificate-oidc-issuer", sig.CertificateOIDCIssuer,
+			)
+		}
+		c = exec.Command("cosign", append(args, sumsPath)...)
+	case types.SignatureTypeMinisign:
+		sigPath, err := fetch(name + ".minisig")
+		if err != nil {
+			return err
+		}
+
+		args := []string{"-V", "-m", sumsPath, "-x", sigPath}
+		if _, err := os.Stat(sig.PublicKey); err == nil {
+			args = append(args, "-p", sig.PublicKey)
+		} else {
+			args = append(args, "-P", sig.PublicKey)
+		}
+		c = exec.Command("minisign", args...)
+	default:
+		return fmt.Errorf("unsupported signature type: %s", sig.Type)
+	}
+
+	if c.Err != nil {
+		return fmt.Errorf("%s is required to verify the signature: %w", c.Args[0], c.Err)
+	}
+	_, err := bash.ExecCommandWithStdout(c)
+	return err
+}
+
+// downloadFile downloads url into destination, errNotPublished is returned
+// when the url doesn't exist
+func downloadFile(url, destination string) error {
+	resp, err := http.Get(url) // nolint: gosec
+	if err != nil {
+		return err
+	}
+	// nolint: errcheck
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return errNotPublished
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download %s: %s", url, resp.Status)
+	}
+
+	f, err := os.Create(destination)
+	if err != nil {
+		return err
+	}
+	// nolint: errcheck
+	defer f.Close()
+
+	_, err = io.Copy(f, resp.Body)
+	return err
+}
+
+func fileSHA256(file string) (string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	// nolint: errcheck
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
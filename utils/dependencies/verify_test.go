@@ -0,0 +1,136 @@
+package dependencies
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dymensionxyz/roller/utils/dependencies/types"
+)
+
+func TestParseChecksums(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "text mode",
+			in:   "aa11  rly_linux_amd64.tar.gz\nbb22  rly_darwin_arm64.tar.gz\n",
+			want: map[string]string{"rly_linux_amd64.tar.gz": "aa11", "rly_darwin_arm64.tar.gz": "bb22"},
+		},
+		{
+			name: "binary mode",
+			in:   "aa11 *rly_linux_amd64.tar.gz",
+			want: map[string]string{"rly_linux_amd64.tar.gz": "aa11"},
+		},
+		{
+			name: "comments and blank lines",
+			in:   "# sha256\n\n  aa11  rly_linux_amd64.tar.gz  \n# end\n",
+			want: map[string]string{"rly_linux_amd64.tar.gz": "aa11"},
+		},
+		{
+			name: "directory and uppercase digest",
+			in:   "AA11  ./dist/rly_linux_amd64.tar.gz",
+			want: map[string]string{"rly_linux_amd64.tar.gz": "aa11"},
+		},
+		{name: "empty", in: "", want: map[string]string{}},
+		{name: "digest only", in: "aa11", wantErr: true},
+		{name: "too many fields", in: "aa11  rly linux.tar.gz", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseChecksums(strings.NewReader(tt.in))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseChecksums(%q) = %v, want an error", tt.in, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseChecksums(%q) unexpected error: %v", tt.in, err)
+			}
+			if !maps.Equal(got, tt.want) {
+				t.Fatalf("ParseChecksums(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifyArtifact(t *testing.T) {
+	const artifact = "rly_linux_amd64.tar.gz"
+
+	file := filepath.Join(t.TempDir(), "release")
+	content := []byte("release archive")
+	if err := os.WriteFile(file, content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(content)
+	digest := hex.EncodeToString(sum[:])
+	other := strings.Repeat("0", len(digest))
+
+	tests := []struct {
+		name      string
+		checksums string
+		recorded  map[string]string
+		wantErr   string
+	}{
+		{name: "listed", checksums: fmt.Sprintf("%s  %s\n", digest, artifact)},
+		{
+			name:      "mismatch",
+			checksums: fmt.Sprintf("%s  %s\n", other, artifact),
+			wantErr:   "sha256 mismatch with checksums.txt",
+		},
+		{
+			name:      "unlisted",
+			checksums: fmt.Sprintf("%s  rly_darwin_arm64.tar.gz\n", digest),
+			wantErr:   "is not listed in checksums.txt",
+		},
+		{name: "not published", wantErr: "can't be verified"},
+		{name: "not published with a recorded digest", recorded: map[string]string{artifact: digest}},
+		{
+			name:     "recorded digest mismatch",
+			recorded: map[string]string{artifact: other},
+			wantErr:  "sha256 mismatch, expected",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.checksums == "" || r.URL.Path != "/download/v1.0.0/"+GoreleaserChecksumsFile {
+					http.NotFound(w, r)
+					return
+				}
+				fmt.Fprint(w, tt.checksums)
+			}))
+			defer srv.Close()
+
+			dep := types.Dependency{
+				DependencyName: "go-relayer",
+				Release:        "v1.0.0",
+				ChecksumsFile:  GoreleaserChecksumsFile,
+				Checksums:      tt.recorded,
+			}
+			err := VerifyArtifact(dep, srv.URL+"/download/v1.0.0/"+artifact, file)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/dymensionxyz/roller/utils/bash"
 	"github.com/dymensionxyz/roller/utils/config/tomlconfig"
 	rollapputils "github.com/dymensionxyz/roller/utils/rollapp"
+	"github.com/dymensionxyz/roller/utils/roller"
 	"github.com/dymensionxyz/roller/utils/sequencer"
 	servicemanager "github.com/dymensionxyz/roller/utils/service_manager"
 )
@@ -132,7 +133,7 @@ func UpdateDymintConfigForIBC(home string, t string, forceUpdate bool) error {
 		}
 		spin.Success("restart successful")
 
-		health := fmt.Sprintf(consts.DefaultRollappRPC+"%s", "/health")
+		health := roller.LocalRollappRPC() + "/health"
 		WaitForHealthyRollApp(health)
 	}
 
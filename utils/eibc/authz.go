@@ -73,3 +73,29 @@ type Amount struct {
 	Denom  string `json:"denom"`
 	Amount string `json:"amount"`
 }
+
+// UnmarshalJSON accepts both the amino {type, value} shape and the proto
+// shape, where the authorization fields sit next to its "@type"
+func (a *Authorization) UnmarshalJSON(b []byte) error {
+	var amino struct {
+		Type  string     `json:"type"`
+		Value *AuthValue `json:"value"`
+	}
+	if err := json.Unmarshal(b, &amino); err != nil {
+		return err
+	}
+	if amino.Value != nil {
+		a.Type, a.Value = amino.Type, *amino.Value
+		return nil
+	}
+
+	var proto struct {
+		Type string `json:"@type"`
+		AuthValue
+	}
+	if err := json.Unmarshal(b, &proto); err != nil {
+		return err
+	}
+	a.Type, a.Value = proto.Type, proto.AuthValue
+	return nil
+}
@@ -0,0 +1,426 @@
+package eibc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os/exec"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/bash"
+)
+
+const (
+	OrderSourceHub     = "hub"
+	OrderSourceIndexer = "indexer"
+
+	// maxHubOrders is the page size used to list the pending demand orders
+	maxHubOrders = 10000
+)
+
+// DemandOrder is a pending eIBC demand order. Amounts are in base denom
+type DemandOrder struct {
+	ID             string    `json:"id" yaml:"id"`
+	RollappID      string    `json:"rollapp_id" yaml:"rollapp_id"`
+	Denom          string    `json:"denom" yaml:"denom"`
+	Price          *big.Int  `json:"price" yaml:"price"`
+	Fee            *big.Int  `json:"fee" yaml:"fee"`
+	CreationHeight int64     `json:"creation_height" yaml:"creation_height"`
+	CreatedAt      time.Time `json:"created_at,omitempty" yaml:"created_at,omitempty"`
+	Sources        []string  `json:"sources" yaml:"sources"`
+}
+
+// FeeRate is the fee of the order relative to the amount that is paid out
+// when fulfilling it
+func (o DemandOrder) FeeRate() float64 {
+	if o.Price == nil || o.Price.Sign() == 0 || o.Fee == nil {
+		return 0
+	}
+	r, _ := new(big.Rat).SetFrac(o.Fee, o.Price).Float64()
+	return r
+}
+
+// OrderProfit splits the fee of an order between the operator, who takes the
+// minimum fee share, and the liquidity provider that fulfills it
+type OrderProfit struct {
+	Operator *big.Int `json:"operator" yaml:"operator"`
+	LP       *big.Int `json:"lp" yaml:"lp"`
+}
+
+func (o DemandOrder) Profit(minFeeShare float32) OrderProfit {
+	fee := o.Fee
+	if fee == nil {
+		fee = big.NewInt(0)
+	}
+
+	share := new(big.Rat).SetFloat64(float64(minFeeShare))
+	if share == nil {
+		share = new(big.Rat)
+	}
+	op := new(big.Rat).Mul(new(big.Rat).SetInt(fee), share)
+	operator := new(big.Int).Quo(op.Num(), op.Denom())
+
+	return OrderProfit{
+		Operator: operator,
+		LP:       new(big.Int).Sub(fee, operator),
+	}
+}
+
+// OrderFilter selects demand orders, zero values match everything
+type OrderFilter struct {
+	Rollapps []string
+	Denoms   []string
+	MinFee   *big.Int
+	// MinFeeRate is the minimum fee relative to the order price, e.g. 0.001
+	MinFeeRate float64
+	MinAge     time.Duration
+	MaxAge     time.Duration
+}
+
+func (f OrderFilter) Match(o DemandOrder, now time.Time) bool {
+	if len(f.Rollapps) > 0 && !contains(f.Rollapps, o.RollappID) {
+		return false
+	}
+	if len(f.Denoms) > 0 && !contains(f.Denoms, o.Denom) {
+		return false
+	}
+	if f.MinFee != nil && (o.Fee == nil || o.Fee.Cmp(f.MinFee) < 0) {
+		return false
+	}
+	if f.MinFeeRate > 0 && o.FeeRate() < f.MinFeeRate {
+		return false
+	}
+	if f.MinAge > 0 || f.MaxAge > 0 {
+		if o.CreatedAt.IsZero() {
+			return false
+		}
+		age := now.Sub(o.CreatedAt)
+		if f.MinAge > 0 && age < f.MinAge {
+			return false
+		}
+		if f.MaxAge > 0 && age > f.MaxAge {
+			return false
+		}
+	}
+
+	return true
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+type hubCoin struct {
+	Denom  string `json:"denom"`
+	Amount string `json:"amount"`
+}
+
+type hubDemandOrder struct {
+	ID             string    `json:"id"`
+	RollappID      string    `json:"rollapp_id"`
+	Price          []hubCoin `json:"price"`
+	Fee            []hubCoin `json:"fee"`
+	CreationHeight string    `json:"creation_height"`
+	FulfillerAddr  string    `json:"fulfiller_address"`
+	IsFulfilled    bool      `json:"is_fulfilled"`
+}
+
+type hubDemandOrdersResponse struct {
+	DemandOrders []hubDemandOrder `json:"demand_orders"`
+}
+
+// QueryHubDemandOrders lists the pending demand orders stored on the hub
+func QueryHubDemandOrders(hd consts.HubData) ([]DemandOrder, error) {
+	cmd := exec.Command(
+		consts.Executables.Dymension,
+		"q", "eibc", "list-demand-orders", "PENDING",
+		"--limit", strconv.Itoa(maxHubOrders),
+		"--node", hd.RpcUrl,
+		"--chain-id", hd.ID,
+		"-o", "json",
+	)
+
+	out, err := bash.ExecCommandWithStdoutFiltered(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp hubDemandOrdersResponse
+	if err := json.Unmarshal(out.Bytes(), &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse demand orders: %w", err)
+	}
+
+	orders := make([]DemandOrder, 0, len(resp.DemandOrders))
+	for _, o := range resp.DemandOrders {
+		if o.IsFulfilled || o.FulfillerAddr != "" {
+			continue
+		}
+
+		order := DemandOrder{
+			ID:        o.ID,
+			RollappID: o.RollappID,
+			Price:     big.NewInt(0),
+			Fee:       big.NewInt(0),
+			Sources:   []string{OrderSourceHub},
+		}
+		if len(o.Price) > 0 {
+			order.Denom = o.Price[0].Denom
+			order.Price = parseAmount(o.Price[0].Amount)
+		}
+		for _, c := range o.Fee {
+			if order.Denom == "" || c.Denom == order.Denom {
+				order.Denom = c.Denom
+				order.Fee = parseAmount(c.Amount)
+			}
+		}
+		order.CreationHeight, _ = strconv.ParseInt(o.CreationHeight, 10, 64)
+
+		orders = append(orders, order)
+	}
+
+	return orders, nil
+}
+
+// indexerOrdersQuery is the GraphQL query used by the eibc client order
+// poller to fetch the pending orders of a hub
+const indexerOrdersQuery = `{ibcTransferDetails(filter: {network: {equalTo: "%s"}, status: {equalTo: EibcPending}}) {nodes {eibcOrderId amount price eibcFee denom rollappId blockHeight blockTimestamp}}}`
+
+type indexerOrdersResponse struct {
+	Data struct {
+		IbcTransferDetails struct {
+			Nodes []struct {
+				EibcOrderID    string `json:"eibcOrderId"`
+				Amount         string `json:"amount"`
+				Price          string `json:"price"`
+				EibcFee        string `json:"eibcFee"`
+				Denom          string `json:"denom"`
+				RollappID      string `json:"rollappId"`
+				BlockHeight    string `json:"blockHeight"`
+				BlockTimestamp string `json:"blockTimestamp"`
+			} `json:"nodes"`
+		} `json:"ibcTransferDetails"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// QueryIndexerDemandOrders lists the pending demand orders known by the
+// indexer configured in the eibc client order polling settings
+func QueryIndexerDemandOrders(indexerURL, hubID string) ([]DemandOrder, error) {
+	body, err := json.Marshal(map[string]string{"query": fmt.Sprintf(indexerOrdersQuery, hubID)})
+	if err != nil {
+		return nil, err
+	}
+
+	client := http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Post(indexerURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("indexer returned %s", resp.Status)
+	}
+
+	var r indexerOrdersResponse
+	if err := json.Unmarshal(b, &r); err != nil {
+		return nil, fmt.Errorf("failed to parse indexer response: %w", err)
+	}
+	if len(r.Errors) > 0 {
+		return nil, fmt.Errorf("indexer query failed: %s", r.Errors[0].Message)
+	}
+
+	nodes := r.Data.IbcTransferDetails.Nodes
+	orders := make([]DemandOrder, 0, len(nodes))
+	for _, n := range nodes {
+		if n.EibcOrderID == "" {
+			continue
+		}
+
+		price := n.Price
+		if price == "" {
+			price = n.Amount
+		}
+		order := DemandOrder{
+			ID:        n.EibcOrderID,
+			RollappID: n.RollappID,
+			Denom:     n.Denom,
+			Price:     parseAmount(price),
+			Fee:       parseAmount(n.EibcFee),
+			Sources:   []string{OrderSourceIndexer},
+		}
+		order.CreationHeight, _ = strconv.ParseInt(n.BlockHeight, 10, 64)
+		order.CreatedAt = parseTimestamp(n.BlockTimestamp)
+
+		orders = append(orders, order)
+	}
+
+	return orders, nil
+}
+
+// OnHub reports whether the order was listed by the hub
+func (o DemandOrder) OnHub() bool {
+	return slices.Contains(o.Sources, OrderSourceHub)
+}
+
+// MergeDemandOrders merges the orders fetched from several sources by ID. The
+// hub is authoritative for amounts, the indexer provides the creation time
+func MergeDemandOrders(lists ...[]DemandOrder) []DemandOrder {
+	byID := map[string]*DemandOrder{}
+	var ids []string
+
+	for _, list := range lists {
+		for _, o := range list {
+			existing, ok := byID[o.ID]
+			if !ok {
+				o := o
+				byID[o.ID] = &o
+				ids = append(ids, o.ID)
+				continue
+			}
+
+			existing.Sources = append(existing.Sources, o.Sources...)
+			if existing.CreatedAt.IsZero() {
+				existing.CreatedAt = o.CreatedAt
+			}
+			if existing.Denom == "" {
+				existing.Denom = o.Denom
+			}
+			if existing.RollappID == "" {
+				existing.RollappID = o.RollappID
+			}
+		}
+	}
+
+	orders := make([]DemandOrder, 0, len(ids))
+	for _, id := range ids {
+		orders = append(orders, *byID[id])
+	}
+	return orders
+}
+
+// SortByLPProfit sorts orders by descending liquidity provider profit
+func SortByLPProfit(orders []DemandOrder, minFeeShare float32) {
+	sort.SliceStable(orders, func(i, j int) bool {
+		return orders[i].Profit(minFeeShare).LP.Cmp(orders[j].Profit(minFeeShare).LP) > 0
+	})
+}
+
+// EstimateCreationTimes sets the creation time of the orders that have none,
+// from their creation height and the average block time of the hub
+func EstimateCreationTimes(orders []DemandOrder, hd consts.HubData) error {
+	missing := false
+	for _, o := range orders {
+		if o.CreatedAt.IsZero() && o.CreationHeight > 0 {
+			missing = true
+			break
+		}
+	}
+	if !missing {
+		return nil
+	}
+
+	latestHeight, latestTime, err := getBlockTime(hd.RpcUrl, 0)
+	if err != nil {
+		return err
+	}
+	const sampleBlocks = 1000
+	if latestHeight <= sampleBlocks {
+		return fmt.Errorf("not enough blocks to estimate the block time")
+	}
+	_, pastTime, err := getBlockTime(hd.RpcUrl, latestHeight-sampleBlocks)
+	if err != nil {
+		return err
+	}
+	blockTime := latestTime.Sub(pastTime) / sampleBlocks
+
+	for i := range orders {
+		o := &orders[i]
+		if o.CreatedAt.IsZero() && o.CreationHeight > 0 {
+			o.CreatedAt = latestTime.Add(-time.Duration(latestHeight-o.CreationHeight) * blockTime)
+		}
+	}
+	return nil
+}
+
+type blockResponse struct {
+	Result struct {
+		Block struct {
+			Header struct {
+				Height string    `json:"height"`
+				Time   time.Time `json:"time"`
+			} `json:"header"`
+		} `json:"block"`
+	} `json:"result"`
+}
+
+// getBlockTime returns the height and time of a block, the latest one when
+// height is 0
+func getBlockTime(rpc string, height int64) (int64, time.Time, error) {
+	url := strings.TrimSuffix(rpc, "/") + "/block"
+	if height > 0 {
+		url = fmt.Sprintf("%s?height=%d", url, height)
+	}
+
+	client := http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	defer resp.Body.Close()
+
+	var b blockResponse
+	if err := json.NewDecoder(resp.Body).Decode(&b); err != nil {
+		return 0, time.Time{}, err
+	}
+
+	h, err := strconv.ParseInt(b.Result.Block.Header.Height, 10, 64)
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("invalid block height: %w", err)
+	}
+	return h, b.Result.Block.Header.Time, nil
+}
+
+func parseAmount(s string) *big.Int {
+	v, ok := new(big.Int).SetString(strings.TrimSpace(s), 10)
+	if !ok {
+		return big.NewInt(0)
+	}
+	return v
+}
+
+func parseTimestamp(s string) time.Time {
+	if s == "" {
+		return time.Time{}
+	}
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t
+	}
+	// the indexer may return timestamps without a timezone, or unix millis
+	if t, err := time.Parse("2006-01-02T15:04:05.999999999", s); err == nil {
+		return t.UTC()
+	}
+	if ms, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.UnixMilli(ms).UTC()
+	}
+	return time.Time{}
+}
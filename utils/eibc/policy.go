@@ -0,0 +1,509 @@
+package eibc
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	cosmossdktypes "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/bash"
+	"github.com/dymensionxyz/roller/utils/tx"
+)
+
+const (
+	FulfillOrderAuthorizationType = "/dymensionxyz.dymension.eibc.FulfillOrderAuthorization"
+	MsgFulfillOrderAuthorizedType = "/dymensionxyz.dymension.eibc.MsgFulfillOrderAuthorized"
+
+	percentageDecisionPolicyType = "/cosmos.group.v1.PercentageDecisionPolicy"
+	policyTxGas                  = 400000
+)
+
+type PolicyActionKind string
+
+const (
+	PolicyActionGrant          PolicyActionKind = "grant"
+	PolicyActionUpdate         PolicyActionKind = "update"
+	PolicyActionRevoke         PolicyActionKind = "revoke"
+	PolicyActionDecisionPolicy PolicyActionKind = "decision-policy"
+)
+
+// PolicyAction is a transaction needed to reconcile the chain with the policy
+// file. Actions without a key can't be signed by roller and are exported for
+// their signer instead
+type PolicyAction struct {
+	Kind           PolicyActionKind `json:"kind" yaml:"kind"`
+	Name           string           `json:"name" yaml:"name"`
+	Signer         string           `json:"signer" yaml:"signer"`
+	Key            string           `json:"key,omitempty" yaml:"key,omitempty"`
+	KeyringBackend string           `json:"-" yaml:"-"`
+	KeyringDir     string           `json:"-" yaml:"-"`
+	Changes        []string         `json:"changes" yaml:"changes"`
+
+	msg map[string]any
+	// spendLimits are the spend limits of the policy file, recorded in the
+	// policy state once the grant is applied
+	spendLimits map[string]string
+}
+
+// PolicyPlan is the set of actions that reconcile the on-chain group policy
+// and authz grants with the policy file
+type PolicyPlan struct {
+	PolicyAddress string         `json:"policy_address" yaml:"policy_address"`
+	Actions       []PolicyAction `json:"actions" yaml:"actions"`
+
+	eibcHome string
+}
+
+// PolicyApplyResult is the outcome of a single plan action
+type PolicyApplyResult struct {
+	Kind       PolicyActionKind `json:"kind" yaml:"kind"`
+	Name       string           `json:"name" yaml:"name"`
+	TxHash     string           `json:"tx_hash,omitempty" yaml:"tx_hash,omitempty"`
+	UnsignedTx string           `json:"unsigned_tx,omitempty" yaml:"unsigned_tx,omitempty"`
+	Error      string           `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// PlanPolicy compares the policy file with the grants given to the policy
+// address and with its decision policy. Unless resetSpendLimits is set, a
+// spend limit that is unchanged in the policy file since it was last applied
+// keeps the remaining on-chain limit when the grant is updated
+func PlanPolicy(
+	pf *PolicyFile,
+	cfg Config,
+	eibcHome string,
+	hd consts.HubData,
+	resetSpendLimits bool,
+) (*PolicyPlan, error) {
+	policyAddr := cfg.Fulfillers.PolicyAddress
+	if policyAddr == "" {
+		return nil, fmt.Errorf("no policy address is set in fulfillers.policy_address of the eibc client config")
+	}
+	p := &PolicyPlan{PolicyAddress: policyAddr, Actions: []PolicyAction{}, eibcHome: eibcHome}
+
+	state, err := LoadPolicyState(eibcHome)
+	if err != nil {
+		return nil, err
+	}
+
+	if pf.GroupPolicy != nil {
+		a, err := planDecisionPolicy(pf.GroupPolicy, cfg, eibcHome, hd)
+		if err != nil {
+			return nil, err
+		}
+		if a != nil {
+			p.Actions = append(p.Actions, *a)
+		}
+	}
+
+	grants, err := GetGrantsByGrantee(policyAddr, hd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the grants of %s: %w", policyAddr, err)
+	}
+	current := map[string][]Rollapp{}
+	for _, g := range grants.Grants {
+		if g.Authorization.Type == FulfillOrderAuthorizationType {
+			current[g.Granter] = g.Authorization.Value.Rollapps
+		}
+	}
+
+	for _, lp := range pf.LPs {
+		desired := make([]Rollapp, 0, len(lp.Rollapps))
+		spendLimits := map[string]string{}
+		for _, ra := range lp.Rollapps {
+			r, _ := ra.toAuthorization()
+			desired = append(desired, r)
+			spendLimits[r.RollappID] = fromAmounts(r.SpendLimit).String()
+		}
+
+		a := PolicyAction{
+			Name:           lp.Name,
+			Signer:         lp.Address,
+			Key:            lp.Key,
+			KeyringBackend: lp.KeyringBackend,
+			KeyringDir:     lp.KeyringDir,
+			spendLimits:    spendLimits,
+		}
+
+		existing, ok := current[lp.Address]
+		delete(current, lp.Address)
+		if !ok {
+			a.Kind = PolicyActionGrant
+			for _, r := range desired {
+				a.Changes = append(a.Changes, fmt.Sprintf("+ %s", describeRollapp(r)))
+			}
+		} else {
+			a.Kind = PolicyActionUpdate
+			a.Changes = diffRollapps(existing, desired, state.SpendLimits[lp.Address], resetSpendLimits)
+			if len(a.Changes) == 0 {
+				continue
+			}
+		}
+
+		a.msg = map[string]any{
+			"@type":   "/cosmos.authz.v1beta1.MsgGrant",
+			"granter": lp.Address,
+			"grantee": policyAddr,
+			"grant": map[string]any{
+				"authorization": map[string]any{
+					"@type":    FulfillOrderAuthorizationType,
+					"rollapps": desired,
+				},
+			},
+		}
+		p.Actions = append(p.Actions, a)
+	}
+
+	// the remaining grants are not declared in the policy file anymore
+	granters := make([]string, 0, len(current))
+	for granter := range current {
+		granters = append(granters, granter)
+	}
+	sort.Strings(granters)
+	for _, granter := range granters {
+		a := PolicyAction{
+			Kind:   PolicyActionRevoke,
+			Name:   granter,
+			Signer: granter,
+		}
+		for _, r := range current[granter] {
+			a.Changes = append(a.Changes, fmt.Sprintf("- %s", describeRollapp(r)))
+		}
+		a.msg = map[string]any{
+			"@type":        "/cosmos.authz.v1beta1.MsgRevoke",
+			"granter":      granter,
+			"grantee":      policyAddr,
+			"msg_type_url": MsgFulfillOrderAuthorizedType,
+		}
+		p.Actions = append(p.Actions, a)
+	}
+
+	return p, nil
+}
+
+func planDecisionPolicy(
+	desired *GroupPolicyDecision,
+	cfg Config,
+	eibcHome string,
+	hd consts.HubData,
+) (*PolicyAction, error) {
+	gID := cfg.OperatorConfig.GroupID
+	if gID == "" {
+		return nil, fmt.Errorf("no group id is set in operator.group_id of the eibc client config")
+	}
+
+	pol, err := GetPolicies(eibcHome, gID, hd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the policies of group %s: %w", gID, err)
+	}
+	idx := slices.IndexFunc(pol.GroupPolicies, func(gp GroupPolicy) bool {
+		return gp.Address == cfg.Fulfillers.PolicyAddress
+	})
+	if idx < 0 {
+		return nil, fmt.Errorf("policy %s not found in group %s", cfg.Fulfillers.PolicyAddress, gID)
+	}
+	gp := pol.GroupPolicies[idx]
+
+	want := *desired
+	if want.VotingPeriod == "" {
+		want.VotingPeriod = "1s"
+	}
+	if want.MinExecutionPeriod == "" {
+		want.MinExecutionPeriod = "0s"
+	}
+
+	var changes []string
+	if !sameDec(gp.DecisionPolicy.Percentage, want.Percentage) {
+		changes = append(changes, fmt.Sprintf("percentage: %s -> %s", gp.DecisionPolicy.Percentage, want.Percentage))
+	}
+	if !sameDuration(gp.DecisionPolicy.Windows.VotingPeriod, want.VotingPeriod) {
+		changes = append(changes, fmt.Sprintf("voting_period: %s -> %s", gp.DecisionPolicy.Windows.VotingPeriod, want.VotingPeriod))
+	}
+	if !sameDuration(gp.DecisionPolicy.Windows.MinExecutionPeriod, want.MinExecutionPeriod) {
+		changes = append(changes, fmt.Sprintf(
+			"min_execution_period: %s -> %s",
+			gp.DecisionPolicy.Windows.MinExecutionPeriod, want.MinExecutionPeriod,
+		))
+	}
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	return &PolicyAction{
+		Kind:       PolicyActionDecisionPolicy,
+		Name:       gp.Address,
+		Signer:     gp.Admin,
+		Key:        consts.KeysIds.Eibc,
+		KeyringDir: eibcHome,
+		Changes:    changes,
+		msg: map[string]any{
+			"@type":                "/cosmos.group.v1.MsgUpdateGroupPolicyDecisionPolicy",
+			"admin":                gp.Admin,
+			"group_policy_address": gp.Address,
+			"decision_policy": map[string]any{
+				"@type":      percentageDecisionPolicyType,
+				"percentage": want.Percentage,
+				"windows": map[string]any{
+					"voting_period":        want.VotingPeriod,
+					"min_execution_period": want.MinExecutionPeriod,
+				},
+			},
+		},
+	}, nil
+}
+
+// diffRollapps describes the changes between the current and the desired
+// rollapps of a grant. applied are the spend limits last granted by rollapp.
+// Unless resetSpendLimits is set, the on-chain spend limit, lowered by
+// fulfillments, is kept in the desired grant when the desired limit is the
+// one last applied
+func diffRollapps(current, desired []Rollapp, applied map[string]string, resetSpendLimits bool) []string {
+	var changes []string
+
+	byID := map[string]Rollapp{}
+	for _, r := range current {
+		byID[r.RollappID] = r
+	}
+
+	for i, want := range desired {
+		have, ok := byID[want.RollappID]
+		delete(byID, want.RollappID)
+		if !ok {
+			changes = append(changes, fmt.Sprintf("+ %s", describeRollapp(want)))
+			continue
+		}
+
+		prefix := fmt.Sprintf("~ %s: ", want.RollappID)
+		haveLimit, wantLimit := fromAmounts(have.SpendLimit), fromAmounts(want.SpendLimit)
+		if !haveLimit.IsEqual(wantLimit) {
+			last, ok := applied[want.RollappID]
+			unchanged := ok && last == wantLimit.String()
+			if !resetSpendLimits && unchanged && sameDenoms(haveLimit, wantLimit) && wantLimit.IsAllGTE(haveLimit) {
+				desired[i].SpendLimit = have.SpendLimit
+			} else {
+				changes = append(changes, fmt.Sprintf("%sspend_limit %s -> %s", prefix, haveLimit, wantLimit))
+			}
+		}
+		if !slices.Equal(sorted(have.Denoms), sorted(want.Denoms)) {
+			changes = append(changes, fmt.Sprintf("%sdenoms %v -> %v", prefix, have.Denoms, want.Denoms))
+		}
+		if haveMax, wantMax := fromAmounts(have.MaxPrice), fromAmounts(want.MaxPrice); !haveMax.IsEqual(wantMax) {
+			changes = append(changes, fmt.Sprintf("%smax_price %s -> %s", prefix, haveMax, wantMax))
+		}
+		if !sameDec(have.MinFeePercentage, want.MinFeePercentage) {
+			changes = append(changes, fmt.Sprintf(
+				"%smin_fee_percentage %s -> %s", prefix, have.MinFeePercentage, want.MinFeePercentage,
+			))
+		}
+		if !sameDec(have.OperatorFeeShare, want.OperatorFeeShare) {
+			changes = append(changes, fmt.Sprintf(
+				"%soperator_fee_share %s -> %s", prefix, have.OperatorFeeShare, want.OperatorFeeShare,
+			))
+		}
+		if have.SettlementValidated != want.SettlementValidated {
+			changes = append(changes, fmt.Sprintf(
+				"%ssettlement_validated %t -> %t", prefix, have.SettlementValidated, want.SettlementValidated,
+			))
+		}
+	}
+
+	for _, r := range current {
+		if _, ok := byID[r.RollappID]; ok {
+			changes = append(changes, fmt.Sprintf("- %s", describeRollapp(r)))
+		}
+	}
+
+	return changes
+}
+
+func describeRollapp(r Rollapp) string {
+	return fmt.Sprintf(
+		"%s denoms=%v spend_limit=%s min_fee=%s operator_fee_share=%s",
+		r.RollappID, r.Denoms, fromAmounts(r.SpendLimit), r.MinFeePercentage, r.OperatorFeeShare,
+	)
+}
+
+// ApplyPolicyPlan runs the actions of the plan in order. The transaction of
+// every action is written to exportDir, and signed and broadcast when the
+// action has a key. The spend limits of the grants that were broadcast are
+// recorded in the policy state
+func ApplyPolicyPlan(p *PolicyPlan, hd consts.HubData, exportDir string) ([]PolicyApplyResult, error) {
+	// nolint:gofumpt
+	if err := os.MkdirAll(exportDir, 0o755); err != nil {
+		return nil, err
+	}
+
+	state, err := LoadPolicyState(p.eibcHome)
+	if err != nil {
+		return nil, err
+	}
+	stateChanged := false
+
+	results := make([]PolicyApplyResult, 0, len(p.Actions))
+	for _, a := range p.Actions {
+		res := PolicyApplyResult{Kind: a.Kind, Name: a.Name}
+
+		unsigned, err := writeUnsignedTx(a, exportDir)
+		if err != nil {
+			res.Error = err.Error()
+			results = append(results, res)
+			continue
+		}
+		res.UnsignedTx = unsigned
+
+		if a.Key != "" {
+			txHash, err := signAndBroadcast(a, unsigned, hd)
+			res.TxHash = txHash
+			if err != nil {
+				res.Error = err.Error()
+			} else {
+				stateChanged = recordPolicyAction(state, a) || stateChanged
+			}
+		}
+
+		results = append(results, res)
+	}
+
+	if stateChanged {
+		if err := state.Save(p.eibcHome); err != nil {
+			return results, fmt.Errorf("failed to save the policy state: %w", err)
+		}
+	}
+
+	return results, nil
+}
+
+// recordPolicyAction updates the policy state with an action applied on chain
+// and reports whether the state changed
+func recordPolicyAction(state PolicyState, a PolicyAction) bool {
+	switch a.Kind {
+	case PolicyActionGrant, PolicyActionUpdate:
+		state.SpendLimits[a.Signer] = a.spendLimits
+	case PolicyActionRevoke:
+		delete(state.SpendLimits, a.Signer)
+	default:
+		return false
+	}
+	return true
+}
+
+func writeUnsignedTx(a PolicyAction, exportDir string) (string, error) {
+	tx := map[string]any{
+		"body": map[string]any{
+			"messages":                       []any{a.msg},
+			"memo":                           "",
+			"timeout_height":                 "0",
+			"extension_options":              []any{},
+			"non_critical_extension_options": []any{},
+		},
+		"auth_info": map[string]any{
+			"signer_infos": []any{},
+			"fee": map[string]any{
+				"amount": []Amount{
+					{Denom: consts.Denoms.Hub, Amount: strconv.Itoa(consts.DefaultTxFee)},
+				},
+				"gas_limit": strconv.Itoa(policyTxGas),
+				"payer":     "",
+				"granter":   "",
+			},
+		},
+		"signatures": []any{},
+	}
+
+	b, err := json.MarshalIndent(tx, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(exportDir, fmt.Sprintf("%s-%s.unsigned.json", a.Kind, a.Name))
+	// nolint:gofumpt
+	return path, os.WriteFile(path, b, 0o644)
+}
+
+func signAndBroadcast(a PolicyAction, unsigned string, hd consts.HubData) (string, error) {
+	keyringBackend := a.KeyringBackend
+	if keyringBackend == "" {
+		keyringBackend = string(consts.SupportedKeyringBackends.Test)
+	}
+
+	args := []string{
+		"tx", "sign", unsigned,
+		"--from", a.Key,
+		"--keyring-backend", keyringBackend,
+		"--chain-id", hd.ID,
+		"--node", hd.RpcUrl,
+		"-o", "json",
+	}
+	if a.KeyringDir != "" {
+		args = append(args, "--keyring-dir", a.KeyringDir)
+	}
+	out, err := bash.ExecCommandWithStdout(exec.Command(consts.Executables.Dymension, args...))
+	if err != nil {
+		return "", fmt.Errorf("failed to sign: %w", err)
+	}
+
+	signed := unsigned[:len(unsigned)-len(".unsigned.json")] + ".signed.json"
+	// nolint:gofumpt
+	if err := os.WriteFile(signed, out.Bytes(), 0o600); err != nil {
+		return "", err
+	}
+
+	out, err = bash.ExecCommandWithStdout(exec.Command(
+		consts.Executables.Dymension,
+		"tx", "broadcast", signed,
+		"--node", hd.RpcUrl,
+		"-o", "json",
+	))
+	if err != nil {
+		return "", fmt.Errorf("failed to broadcast: %w", err)
+	}
+
+	var resp struct {
+		TxHash string `json:"txhash"`
+		Code   int    `json:"code"`
+		RawLog string `json:"raw_log"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &resp); err != nil {
+		return "", fmt.Errorf("failed to parse broadcast response: %w", err)
+	}
+	if resp.Code != 0 {
+		return resp.TxHash, fmt.Errorf("transaction failed with code %d: %s", resp.Code, resp.RawLog)
+	}
+
+	return resp.TxHash, tx.MonitorTransaction(hd.RpcUrl, resp.TxHash)
+}
+
+func sameDec(a, b string) bool {
+	da, errA := parsePolicyDec(a)
+	db, errB := parsePolicyDec(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return da.Equal(db)
+}
+
+func sameDuration(a, b string) bool {
+	da, errA := time.ParseDuration(a)
+	db, errB := time.ParseDuration(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return da == db
+}
+
+func sameDenoms(a, b cosmossdktypes.Coins) bool {
+	return slices.Equal(a.Denoms(), b.Denoms())
+}
+
+func sorted(s []string) []string {
+	c := slices.Clone(s)
+	sort.Strings(c)
+	return c
+}
@@ -0,0 +1,116 @@
+package eibc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiffRollappsSpendLimit(t *testing.T) {
+	rollapp := func(limit string) Rollapp {
+		return Rollapp{
+			RollappID:        "rollapp_1-1",
+			Denoms:           []string{"adym"},
+			MinFeePercentage: "0.01",
+			OperatorFeeShare: "0.1",
+			SpendLimit:       []Amount{{Denom: "adym", Amount: limit}},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		have      string
+		want      string
+		applied   map[string]string
+		reset     bool
+		wantLimit string
+		wantDiff  string
+	}{
+		{
+			name:      "unchanged limit keeps the remaining limit",
+			have:      "40",
+			want:      "100",
+			applied:   map[string]string{"rollapp_1-1": "100adym"},
+			wantLimit: "40",
+		},
+		{
+			name:      "raised limit is granted",
+			have:      "40",
+			want:      "200",
+			applied:   map[string]string{"rollapp_1-1": "100adym"},
+			wantLimit: "200",
+			wantDiff:  "spend_limit 40adym -> 200adym",
+		},
+		{
+			name:      "lowered limit is granted",
+			have:      "40",
+			want:      "50",
+			applied:   map[string]string{"rollapp_1-1": "100adym"},
+			wantLimit: "50",
+			wantDiff:  "spend_limit 40adym -> 50adym",
+		},
+		{
+			name:      "limit never applied is granted",
+			have:      "40",
+			want:      "100",
+			wantLimit: "100",
+			wantDiff:  "spend_limit 40adym -> 100adym",
+		},
+		{
+			name:      "reset restores the limit",
+			have:      "40",
+			want:      "100",
+			applied:   map[string]string{"rollapp_1-1": "100adym"},
+			reset:     true,
+			wantLimit: "100",
+			wantDiff:  "spend_limit 40adym -> 100adym",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desired := []Rollapp{rollapp(tt.want)}
+			changes := diffRollapps([]Rollapp{rollapp(tt.have)}, desired, tt.applied, tt.reset)
+
+			if tt.wantDiff == "" && len(changes) != 0 {
+				t.Fatalf("unexpected changes: %v", changes)
+			}
+			if tt.wantDiff != "" && (len(changes) != 1 || !strings.Contains(changes[0], tt.wantDiff)) {
+				t.Fatalf("got changes %v, want %q", changes, tt.wantDiff)
+			}
+			if got := desired[0].SpendLimit[0].Amount; got != tt.wantLimit {
+				t.Fatalf("granted spend limit is %s, want %s", got, tt.wantLimit)
+			}
+		})
+	}
+}
+
+func TestRecordPolicyAction(t *testing.T) {
+	home := t.TempDir()
+
+	state, err := LoadPolicyState(home)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recordPolicyAction(state, PolicyAction{
+		Kind:        PolicyActionGrant,
+		Signer:      "dym1lp",
+		spendLimits: map[string]string{"rollapp_1-1": "100adym"},
+	})
+	recordPolicyAction(state, PolicyAction{
+		Kind:        PolicyActionGrant,
+		Signer:      "dym1other",
+		spendLimits: map[string]string{"rollapp_1-1": "5adym"},
+	})
+	recordPolicyAction(state, PolicyAction{Kind: PolicyActionRevoke, Signer: "dym1other"})
+	if err := state.Save(home); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadPolicyState(home)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.SpendLimits) != 1 || loaded.SpendLimits["dym1lp"]["rollapp_1-1"] != "100adym" {
+		t.Fatalf("unexpected policy state: %+v", loaded)
+	}
+}
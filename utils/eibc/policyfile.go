@@ -0,0 +1,182 @@
+package eibc
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	cosmossdktypes "github.com/cosmos/cosmos-sdk/types"
+	"gopkg.in/yaml.v3"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+)
+
+// PolicyFileName is the default name of the policy file in the eibc home
+const PolicyFileName = "policy.yaml"
+
+// PolicyFile declares the liquidity providers that delegate order fulfillment
+// to the operator policy, and the decision policy of the operator group policy
+type PolicyFile struct {
+	GroupPolicy *GroupPolicyDecision `yaml:"group_policy,omitempty"`
+	LPs         []LPPolicy           `yaml:"lps"`
+}
+
+// GroupPolicyDecision is the percentage decision policy of the group policy
+type GroupPolicyDecision struct {
+	Percentage         string `yaml:"percentage"`
+	VotingPeriod       string `yaml:"voting_period"`
+	MinExecutionPeriod string `yaml:"min_execution_period"`
+}
+
+// LPPolicy is the fulfillment authorization granted by a liquidity provider to
+// the operator policy. Key is the name of the LP key used to sign its grants
+// and revocations, without it the transactions are exported unsigned
+type LPPolicy struct {
+	Name           string          `yaml:"name"`
+	Address        string          `yaml:"address"`
+	Key            string          `yaml:"key,omitempty"`
+	KeyringBackend string          `yaml:"keyring_backend,omitempty"`
+	KeyringDir     string          `yaml:"keyring_dir,omitempty"`
+	Rollapps       []RollappPolicy `yaml:"rollapps"`
+}
+
+// RollappPolicy are the conditions under which the orders of a rollapp can be
+// fulfilled with the funds of a liquidity provider
+type RollappPolicy struct {
+	RollappID           string   `yaml:"rollapp_id"`
+	Denoms              []string `yaml:"denoms"`
+	SpendLimit          string   `yaml:"spend_limit"`
+	MaxPrice            string   `yaml:"max_price,omitempty"`
+	MinFeePercentage    string   `yaml:"min_fee_percentage"`
+	OperatorFeeShare    string   `yaml:"operator_fee_share"`
+	SettlementValidated bool     `yaml:"settlement_validated"`
+}
+
+func LoadPolicyFile(path string) (*PolicyFile, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file: %w", err)
+	}
+
+	var pf PolicyFile
+	if err := yaml.Unmarshal(b, &pf); err != nil {
+		return nil, fmt.Errorf("failed to parse policy file: %w", err)
+	}
+
+	if err := pf.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid policy file %s: %w", path, err)
+	}
+	return &pf, nil
+}
+
+func (pf *PolicyFile) Validate() error {
+	if pf.GroupPolicy != nil {
+		if _, err := cosmossdktypes.NewDecFromStr(pf.GroupPolicy.Percentage); err != nil {
+			return fmt.Errorf("group_policy: invalid percentage %q", pf.GroupPolicy.Percentage)
+		}
+	}
+
+	seen := map[string]bool{}
+	for _, lp := range pf.LPs {
+		if lp.Name == "" || lp.Address == "" {
+			return fmt.Errorf("every lp needs a name and an address")
+		}
+		if !strings.HasPrefix(lp.Address, consts.AddressPrefixes.Hub) {
+			return fmt.Errorf("lp %s: %s is not a hub address", lp.Name, lp.Address)
+		}
+		if seen[lp.Address] {
+			return fmt.Errorf("lp %s: address %s is declared more than once", lp.Name, lp.Address)
+		}
+		seen[lp.Address] = true
+
+		if len(lp.Rollapps) == 0 {
+			return fmt.Errorf("lp %s: no rollapps, remove the lp to revoke its grant", lp.Name)
+		}
+		for _, ra := range lp.Rollapps {
+			if _, err := ra.toAuthorization(); err != nil {
+				return fmt.Errorf("lp %s: rollapp %s: %w", lp.Name, ra.RollappID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// toAuthorization converts the rollapp policy to its on-chain representation,
+// with normalized coins and decimals
+func (ra RollappPolicy) toAuthorization() (Rollapp, error) {
+	if ra.RollappID == "" {
+		return Rollapp{}, fmt.Errorf("rollapp_id is required")
+	}
+	if len(ra.Denoms) == 0 {
+		return Rollapp{}, fmt.Errorf("at least one denom is required")
+	}
+
+	spendLimit, err := parsePolicyCoins(ra.SpendLimit)
+	if err != nil || spendLimit.Empty() {
+		return Rollapp{}, fmt.Errorf("invalid spend_limit %q", ra.SpendLimit)
+	}
+	maxPrice, err := parsePolicyCoins(ra.MaxPrice)
+	if err != nil {
+		return Rollapp{}, fmt.Errorf("invalid max_price %q", ra.MaxPrice)
+	}
+	minFee, err := parsePolicyDec(ra.MinFeePercentage)
+	if err != nil {
+		return Rollapp{}, fmt.Errorf("invalid min_fee_percentage %q", ra.MinFeePercentage)
+	}
+	operatorFeeShare, err := parsePolicyDec(ra.OperatorFeeShare)
+	if err != nil || operatorFeeShare.GT(cosmossdktypes.OneDec()) {
+		return Rollapp{}, fmt.Errorf("invalid operator_fee_share %q", ra.OperatorFeeShare)
+	}
+
+	return Rollapp{
+		RollappID:           ra.RollappID,
+		Denoms:              ra.Denoms,
+		MaxPrice:            toAmounts(maxPrice),
+		MinFeePercentage:    minFee.String(),
+		OperatorFeeShare:    operatorFeeShare.String(),
+		SettlementValidated: ra.SettlementValidated,
+		SpendLimit:          toAmounts(spendLimit),
+	}, nil
+}
+
+func parsePolicyCoins(s string) (cosmossdktypes.Coins, error) {
+	if s == "" {
+		return cosmossdktypes.Coins{}, nil
+	}
+	return cosmossdktypes.ParseCoinsNormalized(s)
+}
+
+func parsePolicyDec(s string) (cosmossdktypes.Dec, error) {
+	if s == "" {
+		return cosmossdktypes.ZeroDec(), nil
+	}
+	d, err := cosmossdktypes.NewDecFromStr(s)
+	if err != nil {
+		return d, err
+	}
+	if d.IsNegative() {
+		return d, fmt.Errorf("negative value")
+	}
+	return d, nil
+}
+
+func toAmounts(coins cosmossdktypes.Coins) []Amount {
+	amounts := []Amount{}
+	for _, c := range coins {
+		amounts = append(amounts, Amount{Denom: c.Denom, Amount: c.Amount.String()})
+	}
+	return amounts
+}
+
+func fromAmounts(amounts []Amount) cosmossdktypes.Coins {
+	coins := cosmossdktypes.Coins{}
+	for _, a := range amounts {
+		amt, ok := cosmossdktypes.NewIntFromString(a.Amount)
+		if !ok {
+			continue
+		}
+		coins = coins.Add(cosmossdktypes.NewCoin(a.Denom, amt))
+	}
+	return coins
+}
@@ -0,0 +1,56 @@
+package eibc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// PolicyStateFileName is the file in the eibc home that records the policy
+// last applied to the chain
+const PolicyStateFileName = "policy_state.json"
+
+// PolicyState holds the spend limits of the policy file at the time they were
+// last granted, by liquidity provider address and rollapp id. The on-chain
+// spend limit is lowered by every fulfillment, so it is the applied limit, not
+// the on-chain one, that tells whether the policy file changed
+type PolicyState struct {
+	SpendLimits map[string]map[string]string `json:"spend_limits"`
+}
+
+func policyStatePath(eibcHome string) string {
+	return filepath.Join(eibcHome, PolicyStateFileName)
+}
+
+// LoadPolicyState reads the policy state from the eibc home, a missing file is
+// an empty state
+func LoadPolicyState(eibcHome string) (PolicyState, error) {
+	s := PolicyState{SpendLimits: map[string]map[string]string{}}
+
+	b, err := os.ReadFile(policyStatePath(eibcHome))
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return s, err
+	}
+	if err := json.Unmarshal(b, &s); err != nil {
+		return s, fmt.Errorf("failed to parse %s: %w", PolicyStateFileName, err)
+	}
+	if s.SpendLimits == nil {
+		s.SpendLimits = map[string]map[string]string{}
+	}
+	return s, nil
+}
+
+// Save writes the policy state to the eibc home
+func (s PolicyState) Save(eibcHome string) error {
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	// nolint:gofumpt
+	return os.WriteFile(policyStatePath(eibcHome), b, 0o644)
+}
@@ -0,0 +1,503 @@
+package eibc
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	cosmossdktypes "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/bash"
+)
+
+const (
+	// EventOrderFulfilledAuthorized is emitted by the hub when an order is
+	// fulfilled on behalf of a liquidity provider through the operator policy
+	EventOrderFulfilledAuthorized = "dymensionxyz.dymension.eibc.EventDemandOrderFulfilledAuthorized"
+	// EventOrderPacketStatusUpdated is emitted when the packet of an order is
+	// finalized, which releases the capital of the fulfiller
+	EventOrderPacketStatusUpdated = "dymensionxyz.dymension.eibc.EventDemandOrderPacketStatusUpdated"
+
+	reportPageSize = 100
+
+	FinalizationFinalized = "finalized"
+	FinalizationPending   = "pending"
+	// FinalizationUnknown marks the orders whose finalization could not be
+	// looked up, they are left out of the capital locked
+	FinalizationUnknown = "unknown"
+)
+
+// FulfilledOrder is an order fulfilled through the operator policy
+type FulfilledOrder struct {
+	ID          string    `json:"id" yaml:"id"`
+	RollappID   string    `json:"rollapp_id" yaml:"rollapp_id"`
+	Fulfiller   string    `json:"fulfiller" yaml:"fulfiller"`
+	LPAddress   string    `json:"lp_address" yaml:"lp_address"`
+	Denom       string    `json:"denom" yaml:"denom"`
+	Price       *big.Int  `json:"price" yaml:"price"`
+	Fee         *big.Int  `json:"fee" yaml:"fee"`
+	OperatorFee *big.Int  `json:"operator_fee" yaml:"operator_fee"`
+	TxHash      string    `json:"tx_hash" yaml:"tx_hash"`
+	Height      int64     `json:"height" yaml:"height"`
+	FulfilledAt time.Time `json:"fulfilled_at" yaml:"fulfilled_at"`
+	// Finalization is one of finalized, pending or unknown
+	Finalization string    `json:"finalization" yaml:"finalization"`
+	FinalizedAt  time.Time `json:"finalized_at,omitempty" yaml:"finalized_at,omitempty"`
+}
+
+// LPFee is the part of the fee left to the liquidity provider
+func (o FulfilledOrder) LPFee() *big.Int {
+	return new(big.Int).Sub(o.Fee, o.OperatorFee)
+}
+
+// TimeToFinalization is how long the capital of the order was locked, zero
+// while the order is not finalized
+func (o FulfilledOrder) TimeToFinalization() time.Duration {
+	if o.FinalizedAt.IsZero() {
+		return 0
+	}
+	return o.FinalizedAt.Sub(o.FulfilledAt)
+}
+
+// ReportTotals aggregates the fulfilled orders of a fulfiller or a rollapp
+// in a single denom
+type ReportTotals struct {
+	Key    string `json:"key" yaml:"key"`
+	Denom  string `json:"denom" yaml:"denom"`
+	Orders int    `json:"orders" yaml:"orders"`
+	// Volume is the amount paid out to fulfill the orders
+	Volume      *big.Int `json:"volume" yaml:"volume"`
+	FeesEarned  *big.Int `json:"fees_earned" yaml:"fees_earned"`
+	OperatorFee *big.Int `json:"operator_fee" yaml:"operator_fee"`
+	// CapitalLocked is the amount of the orders that are not finalized yet
+	CapitalLocked *big.Int `json:"capital_locked" yaml:"capital_locked"`
+	Finalized     int      `json:"finalized" yaml:"finalized"`
+	// Unknown is the number of orders whose finalization could not be looked up
+	Unknown int `json:"unknown" yaml:"unknown"`
+	// AvgTimeToFinalization is averaged over the finalized orders
+	AvgTimeToFinalization string `json:"avg_time_to_finalization" yaml:"avg_time_to_finalization"`
+
+	totalFinalization time.Duration
+}
+
+// Report is the accounting of the orders fulfilled through a policy address
+// over a period
+type Report struct {
+	PolicyAddress string           `json:"policy_address" yaml:"policy_address"`
+	From          time.Time        `json:"from" yaml:"from"`
+	To            time.Time        `json:"to" yaml:"to"`
+	GeneratedAt   time.Time        `json:"generated_at" yaml:"generated_at"`
+	Orders        []FulfilledOrder `json:"orders" yaml:"orders"`
+	ByFulfiller   []ReportTotals   `json:"by_fulfiller" yaml:"by_fulfiller"`
+	ByRollapp     []ReportTotals   `json:"by_rollapp" yaml:"by_rollapp"`
+	// FinalizationError is set when the finalization of the orders could not
+	// be looked up, they are reported as unknown
+	FinalizationError string `json:"finalization_error,omitempty" yaml:"finalization_error,omitempty"`
+}
+
+type txEventAttribute struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type txEvent struct {
+	Type       string             `json:"type"`
+	Attributes []txEventAttribute `json:"attributes"`
+}
+
+type txSearchResult struct {
+	Height    string    `json:"height"`
+	TxHash    string    `json:"txhash"`
+	Timestamp time.Time `json:"timestamp"`
+	Events    []txEvent `json:"events"`
+	Tx        struct {
+		Body struct {
+			Messages []struct {
+				Type    string `json:"@type"`
+				Grantee string `json:"grantee"`
+				Sender  string `json:"sender"`
+			} `json:"messages"`
+		} `json:"body"`
+	} `json:"tx"`
+}
+
+type txSearchPage struct {
+	TotalCount string           `json:"total_count"`
+	PageTotal  string           `json:"page_total"`
+	Txs        []txSearchResult `json:"txs"`
+}
+
+// BuildReport reconstructs the orders fulfilled through the policy address
+// between from and to from the events of the hub. Only the blocks of the
+// period are searched
+func BuildReport(hd consts.HubData, policyAddr string, from, to time.Time) (*Report, error) {
+	fromHeight, toHeight, err := heightRange(hd.RpcUrl, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the blocks of the period: %w", err)
+	}
+
+	r := &Report{
+		PolicyAddress: policyAddr,
+		From:          from,
+		To:            to,
+		GeneratedAt:   time.Now().UTC(),
+		Orders:        []FulfilledOrder{},
+	}
+	if toHeight < fromHeight {
+		return r, nil
+	}
+
+	query := fmt.Sprintf(
+		"%s.operator_fee_address='\"%s\"' AND tx.height>=%d AND tx.height<=%d",
+		EventOrderFulfilledAuthorized, policyAddr, fromHeight, toHeight,
+	)
+	txs, err := searchTxs(hd, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search fulfillment events: %w", err)
+	}
+
+	for _, tx := range txs {
+		if tx.Timestamp.Before(from) || !tx.Timestamp.Before(to) {
+			continue
+		}
+
+		height, _ := strconv.ParseInt(tx.Height, 10, 64)
+		signer := ""
+		if msgs := tx.Tx.Body.Messages; len(msgs) > 0 {
+			signer = msgs[0].Grantee
+			if signer == "" {
+				signer = msgs[0].Sender
+			}
+		}
+
+		for _, ev := range tx.Events {
+			if ev.Type != EventOrderFulfilledAuthorized {
+				continue
+			}
+			attrs := eventAttributes(ev)
+			if attrs["operator_fee_address"] != policyAddr {
+				continue
+			}
+
+			o := FulfilledOrder{
+				ID:           attrs["order_id"],
+				RollappID:    attrs["rollapp_id"],
+				Fulfiller:    attrs["fulfiller"],
+				LPAddress:    attrs["lp_address"],
+				TxHash:       tx.TxHash,
+				Height:       height,
+				FulfilledAt:  tx.Timestamp,
+				Finalization: FinalizationUnknown,
+			}
+			if o.Fulfiller == "" {
+				o.Fulfiller = signer
+			}
+			o.Denom, o.Price = parseCoinAttribute(attrs["price"])
+			_, o.Fee = parseCoinAttribute(attrs["fee"])
+			_, o.OperatorFee = parseCoinAttribute(attrs["operator_fee"])
+
+			r.Orders = append(r.Orders, o)
+		}
+	}
+
+	if len(r.Orders) > 0 {
+		if err := setFinalizations(hd, r.Orders); err != nil {
+			r.FinalizationError = err.Error()
+		}
+	}
+
+	sort.Slice(r.Orders, func(i, j int) bool { return r.Orders[i].FulfilledAt.Before(r.Orders[j].FulfilledAt) })
+	r.ByFulfiller = aggregate(r.Orders, func(o FulfilledOrder) string { return o.Fulfiller })
+	r.ByRollapp = aggregate(r.Orders, func(o FulfilledOrder) string { return o.RollappID })
+
+	return r, nil
+}
+
+// setFinalizations looks up the finalization of all the orders with a single
+// search of the finalization events emitted since the first fulfillment. The
+// orders are left unknown when the search fails
+func setFinalizations(hd consts.HubData, orders []FulfilledOrder) error {
+	fromHeight := orders[0].Height
+	for _, o := range orders {
+		fromHeight = min(fromHeight, o.Height)
+	}
+
+	query := fmt.Sprintf(
+		"%s.new_packet_status='\"FINALIZED\"' AND tx.height>=%d",
+		EventOrderPacketStatusUpdated, fromHeight,
+	)
+	txs, err := searchTxs(hd, query)
+	if err != nil {
+		return fmt.Errorf("failed to search finalization events: %w", err)
+	}
+
+	finalized := map[string]time.Time{}
+	for _, tx := range txs {
+		for _, ev := range tx.Events {
+			if ev.Type != EventOrderPacketStatusUpdated {
+				continue
+			}
+			attrs := eventAttributes(ev)
+			if attrs["new_packet_status"] != "FINALIZED" {
+				continue
+			}
+			if _, ok := finalized[attrs["order_id"]]; !ok {
+				finalized[attrs["order_id"]] = tx.Timestamp
+			}
+		}
+	}
+
+	for i := range orders {
+		o := &orders[i]
+		if t, ok := finalized[o.ID]; ok {
+			o.Finalization = FinalizationFinalized
+			o.FinalizedAt = t
+		} else {
+			o.Finalization = FinalizationPending
+		}
+	}
+	return nil
+}
+
+// heightRange returns the first and the last height of the blocks produced
+// in [from, to). The range is empty, i.e. the last height is below the first
+// one, when no block was produced in the period
+func heightRange(rpc string, from, to time.Time) (int64, int64, error) {
+	earliest, err := getEarliestHeight(rpc)
+	if err != nil {
+		return 0, 0, err
+	}
+	latest, latestTime, err := getBlockTime(rpc, 0)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	fromHeight, err := firstHeightAt(rpc, earliest, latest, latestTime, from)
+	if err != nil {
+		return 0, 0, err
+	}
+	toHeight, err := firstHeightAt(rpc, fromHeight, latest, latestTime, to)
+	if err != nil {
+		return 0, 0, err
+	}
+	return fromHeight, toHeight - 1, nil
+}
+
+// firstHeightAt returns the first height between lo and latest whose block
+// time is not before t, latest + 1 when there is none
+func firstHeightAt(rpc string, lo, latest int64, latestTime, t time.Time) (int64, error) {
+	if latestTime.Before(t) {
+		return latest + 1, nil
+	}
+
+	hi := latest
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		_, blockTime, err := getBlockTime(rpc, mid)
+		if err != nil {
+			return 0, fmt.Errorf("failed to query block %d: %w", mid, err)
+		}
+		if blockTime.Before(t) {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo, nil
+}
+
+type statusResponse struct {
+	Result struct {
+		SyncInfo struct {
+			EarliestBlockHeight string `json:"earliest_block_height"`
+		} `json:"sync_info"`
+	} `json:"result"`
+}
+
+// getEarliestHeight returns the oldest block height the node still serves
+func getEarliestHeight(rpc string) (int64, error) {
+	client := http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(strings.TrimSuffix(rpc, "/") + "/status")
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	var s statusResponse
+	if err := json.NewDecoder(resp.Body).Decode(&s); err != nil {
+		return 0, err
+	}
+
+	h, err := strconv.ParseInt(s.Result.SyncInfo.EarliestBlockHeight, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid earliest block height: %w", err)
+	}
+	return max(h, 1), nil
+}
+
+func searchTxs(hd consts.HubData, query string) ([]txSearchResult, error) {
+	var txs []txSearchResult
+
+	for page := 1; ; page++ {
+		cmd := exec.Command(
+			consts.Executables.Dymension,
+			"q", "txs",
+			"--query", query,
+			"--page", strconv.Itoa(page),
+			"--limit", strconv.Itoa(reportPageSize),
+			"--node", hd.RpcUrl,
+			"--chain-id", hd.ID,
+			"-o", "json",
+		)
+		out, err := bash.ExecCommandWithStdoutFiltered(cmd)
+		if err != nil {
+			return nil, err
+		}
+
+		var p txSearchPage
+		if err := json.Unmarshal(out.Bytes(), &p); err != nil {
+			return nil, fmt.Errorf("failed to parse transactions: %w", err)
+		}
+		txs = append(txs, p.Txs...)
+
+		pageTotal, _ := strconv.Atoi(p.PageTotal)
+		if len(p.Txs) < reportPageSize || page >= pageTotal {
+			return txs, nil
+		}
+	}
+}
+
+// eventAttributes returns the attributes of a typed event, whose values are
+// JSON encoded
+func eventAttributes(ev txEvent) map[string]string {
+	attrs := make(map[string]string, len(ev.Attributes))
+	for _, a := range ev.Attributes {
+		v := a.Value
+		if unquoted, err := strconv.Unquote(v); err == nil {
+			v = unquoted
+		}
+		attrs[a.Key] = v
+	}
+	return attrs
+}
+
+// parseCoinAttribute parses an amount given either as a coin string, e.g.
+// 100adym, or as a JSON list of coins. Only the first coin is used
+func parseCoinAttribute(v string) (string, *big.Int) {
+	var coins []hubCoin
+	if err := json.Unmarshal([]byte(v), &coins); err == nil {
+		if len(coins) == 0 {
+			return "", big.NewInt(0)
+		}
+		return coins[0].Denom, parseAmount(coins[0].Amount)
+	}
+
+	parsed, err := cosmossdktypes.ParseCoinsNormalized(v)
+	if err != nil || len(parsed) == 0 {
+		return "", parseAmount(v)
+	}
+	return parsed[0].Denom, parsed[0].Amount.BigInt()
+}
+
+func aggregate(orders []FulfilledOrder, key func(FulfilledOrder) string) []ReportTotals {
+	byKey := map[string]*ReportTotals{}
+	for _, o := range orders {
+		k := key(o) + "/" + o.Denom
+		t, ok := byKey[k]
+		if !ok {
+			t = &ReportTotals{
+				Key:           key(o),
+				Denom:         o.Denom,
+				Volume:        big.NewInt(0),
+				FeesEarned:    big.NewInt(0),
+				OperatorFee:   big.NewInt(0),
+				CapitalLocked: big.NewInt(0),
+			}
+			byKey[k] = t
+		}
+
+		t.Orders++
+		t.Volume.Add(t.Volume, o.Price)
+		t.FeesEarned.Add(t.FeesEarned, o.LPFee())
+		t.OperatorFee.Add(t.OperatorFee, o.OperatorFee)
+		switch o.Finalization {
+		case FinalizationFinalized:
+			t.Finalized++
+			t.totalFinalization += o.TimeToFinalization()
+		case FinalizationPending:
+			t.CapitalLocked.Add(t.CapitalLocked, o.Price)
+		default:
+			t.Unknown++
+		}
+	}
+
+	totals := make([]ReportTotals, 0, len(byKey))
+	for _, t := range byKey {
+		if t.Finalized > 0 {
+			t.AvgTimeToFinalization = (t.totalFinalization / time.Duration(t.Finalized)).Round(time.Second).String()
+		}
+		totals = append(totals, *t)
+	}
+	sort.Slice(totals, func(i, j int) bool {
+		if totals[i].Key != totals[j].Key {
+			return totals[i].Key < totals[j].Key
+		}
+		return totals[i].Denom < totals[j].Denom
+	})
+	return totals
+}
+
+// WriteCSV writes one row per fulfilled order
+func (r *Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	header := []string{
+		"order_id", "rollapp_id", "fulfiller", "lp_address", "denom", "price", "fee",
+		"operator_fee", "lp_fee", "fulfilled_at", "finalization", "finalized_at", "time_to_finalization_seconds",
+		"height", "tx_hash",
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for _, o := range r.Orders {
+		finalizedAt, ttf := "", ""
+		if !o.FinalizedAt.IsZero() {
+			finalizedAt = o.FinalizedAt.UTC().Format(time.RFC3339)
+			ttf = strconv.FormatInt(int64(o.TimeToFinalization().Seconds()), 10)
+		}
+		row := []string{
+			o.ID, o.RollappID, o.Fulfiller, o.LPAddress, o.Denom,
+			o.Price.String(), o.Fee.String(), o.OperatorFee.String(), o.LPFee().String(),
+			o.FulfilledAt.UTC().Format(time.RFC3339), o.Finalization, finalizedAt, ttf,
+			strconv.FormatInt(o.Height, 10), o.TxHash,
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// ParseReportTime parses a report boundary given as a date or an RFC3339 time
+func ParseReportTime(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return t.UTC(), nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q, expected YYYY-MM-DD or RFC3339", s)
+	}
+	return t.UTC(), nil
+}
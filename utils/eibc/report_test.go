@@ -0,0 +1,113 @@
+package eibc
+
+import (
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// fakeHubRPC serves /status and /block for blocks earliest to latest, produced
+// every second from genesis
+func fakeHubRPC(t *testing.T, earliest, latest int64, genesis time.Time) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/status":
+			fmt.Fprintf(w, `{"result":{"sync_info":{"earliest_block_height":"%d"}}}`, earliest)
+		case "/block":
+			h := latest
+			if s := r.URL.Query().Get("height"); s != "" {
+				h, _ = strconv.ParseInt(s, 10, 64)
+			}
+			if h < earliest || h > latest {
+				http.Error(w, `{"error":"height is not available"}`, http.StatusInternalServerError)
+				return
+			}
+			blockTime := genesis.Add(time.Duration(h) * time.Second).Format(time.RFC3339)
+			fmt.Fprintf(w, `{"result":{"block":{"header":{"height":"%d","time":"%s"}}}}`, h, blockTime)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestHeightRange(t *testing.T) {
+	genesis := time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC)
+	at := func(h int64) time.Time { return genesis.Add(time.Duration(h) * time.Second) }
+	srv := fakeHubRPC(t, 100, 1000, genesis)
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+		wantFrom int64
+		wantTo   int64
+	}{
+		{name: "within the chain", from: at(200), to: at(300), wantFrom: 200, wantTo: 299},
+		{name: "between blocks", from: at(200).Add(time.Millisecond), to: at(300).Add(time.Millisecond), wantFrom: 201, wantTo: 300},
+		{name: "before the earliest block", from: genesis, to: at(150), wantFrom: 100, wantTo: 149},
+		{name: "until now", from: at(900), to: at(5000), wantFrom: 900, wantTo: 1000},
+		{name: "in the future", from: at(2000), to: at(3000), wantFrom: 1001, wantTo: 1000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, err := heightRange(srv.URL, tt.from, tt.to)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if from != tt.wantFrom || to != tt.wantTo {
+				t.Fatalf("got %d-%d, want %d-%d", from, to, tt.wantFrom, tt.wantTo)
+			}
+		})
+	}
+}
+
+func TestAggregateUnknownFinalization(t *testing.T) {
+	fulfilled := time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC)
+	order := func(id, finalization string, price int64) FulfilledOrder {
+		o := FulfilledOrder{
+			ID:           id,
+			RollappID:    "rollapp_1-1",
+			Denom:        "adym",
+			Price:        big.NewInt(price),
+			Fee:          big.NewInt(10),
+			OperatorFee:  big.NewInt(2),
+			FulfilledAt:  fulfilled,
+			Finalization: finalization,
+		}
+		if finalization == FinalizationFinalized {
+			o.FinalizedAt = fulfilled.Add(time.Hour)
+		}
+		return o
+	}
+
+	totals := aggregate([]FulfilledOrder{
+		order("1", FinalizationFinalized, 100),
+		order("2", FinalizationPending, 200),
+		order("3", FinalizationUnknown, 400),
+	}, func(o FulfilledOrder) string { return o.RollappID })
+
+	if len(totals) != 1 {
+		t.Fatalf("got %d totals, want 1", len(totals))
+	}
+	got := totals[0]
+	if got.Orders != 3 || got.Finalized != 1 || got.Unknown != 1 {
+		t.Fatalf("unexpected counts: %+v", got)
+	}
+	if got.CapitalLocked.Int64() != 200 {
+		t.Fatalf("capital locked is %s, want only the pending order", got.CapitalLocked)
+	}
+	if got.AvgTimeToFinalization != "1h0m0s" {
+		t.Fatalf("average time to finalization is %q", got.AvgTimeToFinalization)
+	}
+	if got.FeesEarned.Int64() != 24 {
+		t.Fatalf("fees earned is %s, want 24", got.FeesEarned)
+	}
+}
@@ -5,13 +5,15 @@ import (
 	"os/signal"
 
 	"github.com/pterm/pterm"
+
+	"github.com/dymensionxyz/roller/utils/plan"
 )
 
 func PrettifyErrorIfExists(err error, printAdditionalInfo ...func()) {
 	if err != nil {
 		defer func() {
 			if r := recover(); r != nil {
-				os.Exit(1)
+				plan.Exit(1)
 			}
 		}()
 		pterm.Error.Printf("💈 %s\n", err.Error())
@@ -30,6 +32,6 @@ func RunOnInterrupt(funcToRun func()) {
 		signal.Notify(c, os.Interrupt)
 		<-c
 		funcToRun()
-		os.Exit(0)
+		plan.Exit(0)
 	}()
 }
@@ -0,0 +1,447 @@
+// Package exporter exposes the state of the roller managed components of a
+// host as prometheus metrics
+package exporter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	cosmossdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	datalayer "github.com/dymensionxyz/roller/data_layer"
+	"github.com/dymensionxyz/roller/relayer"
+	"github.com/dymensionxyz/roller/sequencer"
+	eibcutils "github.com/dymensionxyz/roller/utils/eibc"
+	"github.com/dymensionxyz/roller/utils/keys"
+	"github.com/dymensionxyz/roller/utils/roller"
+	sequencerutils "github.com/dymensionxyz/roller/utils/sequencer"
+	"github.com/dymensionxyz/roller/utils/statenode"
+)
+
+const namespace = "roller"
+
+const (
+	keySequencer    = "sequencer"
+	keyDA           = "da"
+	keyRelayer      = "relayer"
+	keyEibcOperator = "eibc-operator"
+)
+
+// Exporter collects the metrics of a roller home in the background, so that
+// scrapes do not wait for the hub and DA queries
+type Exporter struct {
+	home     string
+	registry *prometheus.Registry
+	logger   *log.Logger
+
+	collectorUp       *prometheus.GaugeVec
+	lastCollect       prometheus.Gauge
+	keyBalance        *prometheus.GaugeVec
+	sequencerBond     *prometheus.GaugeVec
+	relayerChannel    *prometheus.GaugeVec
+	eibcFulfillers    prometheus.Gauge
+	eibcRollapps      prometheus.Gauge
+	eibcOrdersPerTx   prometheus.Gauge
+	daUp              prometheus.Gauge
+	stateNodeScore    *prometheus.GaugeVec
+	stateNodeCurrent  *prometheus.GaugeVec
+	stateNodeSwitches prometheus.Gauge
+	lastSwitch        prometheus.Gauge
+	rollappHeight     prometheus.Gauge
+	hubHeight         prometheus.Gauge
+	heightLag         prometheus.Gauge
+}
+
+func New(home string, logger *log.Logger) *Exporter {
+	gauge := func(subsystem, name, help string) prometheus.Gauge {
+		return prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace, Subsystem: subsystem, Name: name, Help: help,
+		})
+	}
+	gaugeVec := func(subsystem, name, help string, labels ...string) *prometheus.GaugeVec {
+		return prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace, Subsystem: subsystem, Name: name, Help: help,
+		}, labels)
+	}
+
+	e := &Exporter{
+		home:     home,
+		registry: prometheus.NewRegistry(),
+		logger:   logger,
+
+		collectorUp: gaugeVec("exporter", "collector_up",
+			"Whether the last run of a collector succeeded", "collector"),
+		lastCollect: gauge("exporter", "last_collect_timestamp_seconds",
+			"Unix time of the last collection"),
+		keyBalance: gaugeVec("key", "balance",
+			"Balance of a roller managed key in base denom", "key", "address", "denom"),
+		sequencerBond: gaugeVec("sequencer", "bond",
+			"Bond of the sequencer on the hub in base denom", "denom"),
+		relayerChannel: gaugeVec("relayer", "channel_open",
+			"Whether an IBC channel of the rollapp is open", "channel", "counterparty_channel", "state"),
+		eibcFulfillers: gauge("eibc", "fulfillers",
+			"Number of fulfiller accounts of the eibc client"),
+		eibcRollapps: gauge("eibc", "supported_rollapps",
+			"Number of rollapps the eibc client fulfills orders for"),
+		eibcOrdersPerTx: gauge("eibc", "max_orders_per_tx",
+			"Maximum number of orders fulfilled in a single transaction"),
+		daUp: gauge("da", "light_client_up",
+			"Whether the DA light client is active"),
+		stateNodeScore: gaugeVec("da", "state_node_score",
+			"Health score of a DA state node, from 0 to 100", "node"),
+		stateNodeCurrent: gaugeVec("da", "state_node_current",
+			"The DA state node currently in use", "node"),
+		stateNodeSwitches: gauge("da", "state_node_switches_total",
+			"Number of DA state node switches, including health agent failovers"),
+		lastSwitch: gauge("da", "state_node_last_switch_timestamp_seconds",
+			"Unix time of the last DA state node switch"),
+		rollappHeight: gauge("rollapp", "height",
+			"Latest block height of the local rollapp node"),
+		hubHeight: gauge("rollapp", "hub_height",
+			"Latest rollapp height submitted to the hub"),
+		heightLag: gauge("rollapp", "hub_height_lag",
+			"Number of rollapp blocks not submitted to the hub yet"),
+	}
+
+	e.registry.MustRegister(
+		e.collectorUp, e.lastCollect, e.keyBalance, e.sequencerBond, e.relayerChannel,
+		e.eibcFulfillers, e.eibcRollapps, e.eibcOrdersPerTx, e.daUp, e.stateNodeScore,
+		e.stateNodeCurrent, e.stateNodeSwitches, e.lastSwitch, e.rollappHeight,
+		e.hubHeight, e.heightLag,
+	)
+
+	return e
+}
+
+func (e *Exporter) Handler() http.Handler {
+	return promhttp.HandlerFor(e.registry, promhttp.HandlerOpts{})
+}
+
+// Run collects the metrics every interval until ctx is done
+func (e *Exporter) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		e.Collect()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Collect runs every collector once, failing collectors are reported through
+// the collector_up metric
+func (e *Exporter) Collect() {
+	rollerData, err := roller.LoadConfig(e.home)
+	if err != nil {
+		e.logger.Printf("[exporter] failed to load roller config: %v", err)
+		e.collectorUp.WithLabelValues("config").Set(0)
+		return
+	}
+	e.collectorUp.WithLabelValues("config").Set(1)
+
+	collectors := []struct {
+		name string
+		fn   func(roller.RollappConfig) error
+	}{
+		{"balances", e.collectBalances},
+		{"sequencer_bond", e.collectSequencerBond},
+		{"relayer", e.collectRelayer},
+		{"eibc", e.collectEibc},
+		{"da", e.collectDA},
+		{"heights", e.collectHeights},
+	}
+
+	for _, c := range collectors {
+		if err := c.fn(rollerData); err != nil {
+			e.logger.Printf("[exporter] %s: %v", c.name, err)
+			e.collectorUp.WithLabelValues(c.name).Set(0)
+			continue
+		}
+		e.collectorUp.WithLabelValues(c.name).Set(1)
+	}
+
+	e.lastCollect.SetToCurrentTime()
+}
+
+func (e *Exporter) collectBalances(rollerData roller.RollappConfig) error {
+	var errs []error
+	balances := map[string]keys.AccountData{}
+
+	if rollerData.NodeType == consts.NodeType.Sequencer && rollerData.HubData.ID != consts.MockHubID {
+		data, err := sequencerutils.GetSequencerData(rollerData)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("sequencer: %w", err))
+		} else if len(data) > 0 {
+			balances[keySequencer] = data[0]
+		}
+
+		damanager, err := datalayer.NewDAManager(
+			rollerData.DA.Backend,
+			rollerData.Home,
+			rollerData.KeyringBackend,
+			rollerData.NodeType,
+		)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("da: %w", err))
+		} else if data, err := damanager.GetDAAccData(rollerData); err != nil {
+			errs = append(errs, fmt.Errorf("da: %w", err))
+		} else if len(data) > 0 {
+			balances[keyDA] = data[0]
+		}
+	}
+
+	if rlyCfg, ok := loadRelayerConfig(rollerData.Home); ok {
+		if hd, err := rlyCfg.HubDataFromRelayerConfig(); err != nil {
+			errs = append(errs, fmt.Errorf("relayer: %w", err))
+		} else {
+			kc := keys.KeyConfig{
+				ChainBinary:    consts.Executables.Dymension,
+				ID:             consts.KeysIds.HubRelayer,
+				Dir:            filepath.Join(consts.ConfigDirName.Relayer, "keys", hd.ID),
+				KeyringBackend: consts.SupportedKeyringBackends.Test,
+			}
+			data, err := keys.GetRelayerData(rollerData.Home, kc, *hd)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("relayer: %w", err))
+			} else if len(data) > 0 {
+				balances[keyRelayer] = data[0]
+			}
+		}
+	}
+
+	if eibcCfg, userHome, ok := loadEibcConfig(); ok {
+		addr, err := eibcutils.GetKeyConfig().Address(userHome)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("eibc operator: %w", err))
+		} else {
+			balance, err := keys.QueryBalance(keys.ChainQueryConfig{
+				Binary: consts.Executables.Dymension,
+				Denom:  consts.Denoms.Hub,
+				RPC:    eibcCfg.NodeAddress,
+			}, addr)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("eibc operator: %w", err))
+			} else {
+				balances[keyEibcOperator] = keys.AccountData{Address: addr, Balance: *balance}
+			}
+		}
+	}
+
+	e.keyBalance.Reset()
+	for key, data := range balances {
+		e.keyBalance.WithLabelValues(key, data.Address, data.Balance.Denom).
+			Set(coinValue(data.Balance))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (e *Exporter) collectSequencerBond(rollerData roller.RollappConfig) error {
+	if rollerData.NodeType != consts.NodeType.Sequencer || rollerData.HubData.ID == consts.MockHubID {
+		return nil
+	}
+
+	addr, err := sequencerutils.GetHubSequencerAddress(rollerData)
+	if err != nil {
+		return err
+	}
+
+	bond, err := sequencerutils.GetSequencerBond(addr, rollerData.HubData)
+	if err != nil {
+		return err
+	}
+
+	e.sequencerBond.Reset()
+	for _, c := range *bond {
+		e.sequencerBond.WithLabelValues(c.Denom).Set(coinValue(c))
+	}
+	return nil
+}
+
+func (e *Exporter) collectRelayer(rollerData roller.RollappConfig) error {
+	rlyCfg, ok := loadRelayerConfig(rollerData.Home)
+	if !ok {
+		return nil
+	}
+
+	rly, err := rlyCfg.NewRelayerForPath(rollerData.Home, consts.DefaultRelayerPath)
+	if err != nil {
+		return err
+	}
+	channels, err := rly.QueryRollappChannels(rly.Rollapp)
+	if err != nil {
+		return err
+	}
+
+	e.relayerChannel.Reset()
+	for _, c := range channels {
+		open := 0.0
+		if c.State == "STATE_OPEN" {
+			open = 1
+		}
+		e.relayerChannel.WithLabelValues(c.ChannelID, c.Counterparty.ChannelID, c.State).Set(open)
+	}
+	return nil
+}
+
+func (e *Exporter) collectEibc(roller.RollappConfig) error {
+	cfg, _, ok := loadEibcConfig()
+	if !ok {
+		return nil
+	}
+
+	e.eibcFulfillers.Set(float64(cfg.Fulfillers.Scale))
+	e.eibcRollapps.Set(float64(len(cfg.Rollapps)))
+	e.eibcOrdersPerTx.Set(float64(cfg.Fulfillers.MaxOrdersPerTx))
+	return nil
+}
+
+func (e *Exporter) collectDA(rollerData roller.RollappConfig) error {
+	var errs []error
+
+	if rollerData.HubData.ID != consts.MockHubID && rollerData.DA.Backend != consts.Local {
+		damanager, err := datalayer.NewDAManager(
+			rollerData.DA.Backend,
+			rollerData.Home,
+			rollerData.KeyringBackend,
+			rollerData.NodeType,
+		)
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			up := 0.0
+			if damanager.IsHealthy(rollerData) {
+				up = 1
+			}
+			e.daUp.Set(up)
+		}
+	}
+
+	scores, err := statenode.LoadScores(rollerData.Home)
+	if err != nil {
+		errs = append(errs, err)
+	} else {
+		e.stateNodeScore.Reset()
+		for node, s := range scores {
+			e.stateNodeScore.WithLabelValues(node).Set(s.Score)
+		}
+	}
+
+	e.stateNodeCurrent.Reset()
+	if rollerData.DA.CurrentStateNode != "" {
+		e.stateNodeCurrent.WithLabelValues(rollerData.DA.CurrentStateNode).Set(1)
+	}
+
+	events, err := statenode.LoadHistory(rollerData.Home)
+	if err != nil {
+		errs = append(errs, err)
+	} else {
+		e.stateNodeSwitches.Set(float64(len(events)))
+		if len(events) > 0 {
+			e.lastSwitch.Set(float64(events[len(events)-1].Time.Unix()))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (e *Exporter) collectHeights(rollerData roller.RollappConfig) error {
+	raHeight, err := localRollappHeight()
+	if err != nil {
+		return fmt.Errorf("rollapp height: %w", err)
+	}
+	e.rollappHeight.Set(float64(raHeight))
+
+	if rollerData.HubData.ID == consts.MockHubID {
+		return nil
+	}
+
+	seq := &sequencer.Sequencer{RlpCfg: rollerData}
+	h, err := seq.GetHubHeight()
+	if err != nil {
+		return fmt.Errorf("hub height: %w", err)
+	}
+	hubHeight, err := strconv.ParseInt(h, 10, 64)
+	if err != nil {
+		return fmt.Errorf("hub height: %w", err)
+	}
+
+	e.hubHeight.Set(float64(hubHeight))
+	e.heightLag.Set(float64(max(raHeight-hubHeight, 0)))
+	return nil
+}
+
+func localRollappHeight() (int64, error) {
+	client := http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Get(roller.LocalRollappRPC() + "/status")
+	if err != nil {
+		return 0, err
+	}
+	// nolint: errcheck
+	defer resp.Body.Close()
+
+	var status sequencer.Response
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseInt(status.Result.SyncInfo.LatestBlockHeight, 10, 64)
+}
+
+func loadRelayerConfig(home string) (*relayer.Config, bool) {
+	path := relayer.GetConfigFilePath(relayer.GetHomeDir(home))
+	if _, err := os.Stat(path); err != nil {
+		return nil, false
+	}
+
+	var cfg relayer.Config
+	if err := cfg.Load(path); err != nil {
+		return nil, false
+	}
+	return &cfg, true
+}
+
+// loadEibcConfig loads the config of the eibc client, which lives in the user
+// home rather than in the roller home
+func loadEibcConfig() (*eibcutils.Config, string, bool) {
+	userHome, err := os.UserHomeDir()
+	if err != nil {
+		return nil, "", false
+	}
+
+	path := filepath.Join(userHome, consts.ConfigDirName.Eibc, "config.yaml")
+	if _, err := os.Stat(path); err != nil {
+		return nil, "", false
+	}
+
+	cfg, err := eibcutils.ReadConfig(path)
+	if err != nil {
+		return nil, "", false
+	}
+	return cfg, userHome, true
+}
+
+func coinValue(c cosmossdktypes.Coin) float64 {
+	if c.Amount.IsNil() {
+		return 0
+	}
+	v, _ := new(big.Float).SetInt(c.Amount.BigInt()).Float64()
+	return v
+}
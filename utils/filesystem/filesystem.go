@@ -10,6 +10,7 @@ import (
 	"os/exec"
 	"os/user"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/nxadm/tail"
@@ -17,6 +18,7 @@ import (
 
 	"github.com/dymensionxyz/roller/cmd/consts"
 	"github.com/dymensionxyz/roller/config"
+	"github.com/dymensionxyz/roller/utils/plan"
 )
 
 func DirNotEmpty(path string) (bool, error) {
@@ -36,7 +38,14 @@ func DirNotEmpty(path string) (bool, error) {
 	return len(files) > 0, err
 }
 
-func CreateRollerRootWithOptionalOverride(home string, forceOverwrite bool) error {
+// CreateRollerRootWithOptionalOverride creates the roller home. Overwriting an
+// existing home keeps the rollapp profiles it holds and removes the given
+// service files
+func CreateRollerRootWithOptionalOverride(
+	home string,
+	forceOverwrite bool,
+	services []string,
+) error {
 	isRootExist, err := DirNotEmpty(home)
 	if err != nil {
 		return err
@@ -56,23 +65,23 @@ func CreateRollerRootWithOptionalOverride(home string, forceOverwrite bool) erro
 		}
 
 		if shouldOverwrite || forceOverwrite {
-			err = os.RemoveAll(home)
+			err = removeDirContents(
+				home,
+				consts.ProfilesDirName,
+				consts.ContextsFileName,
+				consts.BackupsDirName,
+			)
 			if err != nil {
 				return err
 			}
 
-			err = RemoveServiceFiles(consts.RollappSystemdServices)
-			if err != nil {
-				return err
-			}
-
-			err = os.MkdirAll(home, 0o755)
+			err = RemoveServiceFiles(services)
 			if err != nil {
 				return err
 			}
 		} else {
 			pterm.Info.Println("cancelled by user")
-			os.Exit(0)
+			plan.Exit(0)
 		}
 	} else {
 		err = os.MkdirAll(home, 0o755)
@@ -247,6 +256,25 @@ func TailFile(fp, svcName string, lineNumber int) error {
 	return nil
 }
 
+// removeDirContents removes everything in dir except the entries named in keep
+func removeDirContents(dir string, keep ...string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		if slices.Contains(keep, e.Name()) {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(dir, e.Name())); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func DoesFileExist(path string) (bool, error) {
 	_, err := os.Stat(path)
 
@@ -0,0 +1,50 @@
+package firebase
+
+import (
+	"context"
+	"fmt"
+
+	firebase "firebase.google.com/go"
+	"google.golang.org/api/option"
+)
+
+// OracleBinaryVersionInfo represents the version information for the oracle clients
+type OracleBinaryVersionInfo struct {
+	PriceEvmOracle      string `firestore:"price-evm"`
+	PriceWasmOracle     string `firestore:"price-wasm"`
+	RngEvmOracle        string `firestore:"rng-evm"`
+	RngEvmRandomService string `firestore:"rng-evm-random-service"`
+}
+
+// GetOracleBinaryVersions
+// Fetch the oracle client versions
+// Path format: tool-versions/oracle-client-binaries
+func GetOracleBinaryVersions() (*OracleBinaryVersionInfo, error) {
+	ctx := context.Background()
+	conf := &firebase.Config{ProjectID: "drs-metadata"}
+	app, err := firebase.NewApp(ctx, conf, option.WithoutAuthentication())
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize firebase app: %v", err)
+	}
+
+	client, err := app.Firestore(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create firestore client: %v", err)
+	}
+	defer client.Close()
+
+	drsDoc := client.Collection("tool-versions").
+		Doc("oracle-client-binaries")
+
+	docSnapshot, err := drsDoc.Get(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get oracle version info: %v", err)
+	}
+
+	var obvi OracleBinaryVersionInfo
+	if err := docSnapshot.DataTo(&obvi); err != nil {
+		return nil, fmt.Errorf("failed to parse oracle version info: %v", err)
+	}
+
+	return &obvi, nil
+}
@@ -0,0 +1,121 @@
+package funding
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/dymensionxyz/roller/utils/notify"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+const (
+	DefaultInterval    = time.Hour
+	DefaultAlertRunway = 72 * time.Hour
+)
+
+// Start forecasts the runway of the wallets every interval, raises a balance
+// alert when a runway drops below the alert runway and tops up the wallets
+// when auto top up is enabled
+func Start(home string, cfg roller.FundingConfig, l *log.Logger) {
+	interval := DefaultInterval
+	if d, err := time.ParseDuration(cfg.Interval); err == nil && d > 0 {
+		interval = d
+	}
+	alertRunway := DefaultAlertRunway
+	if d, err := time.ParseDuration(cfg.AlertRunway); err == nil && d > 0 {
+		alertRunway = d
+	}
+
+	notifier, err := notify.Load(home)
+	if err != nil {
+		l.Println("failed to load notification channels, balance alerts are only logged: ", err)
+	}
+
+	alerted := map[string]bool{}
+	for {
+		check(home, alertRunway, alerted, notifier, l)
+		time.Sleep(interval)
+	}
+}
+
+func check(home string, alertRunway time.Duration, alerted map[string]bool, notifier *notify.Dispatcher, l *log.Logger) {
+	rollerData, err := roller.LoadConfig(home)
+	if err != nil {
+		l.Println("funding: failed to load roller config: ", err)
+		return
+	}
+
+	wallets := CollectWallets(home, rollerData)
+	Forecast(home, wallets)
+
+	for _, w := range wallets {
+		if w.Error != "" {
+			l.Printf("funding: failed to query the %s wallet: %s", w.Name, w.Error)
+			continue
+		}
+
+		low := w.Forecast.Known && w.Forecast.Runway < alertRunway
+		switch {
+		case low && !alerted[w.Name]:
+			emit(notifier, l, notify.Event{
+				Source:   notify.SourceBalance,
+				Check:    "runway/" + w.Name,
+				Severity: notify.SeverityWarning,
+				Title:    fmt.Sprintf("%s wallet runs out of funds in %s", w.Name, w.Forecast),
+				Message: fmt.Sprintf(
+					"%s has %s%s and spends %.0f%s per day",
+					w.Address, w.Balance, w.Denom, w.Forecast.BurnPerDay, w.Denom,
+				),
+			})
+		case !low && alerted[w.Name]:
+			emit(notifier, l, notify.Event{
+				Source:   notify.SourceBalance,
+				Check:    "runway/" + w.Name,
+				Severity: notify.SeverityInfo,
+				Title:    fmt.Sprintf("%s wallet is funded for %s", w.Name, w.Forecast),
+				Resolved: true,
+			})
+		}
+		alerted[w.Name] = low
+	}
+
+	if !rollerData.Funding.AutoTopUp {
+		return
+	}
+
+	topUps, err := PlanTopUps(home, rollerData.Funding, wallets)
+	if err != nil {
+		l.Println("funding: failed to plan top ups: ", err)
+		return
+	}
+
+	for _, t := range ExecuteTopUps(home, rollerData, topUps) {
+		e := notify.Event{
+			Source:  notify.SourceBalance,
+			Check:   "topup/" + t.Wallet,
+			Message: t.Reason,
+			Fields: map[string]string{
+				"address": t.Address,
+				"amount":  t.Amount.String() + t.Denom,
+			},
+		}
+		if t.Error != "" {
+			l.Printf("funding: failed to top up the %s wallet: %s", t.Wallet, t.Error)
+			e.Severity = notify.SeverityCritical
+			e.Title = fmt.Sprintf("failed to top up the %s wallet: %s", t.Wallet, t.Error)
+		} else {
+			l.Printf("funding: topped up the %s wallet with %s%s: %s", t.Wallet, t.Amount, t.Denom, t.TxHash)
+			e.Severity = notify.SeverityInfo
+			e.Title = fmt.Sprintf("topped up the %s wallet", t.Wallet)
+			e.Fields["tx_hash"] = t.TxHash
+		}
+		emit(notifier, l, e)
+	}
+}
+
+func emit(notifier *notify.Dispatcher, l *log.Logger, e notify.Event) {
+	if err := notifier.Emit(e); err != nil {
+		l.Println("funding: failed to send notification: ", err)
+	}
+}
@@ -0,0 +1,363 @@
+package funding
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	cosmossdktypes "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/bash"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+const (
+	ledgerFileName = "top_ups.json"
+	budgetWindow   = 24 * time.Hour
+	// ledgerRetention is how long past top ups are kept in the ledger
+	ledgerRetention    = 30 * 24 * time.Hour
+	defaultDAGasPrices = "0.002utia"
+)
+
+// TopUp is a transfer from a treasury to a wallet, planned or executed
+type TopUp struct {
+	Time    time.Time `json:"time" yaml:"time"`
+	Wallet  string    `json:"wallet" yaml:"wallet"`
+	Chain   string    `json:"chain" yaml:"chain"`
+	Address string    `json:"address" yaml:"address"`
+	Denom   string    `json:"denom" yaml:"denom"`
+	Amount  *big.Int  `json:"amount" yaml:"amount"`
+	Reason  string    `json:"reason" yaml:"reason"`
+	TxHash  string    `json:"tx_hash,omitempty" yaml:"tx_hash,omitempty"`
+	Error   string    `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// PlanTopUps returns the top ups of the wallets that crossed their minimum
+// balance or runway, capped per wallet and by the daily budget of their
+// treasury. Without a daily budget, a wallet is topped up at most once per
+// budget window. Top ups that can't be made are returned with an error
+func PlanTopUps(home string, cfg roller.FundingConfig, wallets []WalletRunway) ([]TopUp, error) {
+	ledger, err := LoadLedger(home)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	spent := map[string]*big.Int{}
+	toppedUp := map[string]bool{}
+	for _, t := range ledger {
+		if t.Error != "" || now.Sub(t.Time) > budgetWindow {
+			continue
+		}
+		toppedUp[t.Wallet] = true
+		k := t.Chain + "/" + t.Denom
+		if spent[k] == nil {
+			spent[k] = big.NewInt(0)
+		}
+		spent[k].Add(spent[k], t.Amount)
+	}
+
+	var topUps []TopUp
+	for _, wc := range cfg.Wallets {
+		for _, w := range wallets {
+			if w.Name != wc.Name || w.Error != "" || w.Balance == nil {
+				continue
+			}
+
+			t, err := planTopUp(wc, w)
+			if err != nil {
+				return nil, fmt.Errorf("wallet %s: %w", wc.Name, err)
+			}
+			if t == nil {
+				continue
+			}
+			t.Time = now
+
+			treasury := findTreasury(cfg, w.Chain)
+			if treasury == nil {
+				t.Error = fmt.Sprintf("no treasury is configured for the %s chain", w.Chain)
+				topUps = append(topUps, *t)
+				continue
+			}
+
+			if treasury.DailyBudget == "" {
+				if toppedUp[w.Name] {
+					t.Error = fmt.Sprintf(
+						"the %s wallet was topped up in the last %s, set a daily_budget on the %s treasury to allow more",
+						w.Name, budgetWindow, w.Chain,
+					)
+				}
+				topUps = append(topUps, *t)
+				continue
+			}
+
+			budget, err := parseAmount(treasury.DailyBudget, w.Denom)
+			if err != nil {
+				return nil, fmt.Errorf("treasury %s: daily_budget: %w", treasury.Chain, err)
+			}
+
+			k := w.Chain + "/" + w.Denom
+			if spent[k] == nil {
+				spent[k] = big.NewInt(0)
+			}
+			left := new(big.Int).Sub(budget, spent[k])
+			if left.Sign() <= 0 {
+				t.Error = fmt.Sprintf("the daily budget of the %s treasury is exhausted", w.Chain)
+				topUps = append(topUps, *t)
+				continue
+			}
+			if t.Amount.Cmp(left) > 0 {
+				t.Amount = left
+				t.Reason += ", reduced to the daily budget left"
+			}
+			spent[k].Add(spent[k], t.Amount)
+
+			topUps = append(topUps, *t)
+		}
+	}
+
+	return topUps, nil
+}
+
+func planTopUp(wc roller.FundingWalletConfig, w WalletRunway) (*TopUp, error) {
+	minBalance, err := parseAmount(wc.MinBalance, w.Denom)
+	if err != nil {
+		return nil, fmt.Errorf("min_balance: %w", err)
+	}
+	targetBalance, err := parseAmount(wc.TargetBalance, w.Denom)
+	if err != nil {
+		return nil, fmt.Errorf("target_balance: %w", err)
+	}
+	maxTopUp, err := parseAmount(wc.MaxTopUp, w.Denom)
+	if err != nil {
+		return nil, fmt.Errorf("max_top_up: %w", err)
+	}
+	minRunway, err := parseDuration(wc.MinRunway)
+	if err != nil {
+		return nil, fmt.Errorf("min_runway: %w", err)
+	}
+	targetRunway, err := parseDuration(wc.TargetRunway)
+	if err != nil {
+		return nil, fmt.Errorf("target_runway: %w", err)
+	}
+
+	var reason string
+	switch {
+	case minBalance.Sign() > 0 && w.Balance.Cmp(minBalance) < 0:
+		reason = fmt.Sprintf("balance below %s%s", minBalance, w.Denom)
+	case minRunway > 0 && w.Forecast.Known && w.Forecast.Runway < minRunway:
+		reason = fmt.Sprintf("runway of %s below %s", w.Forecast, minRunway)
+	default:
+		return nil, nil
+	}
+
+	target := new(big.Int).Set(targetBalance)
+	if targetRunway > 0 && w.Forecast.Known {
+		need, _ := big.NewFloat(w.Forecast.BurnPerDay * targetRunway.Hours() / 24).Int(nil)
+		if need.Cmp(target) > 0 {
+			target = need
+		}
+	}
+	if target.Sign() == 0 {
+		// without a target, top up to twice the minimum balance
+		target.Mul(minBalance, big.NewInt(2))
+	}
+
+	amount := new(big.Int).Sub(target, w.Balance)
+	if amount.Sign() <= 0 {
+		return nil, nil
+	}
+	if maxTopUp.Sign() > 0 && amount.Cmp(maxTopUp) > 0 {
+		amount = maxTopUp
+		reason += ", capped by max_top_up"
+	}
+
+	return &TopUp{
+		Wallet:  w.Name,
+		Chain:   w.Chain,
+		Address: w.Address,
+		Denom:   w.Denom,
+		Amount:  amount,
+		Reason:  reason,
+	}, nil
+}
+
+// ExecuteTopUps sends the planned top ups that have no error and records them
+// in the ledger of the daily budget
+func ExecuteTopUps(home string, rollerData roller.RollappConfig, topUps []TopUp) []TopUp {
+	for i := range topUps {
+		t := &topUps[i]
+		if t.Error != "" {
+			continue
+		}
+
+		treasury := findTreasury(rollerData.Funding, t.Chain)
+		txHash, err := send(home, rollerData, *treasury, *t)
+		t.TxHash = txHash
+		if err != nil {
+			t.Error = err.Error()
+		}
+
+		if err := appendLedger(home, *t); err != nil && t.Error == "" {
+			t.Error = fmt.Sprintf("sent, but failed to record the top up: %v", err)
+		}
+	}
+	return topUps
+}
+
+func send(home string, rollerData roller.RollappConfig, treasury roller.FundingTreasuryConfig, t TopUp) (string, error) {
+	keyringBackend := treasury.KeyringBackend
+	if keyringBackend == "" {
+		keyringBackend = string(consts.SupportedKeyringBackends.Test)
+	}
+	keyringDir := filepath.Join(home, "treasury")
+	if treasury.KeyringDir != "" {
+		dir, err := filesystem.ExpandHomePath(treasury.KeyringDir)
+		if err != nil {
+			return "", err
+		}
+		keyringDir = dir
+	}
+
+	var binary string
+	args := []string{
+		"tx", "bank", "send", treasury.Key, t.Address, t.Amount.String() + t.Denom,
+		"--keyring-backend", keyringBackend,
+		"--keyring-dir", keyringDir,
+		"-o", "json", "-y",
+	}
+
+	switch t.Chain {
+	case ChainHub:
+		binary = consts.Executables.Dymension
+		args = append(args,
+			"--node", rollerData.HubData.RpcUrl,
+			"--chain-id", rollerData.HubData.ID,
+			"--fees", fmt.Sprintf("%d%s", consts.DefaultTxFee, consts.Denoms.Hub),
+		)
+	case ChainRollapp:
+		binary = rollerData.RollappBinary
+		args = append(args,
+			"--node", roller.LocalRollappRPC(),
+			"--chain-id", rollerData.RollappID,
+			"--gas", "auto", "--gas-adjustment", "1.5",
+			"--gas-prices", rollerData.MinGasPrices,
+		)
+	case ChainDA:
+		if rollerData.DA.Backend != consts.Celestia {
+			return "", fmt.Errorf("top ups are not supported for the %s DA", rollerData.DA.Backend)
+		}
+		gasPrices := rollerData.DA.GasPrice
+		if gasPrices == "" {
+			gasPrices = defaultDAGasPrices
+		}
+		binary = consts.Executables.CelestiaApp
+		args = append(args,
+			"--node", rollerData.DA.RpcUrl,
+			"--chain-id", string(rollerData.DA.ID),
+			"--gas", "auto", "--gas-adjustment", "1.5",
+			"--gas-prices", gasPrices,
+		)
+	default:
+		return "", fmt.Errorf("unknown chain %s", t.Chain)
+	}
+
+	out, err := bash.ExecCommandWithStdout(exec.Command(binary, args...))
+	if err != nil {
+		return "", err
+	}
+
+	var resp struct {
+		TxHash string `json:"txhash"`
+		Code   int    `json:"code"`
+		RawLog string `json:"raw_log"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &resp); err != nil {
+		return "", fmt.Errorf("failed to parse the transaction response: %w", err)
+	}
+	if resp.Code != 0 {
+		return resp.TxHash, fmt.Errorf("transaction failed with code %d: %s", resp.Code, resp.RawLog)
+	}
+	return resp.TxHash, nil
+}
+
+func findTreasury(cfg roller.FundingConfig, chain string) *roller.FundingTreasuryConfig {
+	for i, t := range cfg.Treasuries {
+		if t.Chain == chain && t.Key != "" {
+			return &cfg.Treasuries[i]
+		}
+	}
+	return nil
+}
+
+// LoadLedger returns the recorded top ups
+func LoadLedger(home string) ([]TopUp, error) {
+	b, err := os.ReadFile(filepath.Join(home, samplesDir, ledgerFileName))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var ledger []TopUp
+	if err := json.Unmarshal(b, &ledger); err != nil {
+		return nil, fmt.Errorf("failed to parse top up ledger: %w", err)
+	}
+	return ledger, nil
+}
+
+func appendLedger(home string, t TopUp) error {
+	ledger, err := LoadLedger(home)
+	if err != nil {
+		return err
+	}
+
+	kept := ledger[:0]
+	for _, l := range ledger {
+		if time.Since(l.Time) < ledgerRetention {
+			kept = append(kept, l)
+		}
+	}
+	kept = append(kept, t)
+
+	b, err := json.MarshalIndent(kept, "", "  ")
+	if err != nil {
+		return err
+	}
+	// nolint:gofumpt
+	if err := os.MkdirAll(filepath.Join(home, samplesDir), 0o755); err != nil {
+		return err
+	}
+	// nolint:gofumpt
+	return os.WriteFile(filepath.Join(home, samplesDir, ledgerFileName), b, 0o644)
+}
+
+// parseAmount parses a coin in the denom of the wallet, zero when empty
+func parseAmount(s, denom string) (*big.Int, error) {
+	if s == "" {
+		return big.NewInt(0), nil
+	}
+	c, err := cosmossdktypes.ParseCoinNormalized(s)
+	if err != nil {
+		return nil, err
+	}
+	if c.Denom != denom {
+		return nil, fmt.Errorf("expected an amount in %s, got %s", denom, c.Denom)
+	}
+	return c.Amount.BigInt(), nil
+}
+
+func parseDuration(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(s)
+}
@@ -0,0 +1,174 @@
+// Package funding forecasts the runway of the operational wallets from their
+// balance history and tops them up from treasury keys
+package funding
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	datalayer "github.com/dymensionxyz/roller/data_layer"
+	eibcutils "github.com/dymensionxyz/roller/utils/eibc"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/keys"
+	"github.com/dymensionxyz/roller/utils/plan"
+	"github.com/dymensionxyz/roller/utils/roller"
+	"github.com/dymensionxyz/roller/utils/runway"
+	sequencerutils "github.com/dymensionxyz/roller/utils/sequencer"
+)
+
+// Wallet names
+const (
+	WalletSequencer      = "sequencer"
+	WalletRelayerHub     = "relayer-hub"
+	WalletRelayerRollapp = "relayer-rollapp"
+	WalletDA             = "da"
+	WalletEibc           = "eibc"
+)
+
+// Chains the wallets live on, each chain is funded by its own treasury
+const (
+	ChainHub     = "hub"
+	ChainRollapp = "rollapp"
+	ChainDA      = "da"
+)
+
+const samplesDir = "runway"
+
+// Wallet is an operational wallet and its current balance in base denom
+type Wallet struct {
+	Name    string   `json:"name" yaml:"name"`
+	Chain   string   `json:"chain" yaml:"chain"`
+	Address string   `json:"address" yaml:"address"`
+	Denom   string   `json:"denom" yaml:"denom"`
+	Balance *big.Int `json:"balance" yaml:"balance"`
+}
+
+// WalletRunway is a wallet along with the forecast of its balance
+type WalletRunway struct {
+	Wallet   `yaml:",inline"`
+	Forecast runway.Forecast `json:"forecast" yaml:"forecast"`
+	Error    string          `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// CollectWallets queries the balance of the wallets set up on this machine.
+// Wallets that are not set up are skipped, wallets that fail to be queried
+// are returned with their error
+func CollectWallets(home string, rollerData roller.RollappConfig) []WalletRunway {
+	var wallets []WalletRunway
+
+	add := func(name, chain string, data []keys.AccountData, err error) {
+		if err != nil {
+			wallets = append(wallets, WalletRunway{Wallet: Wallet{Name: name, Chain: chain}, Error: err.Error()})
+			return
+		}
+		for _, d := range data {
+			wallets = append(wallets, WalletRunway{Wallet: Wallet{
+				Name:    name,
+				Chain:   chain,
+				Address: d.Address,
+				Denom:   d.Balance.Denom,
+				Balance: d.Balance.Amount.BigInt(),
+			}})
+		}
+	}
+
+	if rollerData.NodeType == consts.NodeType.Sequencer {
+		data, err := sequencerutils.GetSequencerData(rollerData)
+		add(WalletSequencer, ChainHub, data, err)
+
+		if rollerData.DA.Backend != consts.Local && rollerData.DA.Backend != "" {
+			dm, err := datalayer.NewDAManager(rollerData.DA.Backend, home, rollerData.KeyringBackend, rollerData.NodeType)
+			if err != nil {
+				add(WalletDA, ChainDA, nil, err)
+			} else {
+				data, err := dm.GetDAAccData(rollerData)
+				add(WalletDA, ChainDA, data, err)
+			}
+		}
+	}
+
+	if ok, _ := filesystem.DirNotEmpty(filepath.Join(home, consts.ConfigDirName.Relayer)); ok {
+		data, err := accountData(home, rollerData.HubData.ID, keys.ChainQueryConfig{
+			Binary: consts.Executables.Dymension,
+			Denom:  consts.Denoms.Hub,
+			RPC:    rollerData.HubData.RpcUrl,
+		})
+		add(WalletRelayerHub, ChainHub, data, err)
+
+		data, err = accountData(home, rollerData.RollappID, keys.ChainQueryConfig{
+			Binary: rollerData.RollappBinary,
+			Denom:  rollerData.BaseDenom,
+			RPC:    roller.LocalRollappRPC(),
+		})
+		add(WalletRelayerRollapp, ChainRollapp, data, err)
+	}
+
+	userHome, _ := os.UserHomeDir()
+	if ok, _ := filesystem.DirNotEmpty(filepath.Join(userHome, consts.ConfigDirName.Eibc)); ok {
+		addr, err := eibcutils.GetKeyConfig().Address(userHome)
+		if err != nil {
+			add(WalletEibc, ChainHub, nil, err)
+		} else {
+			balance, err := keys.QueryBalance(keys.ChainQueryConfig{
+				Binary: consts.Executables.Dymension,
+				Denom:  consts.Denoms.Hub,
+				RPC:    rollerData.HubData.RpcUrl,
+			}, addr)
+			if err != nil {
+				add(WalletEibc, ChainHub, nil, err)
+			} else {
+				add(WalletEibc, ChainHub, []keys.AccountData{{Address: addr, Balance: *balance}}, nil)
+			}
+		}
+	}
+
+	return wallets
+}
+
+func accountData(home, chainID string, cfg keys.ChainQueryConfig) ([]keys.AccountData, error) {
+	addr, err := keys.GetRelayerAddress(home, chainID)
+	if err != nil {
+		return nil, err
+	}
+	balance, err := keys.QueryBalance(cfg, addr)
+	if err != nil {
+		return nil, err
+	}
+	return []keys.AccountData{{Address: addr, Balance: *balance}}, nil
+}
+
+// Forecast records the balance of every wallet and forecasts its runway, the
+// balance is not recorded in dry-run mode
+func Forecast(home string, wallets []WalletRunway) {
+	record := runway.Record
+	if plan.Enabled() {
+		record = runway.Preview
+	}
+
+	for i := range wallets {
+		w := &wallets[i]
+		if w.Error != "" || w.Balance == nil {
+			continue
+		}
+
+		amount, _ := new(big.Float).SetInt(w.Balance).Float64()
+		samples, err := record(SamplesPath(home, w.Name, w.Address), amount)
+		if err != nil {
+			w.Error = fmt.Sprintf("failed to record balance: %v", err)
+			continue
+		}
+		w.Forecast = runway.Estimate(samples)
+	}
+}
+
+// SamplesPath is the balance history of a wallet. The hub relayer history is
+// shared with the relayer status
+func SamplesPath(home, name, address string) string {
+	if name == WalletRelayerHub {
+		return filepath.Join(home, consts.ConfigDirName.Relayer, "balance_samples.json")
+	}
+	return filepath.Join(home, samplesDir, fmt.Sprintf("%s_%s.json", name, address))
+}
@@ -0,0 +1,398 @@
+package healthagent
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/sequencer"
+	"github.com/dymensionxyz/roller/utils/roller"
+	sequencerutils "github.com/dymensionxyz/roller/utils/sequencer"
+)
+
+type CheckType string
+
+const (
+	CheckTypeHTTP        CheckType = "http"
+	CheckTypeMetric      CheckType = "metric"
+	CheckTypeBlockHeight CheckType = "block_height"
+	CheckTypeProcess     CheckType = "process"
+	CheckTypeBalance     CheckType = "balance"
+)
+
+type Remediation string
+
+const (
+	RemediationNone       Remediation = "none"
+	RemediationRestart    Remediation = "restart"
+	RemediationDAFailover Remediation = "da_failover"
+)
+
+const probeTimeout = 10 * time.Second
+
+func defaultRaMetricsEndpoint() string {
+	return roller.LocalEndpoint(consts.DefaultPorts.RollappMetrics) + "/metrics"
+}
+
+// Check is a single health probe. Run returns a non-nil error when the
+// probed component is considered unhealthy
+type Check interface {
+	Name() string
+	Run() error
+}
+
+type checkConstructor func(home string, cfg roller.HealthCheckConfig) (Check, error)
+
+var checkRegistry = map[CheckType]checkConstructor{
+	CheckTypeHTTP:        newHTTPCheck,
+	CheckTypeMetric:      newMetricCheck,
+	CheckTypeBlockHeight: newBlockHeightCheck,
+	CheckTypeProcess:     newProcessCheck,
+	CheckTypeBalance:     newBalanceCheck,
+}
+
+// NewCheck builds the check described by cfg using the check registry
+func NewCheck(home string, cfg roller.HealthCheckConfig) (Check, error) {
+	constructor, ok := checkRegistry[CheckType(cfg.Type)]
+	if !ok {
+		return nil, fmt.Errorf("unknown health check type: %s", cfg.Type)
+	}
+
+	return constructor(home, cfg)
+}
+
+// DefaultChecks returns the checks the health agent runs when no checks are
+// configured in roller.toml. The DA light client probe is only added for
+// celestia, the only DA backend that runs a local light client
+func DefaultChecks(rollerData roller.RollappConfig) []roller.HealthCheckConfig {
+	var checks []roller.HealthCheckConfig
+
+	if rollerData.DA.Backend == consts.Celestia {
+		checks = append(checks, roller.HealthCheckConfig{
+			Name:        "da-light-client",
+			Type:        string(CheckTypeHTTP),
+			Remediation: string(RemediationDAFailover),
+			Endpoint:    roller.LocalEndpoint(consts.DefaultPorts.DARPC),
+		})
+	}
+
+	checks = append(checks, roller.HealthCheckConfig{
+		Name:        "da-submissions",
+		Type:        string(CheckTypeMetric),
+		Remediation: string(RemediationDAFailover),
+		Endpoint:    defaultRaMetricsEndpoint(),
+		Metric:      "rollapp_consecutive_failed_da_submissions",
+		Operator:    "gt",
+		Threshold:   10,
+	})
+
+	return checks
+}
+
+type httpCheck struct {
+	name     string
+	endpoint string
+}
+
+func newHTTPCheck(_ string, cfg roller.HealthCheckConfig) (Check, error) {
+	if cfg.Endpoint == "" {
+		return nil, errors.New("http check requires an endpoint")
+	}
+
+	return &httpCheck{name: cfg.Name, endpoint: cfg.Endpoint}, nil
+}
+
+func (c *httpCheck) Name() string {
+	return c.name
+}
+
+func (c *httpCheck) Run() error {
+	client := http.Client{Timeout: probeTimeout}
+	resp, err := client.Get(c.endpoint)
+	if err != nil {
+		return fmt.Errorf("error making request: %v", err)
+	}
+	// nolint:errcheck
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("bad status: %s", resp.Status)
+	}
+
+	return nil
+}
+
+type metricCheck struct {
+	name      string
+	endpoint  string
+	metric    string
+	operator  string
+	threshold float64
+}
+
+func newMetricCheck(_ string, cfg roller.HealthCheckConfig) (Check, error) {
+	if cfg.Metric == "" {
+		return nil, errors.New("metric check requires a metric name")
+	}
+
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = defaultRaMetricsEndpoint()
+	}
+
+	operator := cfg.Operator
+	if operator == "" {
+		operator = "gt"
+	}
+	if _, err := compare(0, operator, 0); err != nil {
+		return nil, err
+	}
+
+	return &metricCheck{
+		name:      cfg.Name,
+		endpoint:  endpoint,
+		metric:    cfg.Metric,
+		operator:  operator,
+		threshold: cfg.Threshold,
+	}, nil
+}
+
+func (c *metricCheck) Name() string {
+	return c.name
+}
+
+// Run fails when the metric value matches the configured condition,
+// e.g. value gt threshold
+func (c *metricCheck) Run() error {
+	value, err := QueryPromMetricValue(c.endpoint, c.metric)
+	if err != nil {
+		return err
+	}
+
+	triggered, err := compare(value, c.operator, c.threshold)
+	if err != nil {
+		return err
+	}
+
+	if triggered {
+		return fmt.Errorf(
+			"%s is %v, which is %s %v",
+			c.metric,
+			value,
+			c.operator,
+			c.threshold,
+		)
+	}
+
+	return nil
+}
+
+func compare(value float64, operator string, threshold float64) (bool, error) {
+	switch operator {
+	case "gt":
+		return value > threshold, nil
+	case "gte":
+		return value >= threshold, nil
+	case "lt":
+		return value < threshold, nil
+	case "lte":
+		return value <= threshold, nil
+	case "eq":
+		return value == threshold, nil
+	case "ne":
+		return value != threshold, nil
+	default:
+		return false, fmt.Errorf(
+			"unknown operator %s, supported operators: gt, gte, lt, lte, eq, ne",
+			operator,
+		)
+	}
+}
+
+type blockHeightCheck struct {
+	name       string
+	endpoint   string
+	lastHeight int64
+}
+
+func newBlockHeightCheck(_ string, cfg roller.HealthCheckConfig) (Check, error) {
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = roller.LocalRollappRPC()
+	}
+
+	return &blockHeightCheck{name: cfg.Name, endpoint: strings.TrimSuffix(endpoint, "/")}, nil
+}
+
+func (c *blockHeightCheck) Name() string {
+	return c.name
+}
+
+// Run fails when the latest block height reported by the node did not
+// increase since the previous run
+func (c *blockHeightCheck) Run() error {
+	client := http.Client{Timeout: probeTimeout}
+	resp, err := client.Get(fmt.Sprintf("%s/status", c.endpoint))
+	if err != nil {
+		return fmt.Errorf("error making request: %v", err)
+	}
+	// nolint:errcheck
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("error reading response body: %v", err)
+	}
+
+	var response sequencer.Response
+	if err := json.Unmarshal(body, &response); err != nil {
+		return fmt.Errorf("error unmarshaling JSON: %v", err)
+	}
+
+	height, err := strconv.ParseInt(response.Result.SyncInfo.LatestBlockHeight, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid block height: %v", err)
+	}
+
+	previous := c.lastHeight
+	c.lastHeight = height
+	if previous != 0 && height <= previous {
+		return fmt.Errorf("block height is stuck at %d", height)
+	}
+
+	return nil
+}
+
+type processCheck struct {
+	name    string
+	process string
+}
+
+func newProcessCheck(_ string, cfg roller.HealthCheckConfig) (Check, error) {
+	if cfg.Process == "" {
+		return nil, errors.New("process check requires a process pattern")
+	}
+
+	return &processCheck{name: cfg.Name, process: cfg.Process}, nil
+}
+
+func (c *processCheck) Name() string {
+	return c.name
+}
+
+func (c *processCheck) Run() error {
+	// pgrep exits with a non-zero code when no process matches
+	// nolint:gosec
+	if err := exec.Command("pgrep", "-f", c.process).Run(); err != nil {
+		return fmt.Errorf("process %s is not running", c.process)
+	}
+
+	return nil
+}
+
+type balanceCheck struct {
+	name string
+	home string
+}
+
+func newBalanceCheck(home string, cfg roller.HealthCheckConfig) (Check, error) {
+	return &balanceCheck{name: cfg.Name, home: home}, nil
+}
+
+func (c *balanceCheck) Name() string {
+	return c.name
+}
+
+// Run fails when the sequencer address is below the balance it needs to
+// operate
+func (c *balanceCheck) Run() error {
+	rollerData, err := roller.LoadConfig(c.home)
+	if err != nil {
+		return fmt.Errorf("failed to load roller config: %v", err)
+	}
+	if rollerData.NodeType != consts.NodeType.Sequencer {
+		return nil
+	}
+
+	insufficient, err := sequencerutils.GetInsufficientBalances(rollerData)
+	if err != nil {
+		return fmt.Errorf("failed to query balances: %v", err)
+	}
+
+	var msgs []string
+	for _, b := range insufficient {
+		msgs = append(msgs, fmt.Sprintf(
+			"%s (%s) has %s%s, needs %s%s",
+			b.KeyName, b.Address, b.CurrentBalance, b.Denom, b.RequiredBalance, b.Denom,
+		))
+	}
+	if len(msgs) > 0 {
+		return errors.New(strings.Join(msgs, "; "))
+	}
+
+	return nil
+}
+
+// QueryPromMetricValue returns the value of the first sample of metric
+// exposed by the prometheus endpoint. Unlike QueryPromMetric, it matches the
+// metric name exactly, accepts labels and parses float values
+func QueryPromMetricValue(endpoint, metric string) (float64, error) {
+	client := http.Client{Timeout: probeTimeout}
+	resp, err := client.Get(endpoint)
+	if err != nil {
+		return 0, fmt.Errorf("error fetching metrics: %v", err)
+	}
+	// nolint: errcheck
+	defer resp.Body.Close()
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		name, rest, found := strings.Cut(line, " ")
+		if !found {
+			continue
+		}
+		if labelsStart := strings.Index(name, "{"); labelsStart >= 0 {
+			if !strings.HasSuffix(name, "}") {
+				// labels containing spaces
+				end := strings.LastIndex(line, "}")
+				if end < 0 {
+					continue
+				}
+				rest = line[end+1:]
+			}
+			name = name[:labelsStart]
+		}
+		if name != metric {
+			continue
+		}
+
+		fields := strings.Fields(rest)
+		if len(fields) == 0 {
+			return 0, fmt.Errorf("unexpected format for metric line: %s", line)
+		}
+		value, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			return 0, fmt.Errorf("error converting metric value to float: %v", err)
+		}
+		return value, nil
+	}
+
+	if err := scanner.Err(); err != nil {
+		return 0, fmt.Errorf("error reading metrics response: %v", err)
+	}
+
+	return 0, fmt.Errorf("metric not found: %s", metric)
+}
@@ -11,119 +11,296 @@ import (
 	"strings"
 	"time"
 
-	"github.com/dymensionxyz/roller/cmd/services/load"
-	"github.com/dymensionxyz/roller/utils/config/tomlconfig"
 	"github.com/dymensionxyz/roller/utils/dymint"
 	"github.com/dymensionxyz/roller/utils/errorhandling"
+	"github.com/dymensionxyz/roller/utils/notify"
 	"github.com/dymensionxyz/roller/utils/roller"
 	servicemanager "github.com/dymensionxyz/roller/utils/service_manager"
+	"github.com/dymensionxyz/roller/utils/statenode"
 	"github.com/pterm/pterm"
-	"golang.org/x/exp/slices"
 )
 
 const (
 	DefaultWaitBeforeUnhealthy = 2 * time.Minute
 	DefaultHealthCheckInterval = 15 * time.Second
+	// StateNodeScoreInterval is how often the state nodes are rescored, so
+	// that a failover picks a node from a rolling record rather than a single
+	// probe
+	StateNodeScoreInterval = 5 * time.Minute
 )
 
+type scheduledCheck struct {
+	check          Check
+	interval       time.Duration
+	failureWindow  time.Duration
+	remediation    Remediation
+	services       []string
+	nextRun        time.Time
+	unhealthySince time.Time
+	// alerted is set once the check was reported unhealthy, so that its
+	// recovery is reported too
+	alerted bool
+	source  string
+}
+
 func Start(home string, cfg roller.HealthAgentConfig, l *log.Logger) {
-	waitDuration := DefaultWaitBeforeUnhealthy
-	if cfg.WaitBeforeUnhealthy != "" {
-		if parsed, err := time.ParseDuration(cfg.WaitBeforeUnhealthy); err == nil {
-			waitDuration = parsed
+	waitDuration := parseDurationOrDefault(cfg.WaitBeforeUnhealthy, DefaultWaitBeforeUnhealthy)
+	checkInterval := parseDurationOrDefault(cfg.HealthCheckInterval, DefaultHealthCheckInterval)
+
+	checkConfigs := cfg.Checks
+	if len(checkConfigs) == 0 {
+		rollerData, err := roller.LoadConfig(home)
+		if err != nil {
+			l.Println("failed to load roller config, health agent is not started: ", err)
+			return
 		}
+		checkConfigs = DefaultChecks(rollerData)
 	}
 
-	checkInterval := DefaultHealthCheckInterval
-	if cfg.HealthCheckInterval != "" {
-		if parsed, err := time.ParseDuration(cfg.HealthCheckInterval); err == nil {
-			checkInterval = parsed
-		}
+	checks := buildChecks(home, checkConfigs, checkInterval, waitDuration, l)
+	if len(checks) == 0 {
+		l.Println("no valid health checks configured, health agent is not started")
+		return
 	}
 
-	var unhealthySince time.Time
+	notifier, err := notify.Load(home)
+	if err != nil {
+		l.Println("failed to load notification channels, alerts are only logged: ", err)
+	}
 
-	for {
-		time.Sleep(checkInterval)
-		var healthy bool
-		localEndpoint := "localhost"
-		defaultRaMetricPort := "2112"
-		localDaRpcEndpoint := fmt.Sprintf("http://%s:%s", localEndpoint, "26658")
-
-		isDaNodeHealthy, _ := IsEndpointHealthy(localDaRpcEndpoint)
-		healthy = isDaNodeHealthy
-
-		submissions, err := QueryPromMetric(
-			localEndpoint,
-			defaultRaMetricPort,
-			"rollapp_consecutive_failed_da_submissions",
-		)
-		if err != nil {
-			l.Println(err)
+	tick := checkInterval
+	for _, c := range checks {
+		if c.interval < tick {
+			tick = c.interval
 		}
+	}
+
+	var nextScore time.Time
+	for {
+		time.Sleep(tick)
+		now := time.Now()
 
-		if submissions > 10 {
-			healthy = false
+		if !now.Before(nextScore) {
+			nextScore = now.Add(StateNodeScoreInterval)
+			rescoreStateNodes(home, l)
 		}
 
-		if !healthy {
-			if unhealthySince.IsZero() {
-				unhealthySince = time.Now()
-				l.Printf("Service became unhealthy, waiting %v before restart", waitDuration)
+		for _, c := range checks {
+			if now.Before(c.nextRun) {
+				continue
 			}
+			c.nextRun = now.Add(c.interval)
 
-			if time.Since(unhealthySince) >= waitDuration {
-				rollerData, err := roller.LoadConfig(home)
-				errorhandling.PrettifyErrorIfExists(err)
-				rollerConfigPath := roller.GetConfigPath(home)
-
-				i := slices.Index(rollerData.DA.StateNodes, rollerData.DA.CurrentStateNode)
-				var newStateNode string
-				var nodeIndex int
-				if i >= 0 && i+1 < len(rollerData.DA.StateNodes) {
-					nodeIndex = i + 1
-				} else {
-					nodeIndex = 0
+			err := c.check.Run()
+			if err == nil {
+				if !c.unhealthySince.IsZero() {
+					l.Printf("check %s recovered, resetting unhealthy timer", c.check.Name())
 				}
+				if c.alerted {
+					emit(notifier, l, notify.Event{
+						Source:   c.source,
+						Check:    c.check.Name(),
+						Severity: notify.SeverityInfo,
+						Title:    fmt.Sprintf("check %s recovered", c.check.Name()),
+						Resolved: true,
+					})
+					c.alerted = false
+				}
+				c.unhealthySince = time.Time{}
+				continue
+			}
 
-				pterm.Warning.Printf(
-					"detected problems with DA, hotswapping node to %s\n",
-					rollerData.DA.StateNodes[nodeIndex],
+			if c.unhealthySince.IsZero() {
+				c.unhealthySince = now
+				l.Printf(
+					"check %s failed (%v), waiting %v before remediation",
+					c.check.Name(),
+					err,
+					c.failureWindow,
 				)
-				nsn := rollerData.DA.StateNodes[nodeIndex]
-				newStateNode = nsn
-				err = tomlconfig.UpdateFieldInFile(
-					rollerConfigPath,
-					"DA.current_state_node",
-					newStateNode,
-				)
-				if err != nil {
-					pterm.Error.Println("failed to update state node: ", err)
-				}
+			}
 
-				servicesToRestart := []string{
-					"da-light-client",
+			if time.Since(c.unhealthySince) >= c.failureWindow {
+				l.Printf("check %s is unhealthy for %v: %v", c.check.Name(), c.failureWindow, err)
+				if !c.alerted {
+					emit(notifier, l, notify.Event{
+						Source:   c.source,
+						Check:    c.check.Name(),
+						Severity: notify.SeverityCritical,
+						Title:    fmt.Sprintf("check %s is unhealthy", c.check.Name()),
+						Message:  err.Error(),
+						Fields: map[string]string{
+							"failure_window": c.failureWindow.String(),
+							"remediation":    string(c.remediation),
+						},
+					})
+					c.alerted = true
 				}
+				remediate(home, c, l)
+				c.unhealthySince = time.Time{}
+			}
+		}
+	}
+}
 
-				err = load.LoadServices(servicesToRestart, rollerData)
-				if err != nil {
-					pterm.Error.Println("failed to update services")
-				}
+func buildChecks(
+	home string,
+	configs []roller.HealthCheckConfig,
+	defaultInterval, defaultWindow time.Duration,
+	l *log.Logger,
+) []*scheduledCheck {
+	var checks []*scheduledCheck
+
+	for i, cc := range configs {
+		if cc.Name == "" {
+			cc.Name = fmt.Sprintf("%s-%d", cc.Type, i)
+		}
 
-				err = servicemanager.RestartSystemServices(servicesToRestart, home)
-				if err != nil {
-					pterm.Error.Println("failed to restart services")
-				}
+		check, err := NewCheck(home, cc)
+		if err != nil {
+			l.Printf("skipping health check %s: %v", cc.Name, err)
+			continue
+		}
 
-				unhealthySince = time.Time{}
+		remediation := Remediation(cc.Remediation)
+		switch remediation {
+		case "":
+			remediation = RemediationNone
+		case RemediationNone, RemediationDAFailover:
+		case RemediationRestart:
+			if len(cc.Services) == 0 {
+				l.Printf("skipping health check %s: restart remediation requires services", cc.Name)
+				continue
 			}
+		default:
+			l.Printf("skipping health check %s: unknown remediation %s", cc.Name, cc.Remediation)
+			continue
+		}
+
+		checks = append(checks, &scheduledCheck{
+			check:         check,
+			interval:      parseDurationOrDefault(cc.Interval, defaultInterval),
+			failureWindow: parseDurationOrDefault(cc.FailureWindow, defaultWindow),
+			remediation:   remediation,
+			services:      cc.Services,
+			source:        checkSource(cc),
+		})
+	}
+
+	return checks
+}
+
+func checkSource(cc roller.HealthCheckConfig) string {
+	if CheckType(cc.Type) == CheckTypeBalance {
+		return notify.SourceBalance
+	}
+	return notify.SourceHealth
+}
+
+func emit(notifier *notify.Dispatcher, l *log.Logger, e notify.Event) {
+	if err := notifier.Emit(e); err != nil {
+		l.Println("failed to send notification: ", err)
+	}
+}
+
+func remediate(home string, c *scheduledCheck, l *log.Logger) {
+	switch c.remediation {
+	case RemediationDAFailover:
+		failoverDAStateNode(home, l)
+	case RemediationRestart:
+		pterm.Warning.Printf(
+			"check %s is unhealthy, restarting %s\n",
+			c.check.Name(),
+			strings.Join(c.services, ", "),
+		)
+		err := servicemanager.RestartSystemServices(c.services, home)
+		if err != nil {
+			pterm.Error.Println("failed to restart services: ", err)
+		}
+	default:
+		l.Printf("check %s has no remediation configured", c.check.Name())
+	}
+}
+
+// rescoreStateNodes refreshes the scores of the configured state nodes
+func rescoreStateNodes(home string, l *log.Logger) {
+	rollerData, err := roller.LoadConfig(home)
+	if err != nil {
+		l.Println("failed to load roller config, state nodes are not scored: ", err)
+		return
+	}
+	if len(statenode.Candidates(rollerData.DA)) == 0 {
+		return
+	}
+
+	if _, err := statenode.Refresh(home, rollerData.DA); err != nil {
+		l.Println("failed to score state nodes: ", err)
+	}
+}
+
+// failoverDAStateNode probes all configured state nodes and switches the DA
+// light client to the best scoring healthy one
+func failoverDAStateNode(home string, l *log.Logger) {
+	rollerData, err := roller.LoadConfig(home)
+	errorhandling.PrettifyErrorIfExists(err)
+
+	if len(statenode.Candidates(rollerData.DA)) == 0 {
+		pterm.Warning.Println("detected problems with DA, but no state nodes are configured")
+		return
+	}
+
+	current := rollerData.DA.CurrentStateNode
+	if rollerData.DA.PinnedStateNode != "" {
+		l.Printf(
+			"detected problems with DA, state node is pinned to %s, skipping failover",
+			rollerData.DA.PinnedStateNode,
+		)
+		return
+	}
+
+	scores, err := statenode.Refresh(home, rollerData.DA)
+	if err != nil {
+		pterm.Error.Println("failed to score state nodes: ", err)
+		return
+	}
+
+	best, ok := statenode.Best(scores, current)
+	if !ok {
+		l.Printf("detected problems with DA, but no healthy state node to fail over to from %s", current)
+		return
+	}
+
+	reason := fmt.Sprintf("DA unhealthy on %s", current)
+	if cur, ok := scores[current]; ok {
+		if cur.Healthy {
+			reason = fmt.Sprintf("%s (score %.2f)", reason, cur.Score)
 		} else {
-			if !unhealthySince.IsZero() {
-				l.Println("Service recovered, resetting unhealthy timer")
-			}
-			unhealthySince = time.Time{}
+			reason = fmt.Sprintf("%s (probe failed: %s)", reason, cur.LastError)
 		}
 	}
+
+	pterm.Warning.Printf(
+		"detected problems with DA, hotswapping node to %s (score %.2f)\n",
+		best.Node,
+		best.Score,
+	)
+	err = statenode.Switch(rollerData, best.Node, reason, best.Score)
+	if err != nil {
+		pterm.Error.Println("failed to switch state node: ", err)
+	}
+}
+
+func parseDurationOrDefault(s string, d time.Duration) time.Duration {
+	if s == "" {
+		return d
+	}
+
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return d
+	}
+
+	return parsed
 }
 
 func IsAvailNodeHealthy(url string) (bool, error) {
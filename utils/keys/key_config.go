@@ -13,6 +13,7 @@ import (
 
 	"github.com/dymensionxyz/roller/cmd/consts"
 	"github.com/dymensionxyz/roller/utils/bash"
+	"github.com/dymensionxyz/roller/utils/roller"
 )
 
 type keyConfigOptions struct {
@@ -84,6 +85,14 @@ func NewKeyConfig(
 }
 
 func (kc KeyConfig) Create(home string) (*KeyInfo, error) {
+	if addr, ok, err := kc.remoteAddress(home); ok || err != nil {
+		if err != nil {
+			return nil, err
+		}
+		pterm.Info.Printfln("%s is held by the remote signer, using %s", kc.ID, addr)
+		return &KeyInfo{Name: kc.ID, Address: addr}, nil
+	}
+
 	kp := filepath.Join(home, kc.Dir)
 	pterm.Info.Printfln("creating %s in %s", kc.ID, kp)
 	args := []string{
@@ -123,6 +132,13 @@ func (kc KeyConfig) Create(home string) (*KeyInfo, error) {
 }
 
 func (kc KeyConfig) Info(home string) (*KeyInfo, error) {
+	if addr, ok, err := kc.remoteAddress(home); ok || err != nil {
+		if err != nil {
+			return nil, err
+		}
+		return &KeyInfo{Name: kc.ID, Address: addr}, nil
+	}
+
 	var kp string
 	if kc.Dir == consts.ConfigDirName.Eibc {
 		uhd, err := os.UserHomeDir()
@@ -155,6 +171,10 @@ func (kc KeyConfig) Info(home string) (*KeyInfo, error) {
 }
 
 func (kc KeyConfig) Address(home string) (string, error) {
+	if addr, ok, err := kc.remoteAddress(home); ok || err != nil {
+		return addr, err
+	}
+
 	kp := filepath.Join(home, kc.Dir)
 	args := []string{
 		"keys",
@@ -178,6 +198,10 @@ func (kc KeyConfig) Address(home string) (string, error) {
 func (kc KeyConfig) IsInKeyring(
 	home string,
 ) (bool, error) {
+	if _, ok, err := kc.remoteAddress(home); ok || err != nil {
+		return ok, err
+	}
+
 	kp := filepath.Join(home, kc.Dir)
 	args := []string{
 		"keys", "list", "--output", "json",
@@ -210,6 +234,33 @@ func (kc KeyConfig) IsInKeyring(
 	), nil
 }
 
+// remoteAddress returns the address of the key when it is held by the remote
+// signer, i.e. it uses the remote backend or it is the hub sequencer key of a
+// roller home with a remote operator
+func (kc KeyConfig) remoteAddress(home string) (string, bool, error) {
+	isRemote := kc.KeyringBackend == consts.SupportedKeyringBackends.Remote
+	if !isRemote && (kc.ID != consts.KeysIds.HubSequencer || kc.Dir != consts.ConfigDirName.HubKeys) {
+		return "", false, nil
+	}
+
+	rollerData, err := roller.LoadConfig(home)
+	if err != nil {
+		if isRemote {
+			return "", false, err
+		}
+		return "", false, nil
+	}
+
+	s := rollerData.Signer
+	if !isRemote && !s.HasRemoteOperator() {
+		return "", false, nil
+	}
+	if s.OperatorAddress == "" {
+		return "", false, fmt.Errorf("%s is held by the remote signer, operator_address is missing in roller.toml", kc.ID)
+	}
+	return s.OperatorAddress, true, nil
+}
+
 // TODO: KeyInfo and AddressData seem redundant, should be moved into
 // location
 
@@ -2,49 +2,57 @@ package keys
 
 import (
 	"bytes"
+	"fmt"
 	"os/exec"
-	"path/filepath"
 	"runtime"
 
 	"github.com/pterm/pterm"
 
 	"github.com/dymensionxyz/roller/cmd/consts"
+	envconfig "github.com/dymensionxyz/roller/config"
 	"github.com/dymensionxyz/roller/utils/bash"
-	"github.com/dymensionxyz/roller/utils/filesystem"
 )
 
 // KeyringBackendFromEnv determines the appropriate keyring backend based on the environment.
+// ROLLER_KEYRING_BACKEND takes precedence over the environment, it must be one of test, os or file.
 // For mock/playground environments, it returns the test backend.
-// For custom environments, it prompts the user to select between available backends (test and os if not on Darwin).
-// For other environments, it returns the OS backend if not on Darwin, otherwise test backend.
-func KeyringBackendFromEnv(env string) consts.SupportedKeyringBackend {
+// For custom environments, it prompts the user to select between available backends (test, file and os if not on Darwin).
+// For other environments, it returns the OS backend if not on Darwin, otherwise the file backend.
+func KeyringBackendFromEnv(env string) (consts.SupportedKeyringBackend, error) {
+	if kb := envconfig.Config.KeyringBackend; kb != "" {
+		switch backend := consts.SupportedKeyringBackend(kb); backend {
+		case consts.SupportedKeyringBackends.Test,
+			consts.SupportedKeyringBackends.OS,
+			consts.SupportedKeyringBackends.File:
+			return backend, nil
+		default:
+			return "", fmt.Errorf("invalid ROLLER_KEYRING_BACKEND %q, expected test, os or file", kb)
+		}
+	}
+
 	switch env {
 	case "mock", "playground", "blumbus":
-		return consts.SupportedKeyringBackends.Test
-	case "mainnet":
-		if runtime.GOOS != "darwin" {
-			return consts.SupportedKeyringBackends.OS
-		}
-		return consts.SupportedKeyringBackends.Test
+		return consts.SupportedKeyringBackends.Test, nil
 	case "custom":
-		krBackends := []string{"test"}
+		krBackends := []string{"test", "file"}
 		if runtime.GOOS != "darwin" {
 			krBackends = append(krBackends, "os")
 		}
 		keyringBackend, _ := pterm.DefaultInteractiveSelect.WithDefaultText(
 			"select the keyring backend you want to use",
 		).WithOptions(krBackends).Show()
-		return consts.SupportedKeyringBackend(keyringBackend)
+		return consts.SupportedKeyringBackend(keyringBackend), nil
 	default:
 		if runtime.GOOS != "darwin" {
-			return consts.SupportedKeyringBackends.OS
+			return consts.SupportedKeyringBackends.OS, nil
 		}
-		return consts.SupportedKeyringBackends.Test
+		return consts.SupportedKeyringBackends.File, nil
 	}
 }
 
 // RunCmdBasedOnKeyringBackend executes the given command with different behavior based on the keyring backend.
-// For OS keyring backend, it reads the passphrase from a file and handles the interactive prompts.
+// For the os and file keyring backends, it reads the passphrase with ReadKeyringPassphrase and handles the interactive prompts.
+// Keys of the remote backend are not held in a local keyring and can't be used by the command.
 // For other backends, it executes the command directly.
 // Returns the command output buffer and any error encountered.
 func RunCmdBasedOnKeyringBackend(
@@ -53,16 +61,13 @@ func RunCmdBasedOnKeyringBackend(
 	kb consts.SupportedKeyringBackend,
 ) (*bytes.Buffer, error) {
 	var out *bytes.Buffer
-	var pswFileName consts.OsKeyringPwdFileName
 
-	pswFileName, err := filesystem.GetOsKeyringPswFileName(command)
-	if err != nil {
-		return nil, err
+	if kb == consts.SupportedKeyringBackends.Remote {
+		return nil, fmt.Errorf("%s keys are held by the remote signer, they can't be used by %s", kb, command)
 	}
 
-	if kb == consts.SupportedKeyringBackends.OS {
-		fp := filepath.Join(home, string(pswFileName))
-		psw, err := filesystem.ReadFromFile(fp)
+	if kb.RequiresPassphrase() {
+		psw, err := ReadKeyringPassphrase(home, command)
 		if err != nil {
 			return nil, err
 		}
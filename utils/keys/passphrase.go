@@ -0,0 +1,85 @@
+package keys
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	envconfig "github.com/dymensionxyz/roller/config"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+// ReadKeyringPassphrase returns the passphrase of the os or file keyring used
+// by command. ROLLER_KEYRING_PASSPHRASE takes precedence, then the output of
+// the secret command set by ROLLER_KEYRING_PASSPHRASE_COMMAND or by
+// keyring_passphrase_command of roller.toml. The passphrase file of the roller
+// home is read otherwise
+func ReadKeyringPassphrase(home, command string) (string, error) {
+	pswFileName, err := filesystem.GetOsKeyringPswFileName(command)
+	if err != nil {
+		return "", err
+	}
+
+	if psw := envconfig.Config.KeyringPassphrase; psw != "" {
+		return psw, nil
+	}
+	if c := passphraseCommand(home); c != "" {
+		return runPassphraseCommand(c, pswFileName)
+	}
+
+	psw, err := filesystem.ReadFromFile(filepath.Join(home, string(pswFileName)))
+	if err != nil {
+		return "", fmt.Errorf("failed to read keyring passphrase file: %w", err)
+	}
+	return psw, nil
+}
+
+// HasPassphraseSource returns whether the keyring passphrases come from the
+// environment or a secret command, no passphrase file is needed then
+func HasPassphraseSource(home string) bool {
+	return envconfig.Config.KeyringPassphrase != "" || passphraseCommand(home) != ""
+}
+
+func passphraseCommand(home string) string {
+	if c := envconfig.Config.KeyringPassphraseCommand; c != "" {
+		return c
+	}
+	rollerData, err := roller.LoadConfig(home)
+	if err != nil {
+		return ""
+	}
+	return rollerData.KeyringPassphraseCommand
+}
+
+// runPassphraseCommand runs the secret command with ROLLER_KEYRING set to the
+// keyring it is run for, rollapp or da, and returns its first output line
+func runPassphraseCommand(command string, pswFileName consts.OsKeyringPwdFileName) (string, error) {
+	keyring := "rollapp"
+	if pswFileName == consts.OsKeyringPwdFileNames.Da {
+		keyring = "da"
+	}
+
+	var stdout, stderr bytes.Buffer
+	c := exec.Command("sh", "-c", command)
+	c.Env = append(os.Environ(), "ROLLER_KEYRING="+keyring)
+	c.Stdout = &stdout
+	c.Stderr = &stderr
+	if err := c.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("keyring passphrase command failed: %w: %s", err, msg)
+		}
+		return "", fmt.Errorf("keyring passphrase command failed: %w", err)
+	}
+
+	psw, _, _ := strings.Cut(stdout.String(), "\n")
+	psw = strings.TrimRight(psw, "\r")
+	if psw == "" {
+		return "", fmt.Errorf("keyring passphrase command printed no passphrase")
+	}
+	return psw, nil
+}
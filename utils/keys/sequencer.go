@@ -34,12 +34,22 @@ func createSequencersKeys(rollerData roller.RollappConfig) ([]KeyInfo, error) {
 	return addresses, nil
 }
 
+// CreateSequencerOsKeyringPswFile writes the rollapp keyring passphrase file,
+// unless the passphrase is read from the environment or a secret command
 func CreateSequencerOsKeyringPswFile(home string) error {
+	if HasPassphraseSource(home) {
+		return nil
+	}
 	raFp := filepath.Join(home, string(consts.OsKeyringPwdFileNames.RollApp))
 	return config.WritePasswordToFile(raFp)
 }
 
+// CreateDaOsKeyringPswFile writes the da keyring passphrase file, unless the
+// passphrase is read from the environment or a secret command
 func CreateDaOsKeyringPswFile(home string) error {
+	if HasPassphraseSource(home) {
+		return nil
+	}
 	daFp := filepath.Join(home, string(consts.OsKeyringPwdFileNames.Da))
 
 	return config.WritePasswordToFile(daFp)
@@ -118,7 +128,7 @@ func generateRaSequencerKeys(
 			return nil, err
 		}
 
-		if rollerData.KeyringBackend == consts.SupportedKeyringBackends.OS {
+		if rollerData.KeyringBackend.RequiresPassphrase() && !HasPassphraseSource(home) {
 			pterm.Info.Printfln(
 				"use the os keyring password from %s",
 				pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
@@ -167,6 +177,10 @@ func GetMockSequencerKeyConfig(rollappConfig roller.RollappConfig) []KeyConfig {
 }
 
 func GetSequencerPubKey(rollappConfig roller.RollappConfig) (string, error) {
+	if pk := rollappConfig.Signer.ConsensusPubKey; pk != "" {
+		return pk, nil
+	}
+
 	cmd := exec.Command(
 		rollappConfig.RollappBinary,
 		"dymint",
@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"os"
 	"strings"
 	"time"
 
@@ -12,24 +11,40 @@ import (
 
 	"github.com/dymensionxyz/roller/cmd/consts"
 	"github.com/dymensionxyz/roller/utils/dependencies"
+	"github.com/dymensionxyz/roller/utils/plan"
 	"github.com/dymensionxyz/roller/utils/roller"
 	"github.com/dymensionxyz/roller/version"
 )
 
-func RequireRollerMigrateIfNeeded(rlpCfg roller.RollappConfig) {
+// CheckRollerVersion returns an error when the roller config was written by a
+// different roller version than the installed one
+func CheckRollerVersion(rlpCfg roller.RollappConfig) error {
 	currentRollerVersion := version.TrimVersionStr(version.BuildVersion)
 	configRollerVersion := version.TrimVersionStr(rlpCfg.RollerVersion)
 
 	if configRollerVersion != currentRollerVersion {
-		//nolint:errcheck,gosec
-		pterm.Warning.Printf(
-			"💈 Your rollapp config version ('%s') is older than your"+
-				" installed roller version ('%s'),"+
-				" please run 'roller migrate' to update your config.\n", configRollerVersion, currentRollerVersion,
+		return fmt.Errorf(
+			"your rollapp config version ('%s') does not match your installed roller version ('%s')",
+			configRollerVersion,
+			currentRollerVersion,
 		)
 	}
 
-	os.Exit(1)
+	return nil
+}
+
+func RequireRollerMigrateIfNeeded(rlpCfg roller.RollappConfig) {
+	err := CheckRollerVersion(rlpCfg)
+	if err == nil {
+		return
+	}
+
+	//nolint:errcheck,gosec
+	pterm.Warning.Printf(
+		"💈 %s, please run 'roller rollapp migrate' to update your config.\n", err,
+	)
+
+	plan.Exit(1)
 }
 
 func RequireRollappMigrateIfNeeded(current, last, vmType string) error {
@@ -0,0 +1,219 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+type Severity string
+
+const (
+	SeverityInfo     Severity = "info"
+	SeverityWarning  Severity = "warning"
+	SeverityCritical Severity = "critical"
+)
+
+// Alert sources, used to route alerts to channels
+const (
+	SourceHealth  = "health"
+	SourceBalance = "balance"
+	SourceRelayer = "relayer"
+	SourceEibc    = "eibc"
+	SourceTest    = "test"
+)
+
+const sendTimeout = 15 * time.Second
+
+func (s Severity) rank() int {
+	switch s {
+	case SeverityCritical:
+		return 2
+	case SeverityWarning:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func ParseSeverity(s string) (Severity, error) {
+	switch Severity(strings.ToLower(s)) {
+	case "":
+		return SeverityWarning, nil
+	case SeverityInfo:
+		return SeverityInfo, nil
+	case SeverityWarning:
+		return SeverityWarning, nil
+	case SeverityCritical:
+		return SeverityCritical, nil
+	default:
+		return "", fmt.Errorf("unknown severity %q, expected info, warning or critical", s)
+	}
+}
+
+// Event is an alert raised by roller. Check identifies the condition the
+// event reports on within its source, and Resolved marks the event that
+// reports its recovery
+type Event struct {
+	Time     time.Time         `json:"time"`
+	Source   string            `json:"source"`
+	Check    string            `json:"check,omitempty"`
+	Severity Severity          `json:"severity"`
+	Title    string            `json:"title"`
+	Message  string            `json:"message"`
+	Fields   map[string]string `json:"fields,omitempty"`
+	Resolved bool              `json:"resolved,omitempty"`
+}
+
+func (e Event) text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "[%s] %s", strings.ToUpper(string(e.Severity)), e.Title)
+	if e.Message != "" {
+		fmt.Fprintf(&b, "\n%s", e.Message)
+	}
+
+	keys := make([]string, 0, len(e.Fields))
+	for k := range e.Fields {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		fmt.Fprintf(&b, "\n%s: %s", k, e.Fields[k])
+	}
+	return b.String()
+}
+
+// Sink delivers events to a notification channel
+type Sink interface {
+	Send(ctx context.Context, e Event) error
+}
+
+type route struct {
+	name        string
+	sink        Sink
+	minSeverity Severity
+	sources     []string
+}
+
+// accepts reports whether the route takes the event. Recoveries are sent
+// regardless of their severity, so that the channel can close the alert
+func (r route) accepts(e Event) bool {
+	if !e.Resolved && e.Severity.rank() < r.minSeverity.rank() {
+		return false
+	}
+	return len(r.sources) == 0 || slices.Contains(r.sources, e.Source)
+}
+
+// Dispatcher sends events to the channels configured in roller.toml. A nil
+// or empty dispatcher drops all events
+type Dispatcher struct {
+	routes []route
+}
+
+func New(cfg roller.NotifyConfig) (*Dispatcher, error) {
+	d := &Dispatcher{}
+
+	for i, cc := range cfg.Channels {
+		if cc.Name == "" {
+			cc.Name = fmt.Sprintf("%s-%d", cc.Type, i)
+		}
+
+		sev, err := ParseSeverity(cc.MinSeverity)
+		if err != nil {
+			return nil, fmt.Errorf("notify channel %s: %w", cc.Name, err)
+		}
+
+		sink, err := NewSink(cc)
+		if err != nil {
+			return nil, fmt.Errorf("notify channel %s: %w", cc.Name, err)
+		}
+
+		d.routes = append(d.routes, route{
+			name:        cc.Name,
+			sink:        sink,
+			minSeverity: sev,
+			sources:     cc.Sources,
+		})
+	}
+
+	return d, nil
+}
+
+// Load returns the dispatcher configured in the roller.toml of home. Without
+// a roller.toml, the returned dispatcher drops all events
+func Load(home string) (*Dispatcher, error) {
+	rollerData, err := roller.LoadConfig(home)
+	if err != nil {
+		return &Dispatcher{}, nil
+	}
+	return New(rollerData.Notify)
+}
+
+// Channels returns the names of the configured channels
+func (d *Dispatcher) Channels() []string {
+	if d == nil {
+		return nil
+	}
+	names := make([]string, 0, len(d.routes))
+	for _, r := range d.routes {
+		names = append(names, r.name)
+	}
+	return names
+}
+
+// Emit sends the event to every channel that accepts its source and
+// severity, and returns the errors of the channels that failed
+func (d *Dispatcher) Emit(e Event) error {
+	if d == nil {
+		return nil
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now().UTC()
+	}
+	if e.Severity == "" {
+		e.Severity = SeverityWarning
+	}
+
+	var errs []error
+	for _, r := range d.routes {
+		if !r.accepts(e) {
+			continue
+		}
+		if err := d.send(r, e); err != nil {
+			errs = append(errs, fmt.Errorf("notify channel %s: %w", r.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Test sends the event to the named channel, or to all channels when name is
+// empty, regardless of their routing. It returns the result per channel
+func (d *Dispatcher) Test(name string, e Event) map[string]error {
+	results := map[string]error{}
+	if d == nil {
+		return results
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now().UTC()
+	}
+
+	for _, r := range d.routes {
+		if name != "" && r.name != name {
+			continue
+		}
+		results[r.name] = d.send(r, e)
+	}
+	return results
+}
+
+func (d *Dispatcher) send(r route, e Event) error {
+	ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+	defer cancel()
+
+	return r.sink.Send(ctx, e)
+}
@@ -0,0 +1,345 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+type SinkType string
+
+const (
+	SinkTypeWebhook   SinkType = "webhook"
+	SinkTypeTelegram  SinkType = "telegram"
+	SinkTypeDiscord   SinkType = "discord"
+	SinkTypePagerDuty SinkType = "pagerduty"
+	SinkTypeSMTP      SinkType = "smtp"
+	SinkTypeFile      SinkType = "file"
+)
+
+const (
+	pagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+	telegramAPIURL     = "https://api.telegram.org"
+	discordMaxLength   = 2000
+)
+
+type sinkConstructor func(cfg roller.NotifyChannelConfig) (Sink, error)
+
+var sinkRegistry = map[SinkType]sinkConstructor{
+	SinkTypeWebhook:   newWebhookSink,
+	SinkTypeTelegram:  newTelegramSink,
+	SinkTypeDiscord:   newDiscordSink,
+	SinkTypePagerDuty: newPagerDutySink,
+	SinkTypeSMTP:      newSMTPSink,
+	SinkTypeFile:      newFileSink,
+}
+
+// NewSink builds the sink described by cfg using the sink registry
+func NewSink(cfg roller.NotifyChannelConfig) (Sink, error) {
+	constructor, ok := sinkRegistry[SinkType(cfg.Type)]
+	if !ok {
+		return nil, fmt.Errorf("unknown channel type: %s", cfg.Type)
+	}
+
+	return constructor(cfg)
+}
+
+func postJSON(ctx context.Context, url string, headers map[string]string, v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, os.ExpandEnv(v))
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	// nolint:errcheck
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("bad status: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+type webhookSink struct {
+	url     string
+	headers map[string]string
+}
+
+func newWebhookSink(cfg roller.NotifyChannelConfig) (Sink, error) {
+	if cfg.URL == "" {
+		return nil, errors.New("webhook channel requires a url")
+	}
+	return &webhookSink{url: os.ExpandEnv(cfg.URL), headers: cfg.Headers}, nil
+}
+
+// Send posts the event as JSON
+func (s *webhookSink) Send(ctx context.Context, e Event) error {
+	return postJSON(ctx, s.url, s.headers, e)
+}
+
+type telegramSink struct {
+	botToken string
+	chatID   string
+}
+
+func newTelegramSink(cfg roller.NotifyChannelConfig) (Sink, error) {
+	if cfg.BotToken == "" || cfg.ChatID == "" {
+		return nil, errors.New("telegram channel requires a bot_token and a chat_id")
+	}
+	return &telegramSink{botToken: os.ExpandEnv(cfg.BotToken), chatID: os.ExpandEnv(cfg.ChatID)}, nil
+}
+
+func (s *telegramSink) Send(ctx context.Context, e Event) error {
+	url := fmt.Sprintf("%s/bot%s/sendMessage", telegramAPIURL, s.botToken)
+	return postJSON(ctx, url, nil, map[string]string{
+		"chat_id": s.chatID,
+		"text":    e.text(),
+	})
+}
+
+type discordSink struct {
+	url string
+}
+
+func newDiscordSink(cfg roller.NotifyChannelConfig) (Sink, error) {
+	if cfg.URL == "" {
+		return nil, errors.New("discord channel requires a webhook url")
+	}
+	return &discordSink{url: os.ExpandEnv(cfg.URL)}, nil
+}
+
+func (s *discordSink) Send(ctx context.Context, e Event) error {
+	content := e.text()
+	if len(content) > discordMaxLength {
+		content = content[:discordMaxLength-3] + "..."
+	}
+	return postJSON(ctx, s.url, nil, map[string]string{"content": content})
+}
+
+type pagerDutySink struct {
+	routingKey string
+}
+
+func newPagerDutySink(cfg roller.NotifyChannelConfig) (Sink, error) {
+	if cfg.RoutingKey == "" {
+		return nil, errors.New("pagerduty channel requires a routing_key")
+	}
+	return &pagerDutySink{routingKey: os.ExpandEnv(cfg.RoutingKey)}, nil
+}
+
+// Send triggers a PagerDuty Events v2 alert, or resolves it for a recovery.
+// Alerts are deduplicated by source and check
+func (s *pagerDutySink) Send(ctx context.Context, e Event) error {
+	host, _ := os.Hostname()
+	details := map[string]string{"message": e.Message}
+	for k, v := range e.Fields {
+		details[k] = v
+	}
+
+	action := "trigger"
+	if e.Resolved {
+		action = "resolve"
+	}
+
+	return postJSON(ctx, pagerDutyEventsURL, nil, map[string]any{
+		"routing_key":  s.routingKey,
+		"event_action": action,
+		"dedup_key":    pagerDutyDedupKey(e),
+		"payload": map[string]any{
+			"summary":        e.Title,
+			"source":         host,
+			"severity":       string(e.Severity),
+			"component":      e.Source,
+			"timestamp":      e.Time.Format("2006-01-02T15:04:05.000Z07:00"),
+			"custom_details": details,
+		},
+	})
+}
+
+// pagerDutyDedupKey identifies the alert of the event, events without a check
+// fall back to their title
+func pagerDutyDedupKey(e Event) string {
+	check := e.Check
+	if check == "" {
+		check = e.Title
+	}
+	return fmt.Sprintf("roller/%s/%s", e.Source, check)
+}
+
+type smtpSink struct {
+	addr     string
+	host     string
+	port     int
+	username string
+	password string
+	from     string
+	to       []string
+}
+
+func newSMTPSink(cfg roller.NotifyChannelConfig) (Sink, error) {
+	if cfg.SMTPHost == "" || cfg.From == "" || len(cfg.To) == 0 {
+		return nil, errors.New("smtp channel requires a smtp_host, a from address and to addresses")
+	}
+
+	port := cfg.SMTPPort
+	if port == 0 {
+		port = 587
+	}
+
+	return &smtpSink{
+		addr:     net.JoinHostPort(cfg.SMTPHost, strconv.Itoa(port)),
+		host:     cfg.SMTPHost,
+		port:     port,
+		username: os.ExpandEnv(cfg.Username),
+		password: os.ExpandEnv(cfg.Password),
+		from:     cfg.From,
+		to:       cfg.To,
+	}, nil
+}
+
+// Send mails the event. Port 465 uses implicit TLS, other ports upgrade the
+// connection with STARTTLS when the server supports it. The whole exchange is
+// bounded by the deadline of ctx
+func (s *smtpSink) Send(ctx context.Context, e Event) error {
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", s.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(s.to, ", "))
+	fmt.Fprintf(&msg, "Subject: [roller] [%s] %s\r\n", e.Severity, e.Title)
+	fmt.Fprintf(&msg, "Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(e.text(), "\n", "\r\n"))
+
+	var auth smtp.Auth
+	if s.username != "" {
+		auth = smtp.PlainAuth("", s.username, s.password, s.host)
+	}
+
+	tlsConfig := &tls.Config{ServerName: s.host, MinVersion: tls.VersionTLS12}
+	var conn net.Conn
+	var err error
+	if s.port == 465 {
+		conn, err = (&tls.Dialer{Config: tlsConfig}).DialContext(ctx, "tcp", s.addr)
+	} else {
+		conn, err = (&net.Dialer{}).DialContext(ctx, "tcp", s.addr)
+	}
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			// nolint:errcheck
+			conn.Close()
+			return err
+		}
+	}
+
+	c, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		// nolint:errcheck
+		conn.Close()
+		return err
+	}
+	// nolint:errcheck
+	defer c.Close()
+
+	if s.port != 465 {
+		if ok, _ := c.Extension("STARTTLS"); ok {
+			if err := c.StartTLS(tlsConfig); err != nil {
+				return err
+			}
+		}
+	}
+	if auth != nil {
+		if err := c.Auth(auth); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(s.from); err != nil {
+		return err
+	}
+	for _, to := range s.to {
+		if err := c.Rcpt(to); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg.Bytes()); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+type fileSink struct {
+	mu   sync.Mutex
+	path string
+}
+
+func newFileSink(cfg roller.NotifyChannelConfig) (Sink, error) {
+	if cfg.Path == "" {
+		return nil, errors.New("file channel requires a path")
+	}
+	path, err := filesystem.ExpandHomePath(cfg.Path)
+	if err != nil {
+		return nil, err
+	}
+	return &fileSink{path: path}, nil
+}
+
+// Send appends the event to the file as a JSON line
+func (s *fileSink) Send(_ context.Context, e Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	// nolint:gofumpt
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+	// nolint:gofumpt
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	// nolint:errcheck
+	defer f.Close()
+
+	_, err = f.Write(append(b, '\n'))
+	return err
+}
@@ -0,0 +1,73 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/pterm/pterm"
+	"gopkg.in/yaml.v3"
+)
+
+type Format string
+
+const (
+	FormatText Format = "text"
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+)
+
+var SupportedFormats = []Format{FormatText, FormatJSON, FormatYAML}
+
+func ParseFormat(s string) (Format, error) {
+	switch Format(s) {
+	case "", FormatText:
+		return FormatText, nil
+	case FormatJSON:
+		return FormatJSON, nil
+	case FormatYAML:
+		return FormatYAML, nil
+	default:
+		return "", fmt.Errorf("invalid output format: %s. supported formats %s", s, SupportedFormats)
+	}
+}
+
+// IsStructured returns true when the output is meant to be consumed by
+// machines rather than humans
+func (f Format) IsStructured() bool {
+	return f == FormatJSON || f == FormatYAML
+}
+
+// Configure prepares the terminal output for the given format. In structured
+// mode all pterm output (info, warnings, errors, spinners) is sent to stderr
+// without styling, so stdout only contains the structured result
+func Configure(f Format) {
+	if !f.IsStructured() {
+		return
+	}
+
+	pterm.DisableStyling()
+	pterm.SetDefaultOutput(os.Stderr)
+}
+
+// Print writes v to stdout in the given structured format
+func Print(f Format, v any) error {
+	switch f {
+	case FormatJSON:
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error marshalling data: %w", err)
+		}
+		fmt.Println(string(data))
+	case FormatYAML:
+		data, err := yaml.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("error marshalling data: %w", err)
+		}
+		fmt.Print(string(data))
+	default:
+		return fmt.Errorf("%s is not a structured output format", f)
+	}
+
+	return nil
+}
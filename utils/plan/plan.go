@@ -0,0 +1,342 @@
+// Package plan implements the dry-run mode of roller. When it is enabled, the
+// file writes, mutating commands and service actions that go through the
+// shared helpers are recorded into a plan instead of being applied
+package plan
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/pterm/pterm"
+
+	"github.com/dymensionxyz/roller/utils/config/backup"
+	"github.com/dymensionxyz/roller/utils/output"
+)
+
+type Kind string
+
+const (
+	KindFile    Kind = "file"
+	KindDir     Kind = "dir"
+	KindCommand Kind = "command"
+	KindService Kind = "service"
+)
+
+// FileChange is a planned edit of a single file, with the diff between the
+// content on disk and the content the command would have written
+type FileChange struct {
+	Path    string `json:"path" yaml:"path"`
+	Created bool   `json:"created" yaml:"created"`
+	Diff    string `json:"diff" yaml:"diff"`
+}
+
+// Action is a planned side effect other than a file edit, like a key
+// creation, a transaction or a service restart
+type Action struct {
+	Kind        Kind   `json:"kind" yaml:"kind"`
+	Description string `json:"description" yaml:"description"`
+}
+
+type Plan struct {
+	Files   []FileChange `json:"files" yaml:"files"`
+	Actions []Action     `json:"actions" yaml:"actions"`
+}
+
+type file struct {
+	existed  bool
+	original []byte
+	current  []byte
+}
+
+var state = struct {
+	sync.Mutex
+	enabled bool
+	format  output.Format
+	printed bool
+	files   map[string]*file
+	order   []string
+	actions []Action
+}{files: map[string]*file{}}
+
+// Enable switches the current process into dry-run mode, the plan is printed
+// in format when the command ends
+func Enable(format output.Format) {
+	state.Lock()
+	defer state.Unlock()
+	state.enabled = true
+	state.format = format
+}
+
+func Enabled() bool {
+	state.Lock()
+	defer state.Unlock()
+	return state.enabled
+}
+
+// ReadFile reads a file, returning the planned content if the file was
+// written in dry-run mode
+func ReadFile(path string) ([]byte, error) {
+	state.Lock()
+	f, ok := state.files[filepath.Clean(path)]
+	state.Unlock()
+	if ok {
+		return slices.Clone(f.current), nil
+	}
+
+	return os.ReadFile(path)
+}
+
+// WriteFile writes a file atomically after backing it up, or records the
+// write in dry-run mode
+func WriteFile(path string, data []byte, perm os.FileMode) error {
+	if !Enabled() {
+		return backup.WriteFile(path, data, perm)
+	}
+
+	path = filepath.Clean(path)
+
+	state.Lock()
+	defer state.Unlock()
+
+	f, ok := state.files[path]
+	if !ok {
+		f = &file{}
+		original, err := os.ReadFile(path)
+		switch {
+		case err == nil:
+			f.existed = true
+			f.original = original
+		case errors.Is(err, os.ErrNotExist):
+		default:
+			return err
+		}
+		state.files[path] = f
+		state.order = append(state.order, path)
+	}
+	f.current = slices.Clone(data)
+
+	return nil
+}
+
+// MkdirAll creates a directory, or records it in dry-run mode
+func MkdirAll(path string, perm os.FileMode) error {
+	if !Enabled() {
+		return os.MkdirAll(path, perm)
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	Record(KindDir, "create %s", path)
+	return nil
+}
+
+// RemoveAll removes a path, or records the removal in dry-run mode
+func RemoveAll(path string) error {
+	if !Enabled() {
+		return os.RemoveAll(path)
+	}
+
+	Record(KindDir, "remove %s", path)
+	return nil
+}
+
+// Remove removes a file after backing it up, or records the removal in
+// dry-run mode
+func Remove(path string) error {
+	if !Enabled() {
+		return backup.Remove(path)
+	}
+
+	Record(KindFile, "remove %s", path)
+	return nil
+}
+
+// Record adds an action to the plan
+func Record(kind Kind, format string, args ...any) {
+	state.Lock()
+	defer state.Unlock()
+	state.actions = append(
+		state.actions,
+		Action{Kind: kind, Description: fmt.Sprintf(format, args...)},
+	)
+}
+
+// Intercept records cmd when dry-run mode is enabled and the command would
+// change the local machine or the chain. It reports whether the caller must
+// skip running the command. Read-only commands like queries are still run
+func Intercept(cmd *exec.Cmd) bool {
+	if !Enabled() || !isMutating(cmd.Args) {
+		return false
+	}
+
+	kind := KindCommand
+	if isServiceCommand(cmd.Args) {
+		kind = KindService
+	}
+	Record(kind, "run %s", strings.Join(cmd.Args, " "))
+	return true
+}
+
+var (
+	mutatingBinaries = []string{
+		"sudo", "bash", "sh", "cp", "mv", "rm", "mkdir", "tee",
+		"chmod", "chown", "ln", "tar", "unzip", "crontab",
+	}
+	mutatingKeysVerbs = []string{
+		"add", "delete", "import", "rename", "restore", "recover", "unsafe-import-eth-key",
+	}
+	readOnlyServiceVerbs = []string{"is-active", "status", "list", "show", "print"}
+)
+
+func isMutating(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+
+	bin := filepath.Base(args[0])
+	rest := args[1:]
+	if slices.Contains(mutatingBinaries, bin) {
+		return true
+	}
+	if bin == "systemctl" || bin == "launchctl" {
+		return len(rest) > 0 && !slices.Contains(readOnlyServiceVerbs, rest[0])
+	}
+
+	for i, a := range rest {
+		switch a {
+		case "tx", "transact", "init", "gentx", "collect-gentxs":
+			return true
+		case "keys":
+			if i+1 < len(rest) && slices.Contains(mutatingKeysVerbs, rest[i+1]) {
+				return true
+			}
+		case "paths", "chains":
+			if i+1 < len(rest) && slices.Contains([]string{"new", "add", "delete"}, rest[i+1]) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func isServiceCommand(args []string) bool {
+	for _, a := range args {
+		switch filepath.Base(a) {
+		case "systemctl", "launchctl":
+			return true
+		}
+	}
+	return false
+}
+
+// Get returns the plan recorded so far
+func Get() (Plan, error) {
+	state.Lock()
+	defer state.Unlock()
+
+	p := Plan{Files: []FileChange{}, Actions: slices.Clone(state.actions)}
+	if p.Actions == nil {
+		p.Actions = []Action{}
+	}
+
+	for _, path := range state.order {
+		f := state.files[path]
+		if f.existed && string(f.original) == string(f.current) {
+			continue
+		}
+
+		from := path
+		if !f.existed {
+			from = "/dev/null"
+		}
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(string(f.original)),
+			B:        difflib.SplitLines(string(f.current)),
+			FromFile: from,
+			ToFile:   path,
+			Context:  3,
+		})
+		if err != nil {
+			return p, fmt.Errorf("failed to diff %s: %w", path, err)
+		}
+
+		p.Files = append(p.Files, FileChange{Path: path, Created: !f.existed, Diff: diff})
+	}
+
+	return p, nil
+}
+
+// Flush prints the plan in dry-run mode unless it was printed already
+func Flush() error {
+	state.Lock()
+	enabled, printed, format := state.enabled, state.printed, state.format
+	state.Unlock()
+	if !enabled || printed {
+		return nil
+	}
+	return Print(format)
+}
+
+// Exit flushes the plan and exits, commands that end with os.Exit use it so
+// that the plan is printed in dry-run mode
+func Exit(code int) {
+	if err := Flush(); err != nil {
+		pterm.Error.Println("failed to print the dry run plan: ", err)
+	}
+	os.Exit(code)
+}
+
+// Print writes the recorded plan, as unified diffs followed by the other
+// actions in text mode
+func Print(format output.Format) error {
+	p, err := Get()
+	if err != nil {
+		return err
+	}
+
+	state.Lock()
+	state.printed = true
+	state.Unlock()
+
+	if format.IsStructured() {
+		return output.Print(format, p)
+	}
+
+	fmt.Println()
+	pterm.DefaultSection.Println("Dry run plan")
+	if len(p.Files) == 0 && len(p.Actions) == 0 {
+		fmt.Println("no changes")
+		return nil
+	}
+
+	for _, f := range p.Files {
+		fmt.Print(f.Diff)
+		if !strings.HasSuffix(f.Diff, "\n") {
+			fmt.Println()
+		}
+	}
+
+	if len(p.Actions) > 0 {
+		fmt.Println()
+		for _, a := range p.Actions {
+			fmt.Printf("[%s] %s\n", a.Kind, a.Description)
+		}
+	}
+
+	fmt.Printf(
+		"\n💈 %d file changes and %d actions planned, nothing was applied\n",
+		len(p.Files),
+		len(p.Actions),
+	)
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package plan
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIsMutating(t *testing.T) {
+	tests := []struct {
+		name string
+		args string
+		want bool
+	}{
+		{"empty", "", false},
+		{"shell", "bash -c echo", true},
+		{"sudo by path", "/usr/bin/sudo cp a b", true},
+		{"copy", "cp a b", true},
+		{"systemctl restart", "systemctl restart rollapp", true},
+		{"systemctl is-active", "systemctl is-active rollapp", false},
+		{"systemctl bare", "systemctl", false},
+		{"launchctl load", "launchctl load plist", true},
+		{"launchctl list", "launchctl list", false},
+		{"tx", "dymd tx sequencer create-sequencer", true},
+		{"init", "rollappd init mynode", true},
+		{"gentx", "rollappd genesis gentx", true},
+		{"query", "dymd q sequencer show-sequencer", false},
+		{"keys add", "dymd keys add hub_sequencer", true},
+		{"keys delete", "celestia-appd keys delete da", true},
+		{"keys show", "dymd keys show hub_sequencer -a", false},
+		{"keys list", "dymd keys list", false},
+		{"keys last arg", "dymd keys", false},
+		{"relayer paths new", "rly paths new hub rollapp hub-rollapp", true},
+		{"relayer chains add", "rly chains add --file hub.json", true},
+		{"relayer paths list", "rly paths list", false},
+		{"relayer transact", "rly transact link hub-rollapp", true},
+		{"status", "rollappd status", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isMutating(strings.Fields(tt.args)); got != tt.want {
+				t.Errorf("isMutating(%q) = %v, want %v", tt.args, got, tt.want)
+			}
+		})
+	}
+}
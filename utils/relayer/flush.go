@@ -53,8 +53,16 @@ func Flush(home string) {
 		pterm.Error.Println("failed to retrieve chains to run flush for: ", err)
 		return
 	}
-	hd := rlyConfig.HubDataFromRelayerConfig()
-	raID := rlyConfig.Paths.HubRollapp.Dst.ChainID
+	hd, err := rlyConfig.HubDataFromRelayerConfig()
+	if err != nil {
+		pterm.Error.Println("failed to load relayer config: ", err)
+		return
+	}
+	raID, err := rlyConfig.RollappID()
+	if err != nil {
+		pterm.Error.Println("failed to load relayer config: ", err)
+		return
+	}
 
 	flushCfg, err := getFlushConfig(rrhf, raID, *hd)
 	if err != nil {
@@ -109,6 +117,8 @@ func Flush(home string) {
 			// Run a command to get the latest height first
 			testCmd := getFlushCmd(
 				rlyConfigDir,
+				consts.DefaultRelayerPath,
+				"",
 				chainID,
 				endHeight,
 				1,
@@ -151,6 +161,8 @@ func Flush(home string) {
 
 		flushCmd := getFlushCmd(
 			rlyConfigDir,
+			consts.DefaultRelayerPath,
+			"",
 			chainID,
 			startHeight,
 			endHeight-startHeight,
@@ -328,28 +340,28 @@ func writeFlushConfig(configPath string, config *RollerRelayerHelperConfig) erro
 	return toml.NewEncoder(f).Encode(config)
 }
 
-func getFlushCmd(rlyConfigDir, chain string, startHeight, r int) *exec.Cmd {
+// getFlushCmd flushes the stuck packets written on chain between two heights,
+// on every channel of the path unless a hub channel is given
+func getFlushCmd(rlyConfigDir, path, channel, chain string, startHeight, r int) *exec.Cmd {
 	endHeight := startHeight + r
 
-	shStr := fmt.Sprintf("%d", startHeight)
-	ehStr := fmt.Sprintf("%d", endHeight)
-
-	cmd := exec.Command(
-		consts.Executables.Relayer,
+	args := []string{
 		"tx",
 		"flush",
 		"--stuck-packet-chain-id",
 		chain,
 		"--stuck-packet-height-start",
-		shStr,
+		strconv.Itoa(startHeight),
 		"--stuck-packet-height-end",
-		ehStr,
-		"hub-rollapp",
-		"--home",
-		rlyConfigDir,
-	)
+		strconv.Itoa(endHeight),
+		path,
+	}
+	if channel != "" {
+		args = append(args, channel)
+	}
+	args = append(args, "--home", rlyConfigDir)
 
-	return cmd
+	return exec.Command(consts.Executables.Relayer, args...)
 }
 
 func getFlushConfig(rrhf, raID string, hd consts.HubData) (*RollerRelayerHelperConfig, error) {
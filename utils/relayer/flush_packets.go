@@ -0,0 +1,497 @@
+package relayer
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/pterm/pterm"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/relayer"
+	"github.com/dymensionxyz/roller/utils/bash"
+)
+
+const (
+	packetFlushProgressFileName = "flush_progress.json"
+	packetFlushLogFileName      = "flush.log"
+
+	// heightFlushTimeout bounds the time rly gets to relay the packets of a
+	// single height
+	heightFlushTimeout = 5 * time.Minute
+
+	flushKindPacket = "packet"
+	flushKindAck    = "ack"
+
+	flushStatusPending = "pending"
+	flushStatusRelayed = "relayed"
+	flushStatusFailed  = "failed"
+
+	// maxFlushSequences bounds the number of sequences a flush is restricted
+	// to, a larger selection is better flushed without --sequences
+	maxFlushSequences = 10_000
+)
+
+// PacketFlushOptions selects the packets and acknowledgements relayed by
+// FlushPackets
+type PacketFlushOptions struct {
+	// Channel is the hub end of the channel to flush, the active channel is
+	// used when empty
+	Channel string
+	// Sequences restricts the flush to the given packet sequences
+	Sequences []uint64
+}
+
+// packetFlushItem is an unrelayed packet, or the unrelayed acknowledgement of
+// a packet, along with the height of the block it was written in
+type packetFlushItem struct {
+	Kind     string `json:"kind"`
+	Chain    string `json:"chain"`
+	Sequence uint64 `json:"sequence"`
+	Height   int    `json:"height"`
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+}
+
+func (i packetFlushItem) key() string {
+	return fmt.Sprintf("%s/%s/%d", i.Kind, i.Chain, i.Sequence)
+}
+
+// packetFlushProgress is persisted after every flushed height so that an
+// interrupted flush resumes without looking up the heights again
+type packetFlushProgress struct {
+	Channel   string            `json:"channel"`
+	UpdatedAt time.Time         `json:"updated_at"`
+	Heights   map[string]int    `json:"heights"`
+	Items     []packetFlushItem `json:"items"`
+}
+
+// ParseSequences parses a comma separated list of sequences and inclusive
+// sequence ranges, e.g. 1,4,10-20. At most maxFlushSequences sequences can be
+// selected
+func ParseSequences(s string) ([]uint64, error) {
+	var seqs []uint64
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		from, to, isRange := strings.Cut(part, "-")
+		start, err := strconv.ParseUint(strings.TrimSpace(from), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sequence %q", part)
+		}
+		end := start
+		if isRange {
+			end, err = strconv.ParseUint(strings.TrimSpace(to), 10, 64)
+			if err != nil || end < start {
+				return nil, fmt.Errorf("invalid sequence range %q", part)
+			}
+		}
+		if end-start >= uint64(maxFlushSequences-len(seqs)) {
+			return nil, fmt.Errorf("too many sequences, at most %d can be flushed at once", maxFlushSequences)
+		}
+
+		// the loop ends on end rather than past it, so that a range ending at
+		// the largest sequence does not wrap around
+		for seq := start; ; seq++ {
+			seqs = append(seqs, seq)
+			if seq == end {
+				break
+			}
+		}
+	}
+
+	return seqs, nil
+}
+
+// FlushPackets relays the packets and acknowledgements that are pending on a
+// channel, by flushing only the heights they were written at. Progress is
+// reported per sequence and saved in the relayer home, so that the flush can
+// be interrupted and started again
+func FlushPackets(home string, opts PacketFlushOptions) error {
+	rlyConfigDir := filepath.Join(home, consts.ConfigDirName.Relayer)
+
+	var rlyCfg relayer.Config
+	if err := rlyCfg.Load(relayer.GetConfigFilePath(rlyConfigDir)); err != nil {
+		return fmt.Errorf("failed to load relayer config: %w", err)
+	}
+	rly, err := rlyCfg.NewRelayerForPath(home, consts.DefaultRelayerPath)
+	if err != nil {
+		return err
+	}
+	raData, hd := rly.Rollapp, rly.Hub
+
+	if err := resolveFlushChannel(rly, opts.Channel); err != nil {
+		return err
+	}
+	pterm.Info.Printfln(
+		"flushing channel %s on %s <-> %s on %s",
+		rly.SrcChannel, hd.ID, rly.DstChannel, raData.ID,
+	)
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	progressPath := filepath.Join(rlyConfigDir, packetFlushProgressFileName)
+	progress, err := loadPacketFlushProgress(progressPath)
+	if err != nil {
+		return err
+	}
+	if progress.Channel != rly.SrcChannel {
+		progress = &packetFlushProgress{Channel: rly.SrcChannel, Heights: map[string]int{}}
+	}
+
+	items, err := queryPendingItems(rly, opts.Sequences)
+	if err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		pterm.Success.Println("no unrelayed packets or acknowledgements found")
+		progress.Items = nil
+		return savePacketFlushProgress(progressPath, progress)
+	}
+	pterm.Info.Printfln("found %d unrelayed packets and acknowledgements", len(items))
+
+	for i := range items {
+		if ctx.Err() != nil {
+			break
+		}
+
+		it := &items[i]
+		if h, ok := progress.Heights[it.key()]; ok {
+			it.Height = h
+			continue
+		}
+
+		h, err := findItemHeight(rly, *it)
+		if err != nil {
+			it.Status = flushStatusFailed
+			it.Error = err.Error()
+			pterm.Warning.Printfln("%s %d on %s: %v", it.Kind, it.Sequence, it.Chain, err)
+			continue
+		}
+		it.Height = h
+		progress.Heights[it.key()] = h
+	}
+	progress.Items = items
+	if err := savePacketFlushProgress(progressPath, progress); err != nil {
+		return err
+	}
+
+	logFile, err := os.OpenFile(
+		filepath.Join(rlyConfigDir, packetFlushLogFileName),
+		os.O_CREATE|os.O_WRONLY|os.O_APPEND,
+		0o644,
+	)
+	if err != nil {
+		return err
+	}
+	defer logFile.Close()
+	pterm.Info.Println("rly output is written to", logFile.Name())
+
+	groups := groupByHeight(items)
+	done := 0
+	for _, group := range groups {
+		if ctx.Err() != nil {
+			break
+		}
+
+		first := items[group[0]]
+		pterm.Info.Printfln(
+			"flushing height %d on %s (%s)",
+			first.Height, first.Chain, describeItems(items, group),
+		)
+
+		err := runHeightFlush(ctx, getFlushCmd(rlyConfigDir, rly.Path, rly.SrcChannel, first.Chain, first.Height, 1), logFile)
+		if err != nil && ctx.Err() == nil {
+			pterm.Warning.Printfln("failed to flush height %d on %s: %v", first.Height, first.Chain, err)
+		}
+
+		pending, qErr := queryPendingItems(rly, opts.Sequences)
+		for _, idx := range group {
+			it := &items[idx]
+			done++
+			switch {
+			case qErr != nil:
+				it.Status = flushStatusFailed
+				it.Error = fmt.Sprintf("failed to verify: %v", qErr)
+			case slices.ContainsFunc(pending, func(p packetFlushItem) bool { return p.key() == it.key() }):
+				it.Status = flushStatusFailed
+				it.Error = "still unrelayed after flush"
+				if err != nil {
+					it.Error = err.Error()
+				}
+			default:
+				it.Status = flushStatusRelayed
+				it.Error = ""
+			}
+
+			if it.Status == flushStatusRelayed {
+				pterm.Success.Printfln("[%d/%d] %s %d on %s relayed", done, len(items), it.Kind, it.Sequence, it.Chain)
+			} else {
+				pterm.Warning.Printfln("[%d/%d] %s %d on %s: %s", done, len(items), it.Kind, it.Sequence, it.Chain, it.Error)
+			}
+		}
+
+		progress.Items = items
+		if err := savePacketFlushProgress(progressPath, progress); err != nil {
+			return err
+		}
+	}
+
+	relayed, failed, pending := 0, 0, 0
+	for _, it := range items {
+		switch it.Status {
+		case flushStatusRelayed:
+			relayed++
+		case flushStatusFailed:
+			failed++
+		default:
+			pending++
+		}
+	}
+
+	if ctx.Err() != nil {
+		pterm.Warning.Printfln(
+			"flush interrupted, %d relayed, %d failed, %d left. Run the command again to resume",
+			relayed, failed, pending,
+		)
+		return nil
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d packets and acknowledgements were not relayed, see %s", failed, len(items), progressPath)
+	}
+	pterm.Success.Printfln("all %d packets and acknowledgements were relayed", relayed)
+	return nil
+}
+
+// resolveFlushChannel sets the hub and rollapp ends of the channel to flush
+func resolveFlushChannel(rly *relayer.Relayer, hubChannel string) error {
+	if hubChannel == "" {
+		if err := rly.LoadActiveChannel(rly.Rollapp, rly.Hub); err != nil {
+			return fmt.Errorf("failed to load the active channel: %w", err)
+		}
+		return nil
+	}
+
+	channels, err := rly.QueryRollappChannels(rly.Rollapp)
+	if err != nil {
+		return fmt.Errorf("failed to query rollapp channels: %w", err)
+	}
+	for _, c := range channels {
+		if c.Counterparty.ChannelID == hubChannel {
+			rly.SrcChannel = hubChannel
+			rly.DstChannel = c.ChannelID
+			return nil
+		}
+	}
+
+	return fmt.Errorf("channel %s on %s has no counterparty on %s", hubChannel, rly.Hub.ID, rly.Rollapp.ID)
+}
+
+// queryPendingItems returns the unrelayed packets and acknowledgements of the
+// channel, filtered by sequence
+func queryPendingItems(rly *relayer.Relayer, sequences []uint64) ([]packetFlushItem, error) {
+	packets, err := rly.QueryUnrelayedPackets(rly.SrcChannel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query unrelayed packets: %w", err)
+	}
+	acks, err := rly.QueryUnrelayedAcks(rly.SrcChannel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query unrelayed acknowledgements: %w", err)
+	}
+
+	var items []packetFlushItem
+	add := func(kind, chain string, seqs []uint64) {
+		for _, seq := range seqs {
+			if len(sequences) > 0 && !slices.Contains(sequences, seq) {
+				continue
+			}
+			items = append(items, packetFlushItem{Kind: kind, Chain: chain, Sequence: seq, Status: flushStatusPending})
+		}
+	}
+	add(flushKindPacket, rly.Hub.ID, packets.Src)
+	add(flushKindPacket, rly.Rollapp.ID, packets.Dst)
+	add(flushKindAck, rly.Hub.ID, acks.Src)
+	add(flushKindAck, rly.Rollapp.ID, acks.Dst)
+
+	return items, nil
+}
+
+type txSearchResponse struct {
+	Txs []struct {
+		Height string `json:"height"`
+	} `json:"txs"`
+}
+
+// findItemHeight searches the chain the packet or acknowledgement was written
+// on for the height of the transaction that emitted it
+func findItemHeight(rly *relayer.Relayer, it packetFlushItem) (int, error) {
+	binary, node, channel := consts.Executables.Dymension, rly.Hub.RpcUrl, rly.SrcChannel
+	if it.Chain == rly.Rollapp.ID {
+		binary, node, channel = consts.Executables.RollappEVM, rly.Rollapp.RpcUrl, rly.DstChannel
+	}
+
+	query := fmt.Sprintf(
+		"send_packet.packet_src_channel='%s' AND send_packet.packet_sequence='%d'",
+		channel, it.Sequence,
+	)
+	if it.Kind == flushKindAck {
+		query = fmt.Sprintf(
+			"write_acknowledgement.packet_dst_channel='%s' AND write_acknowledgement.packet_sequence='%d'",
+			channel, it.Sequence,
+		)
+	}
+
+	out, err := bash.ExecCommandWithStdoutFiltered(exec.Command(
+		binary, "q", "txs",
+		"--query", query,
+		"--limit", "1",
+		"--node", node,
+		"--chain-id", it.Chain,
+		"-o", "json",
+	))
+	if err != nil {
+		return 0, fmt.Errorf("failed to search the transaction: %w", err)
+	}
+
+	var resp txSearchResponse
+	if err := json.Unmarshal(out.Bytes(), &resp); err != nil {
+		return 0, err
+	}
+	if len(resp.Txs) == 0 {
+		return 0, errors.New("no transaction found, the node may have pruned it")
+	}
+
+	return strconv.Atoi(resp.Txs[0].Height)
+}
+
+// groupByHeight returns the indexes of the items with a known height, grouped
+// by chain and height in ascending order
+func groupByHeight(items []packetFlushItem) [][]int {
+	byHeight := map[string][]int{}
+	var keys []string
+	for i, it := range items {
+		if it.Height == 0 {
+			continue
+		}
+		k := fmt.Sprintf("%s/%020d", it.Chain, it.Height)
+		if _, ok := byHeight[k]; !ok {
+			keys = append(keys, k)
+		}
+		byHeight[k] = append(byHeight[k], i)
+	}
+	sort.Strings(keys)
+
+	groups := make([][]int, 0, len(keys))
+	for _, k := range keys {
+		groups = append(groups, byHeight[k])
+	}
+	return groups
+}
+
+func describeItems(items []packetFlushItem, group []int) string {
+	parts := make([]string, 0, len(group))
+	for _, idx := range group {
+		parts = append(parts, fmt.Sprintf("%s %d", items[idx].Kind, items[idx].Sequence))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// runHeightFlush runs a stuck packet flush of a single height until rly
+// reports the height as processed, writing its output to w
+func runHeightFlush(ctx context.Context, cmd *exec.Cmd, w io.Writer) error {
+	ctx, cancel := context.WithTimeout(ctx, heightFlushTimeout)
+	defer cancel()
+
+	pr, pw := io.Pipe()
+	cmd.Stdout = pw
+	cmd.Stderr = pw
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	waitErr := make(chan error, 1)
+	go func() {
+		err := cmd.Wait()
+		// nolint: errcheck
+		pw.Close()
+		waitErr <- err
+	}()
+
+	processed := make(chan struct{})
+	go func() {
+		scanner := bufio.NewScanner(pr)
+		for scanner.Scan() {
+			// nolint: errcheck
+			fmt.Fprintln(w, scanner.Text())
+			if strings.Contains(scanner.Text(), "Parsed stuck packet height, skipping to current") {
+				close(processed)
+				break
+			}
+		}
+		// keep draining so that rly does not block on a full pipe
+		// nolint: errcheck
+		io.Copy(io.Discard, pr)
+	}()
+
+	select {
+	case <-processed:
+		// nolint: errcheck
+		cmd.Process.Kill()
+		<-waitErr
+		return nil
+	case err := <-waitErr:
+		return err
+	case <-ctx.Done():
+		// nolint: errcheck
+		cmd.Process.Kill()
+		<-waitErr
+		return ctx.Err()
+	}
+}
+
+func loadPacketFlushProgress(path string) (*packetFlushProgress, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return &packetFlushProgress{Heights: map[string]int{}}, nil
+		}
+		return nil, err
+	}
+
+	var p packetFlushProgress
+	if err := json.Unmarshal(b, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if p.Heights == nil {
+		p.Heights = map[string]int{}
+	}
+	return &p, nil
+}
+
+func savePacketFlushProgress(path string, p *packetFlushProgress) error {
+	p.UpdatedAt = time.Now().UTC()
+	b, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	// nolint:gofumpt
+	return os.WriteFile(path, b, 0o644)
+}
@@ -0,0 +1,103 @@
+package relayer
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseSequences(t *testing.T) {
+	maxSeq := uint64(math.MaxUint64)
+
+	tests := []struct {
+		name    string
+		in      string
+		want    []uint64
+		wantLen int
+		wantErr string
+	}{
+		{name: "single", in: "4", want: []uint64{4}},
+		{name: "list and range", in: "1, 4,10-12", want: []uint64{1, 4, 10, 11, 12}},
+		{name: "empty parts", in: "1,,2,", want: []uint64{1, 2}},
+		{name: "empty", in: "", want: nil},
+		{name: "single element range", in: "7-7", want: []uint64{7}},
+		{
+			name: "range ending at the largest sequence",
+			in:   fmt.Sprintf("%d-%d", maxSeq-2, maxSeq),
+			want: []uint64{maxSeq - 2, maxSeq - 1, maxSeq},
+		},
+		{name: "largest sequence", in: fmt.Sprint(maxSeq), want: []uint64{maxSeq}},
+		{name: "range at the cap", in: fmt.Sprintf("1-%d", maxFlushSequences), wantLen: maxFlushSequences},
+		{name: "reversed range", in: "5-3", wantErr: "invalid sequence range"},
+		{name: "not a number", in: "abc", wantErr: "invalid sequence"},
+		{name: "negative", in: "-1", wantErr: "invalid sequence"},
+		{name: "open range", in: "3-", wantErr: "invalid sequence range"},
+		{name: "overflowing sequence", in: "18446744073709551616", wantErr: "invalid sequence"},
+		{name: "full range", in: fmt.Sprintf("1-%d", maxSeq), wantErr: "too many sequences"},
+		{name: "from zero to the largest", in: fmt.Sprintf("0-%d", maxSeq), wantErr: "too many sequences"},
+		{name: "range over the cap", in: fmt.Sprintf("1-%d", maxFlushSequences+1), wantErr: "too many sequences"},
+		{
+			name:    "parts over the cap together",
+			in:      fmt.Sprintf("1-%d,%d", maxFlushSequences, maxFlushSequences+5),
+			wantErr: "too many sequences",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSequences(tt.in)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseSequences(%q) error = %v, want %q", tt.in, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSequences(%q) unexpected error: %v", tt.in, err)
+			}
+			if tt.wantLen > 0 {
+				if len(got) != tt.wantLen {
+					t.Fatalf("ParseSequences(%q) returned %d sequences, want %d", tt.in, len(got), tt.wantLen)
+				}
+				return
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("ParseSequences(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetFlushCmd(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		channel string
+		want    string
+	}{
+		{
+			name: "every channel of the path",
+			path: "hub-rollapp",
+			want: "tx flush --stuck-packet-chain-id hub_1-1 --stuck-packet-height-start 10 " +
+				"--stuck-packet-height-end 11 hub-rollapp --home /rly",
+		},
+		{
+			name:    "single channel",
+			path:    "hub-rollapp2",
+			channel: "channel-7",
+			want: "tx flush --stuck-packet-chain-id hub_1-1 --stuck-packet-height-start 10 " +
+				"--stuck-packet-height-end 11 hub-rollapp2 channel-7 --home /rly",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := getFlushCmd("/rly", tt.path, tt.channel, "hub_1-1", 10, 1)
+			if got := strings.Join(cmd.Args[1:], " "); got != tt.want {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
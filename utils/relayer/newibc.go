@@ -33,7 +33,7 @@ func InitializeRelayer(home string, rollerData roller.RollappConfig) error {
 	err = relayer.InitializeConfig(
 		relayer.ChainConfig{
 			ID:            rollerData.RollappID,
-			RPC:           consts.DefaultRollappRPC,
+			RPC:           roller.LocalRollappRPC(),
 			Denom:         rollerData.BaseDenom,
 			AddressPrefix: rollerData.Bech32Prefix,
 			GasPrices:     "2000000000",
@@ -56,6 +56,23 @@ func InitializeRelayer(home string, rollerData roller.RollappConfig) error {
 	return nil
 }
 
+// EnsureRollappChain adds the rollapp to an already initialized relayer, so
+// that a path to a rollapp other than the one the relayer was initialized for
+// can be created
+func EnsureRollappChain(home string, rollerData roller.RollappConfig, rpc string) error {
+	return relayer.AddChain(
+		relayer.ChainConfig{
+			ID:            rollerData.RollappID,
+			RPC:           rpc,
+			Denom:         rollerData.BaseDenom,
+			AddressPrefix: rollerData.Bech32Prefix,
+			GasPrices:     "2000000000",
+		},
+		consts.KeysIds.RollappRelayer,
+		filepath.Join(home, consts.ConfigDirName.Relayer),
+	)
+}
+
 func EnsureKeysArePresentAndFunded(
 	home string,
 	rollerData roller.RollappConfig,
@@ -28,6 +28,9 @@ import (
 const (
 	minEvmDRSNewDAConfig  = "5"
 	minWasmDRSNewDAConfig = "7"
+	// MinDRSRemoteConsensusSigner is the first DRS whose node connects to a
+	// consensus key signer listening on priv_validator_laddr
+	MinDRSRemoteConsensusSigner = 9
 )
 
 func GetHomeDir(home string) string {
@@ -232,7 +235,10 @@ func PopulateRollerConfigWithRaMetadataFromChain(
 				pterm.Info.Println(
 					"keyring backend not set in roller config, retrieving it from environment",
 				)
-				kb = keys.KeyringBackendFromEnv(hd.Environment)
+				kb, err = keys.KeyringBackendFromEnv(hd.Environment)
+				if err != nil {
+					return nil, err
+				}
 			}
 		} else {
 			kb = rollerData.KeyringBackend
@@ -240,7 +246,10 @@ func PopulateRollerConfigWithRaMetadataFromChain(
 		cfg = rollerData
 	} else if kb.Zero() {
 		pterm.Info.Println("no existing roller configuration found, retrieving keyring backend from environment")
-		kb = keys.KeyringBackendFromEnv(hd.Environment)
+		kb, err = keys.KeyringBackendFromEnv(hd.Environment)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	genesisTmpDir, err := os.MkdirTemp(os.TempDir(), "genesis-file")
@@ -266,6 +275,12 @@ func PopulateRollerConfigWithRaMetadataFromChain(
 		return nil, fmt.Errorf("failed to get DA info: %w", err)
 	}
 
+	// the generic DA is an operator choice that is not reflected in the genesis,
+	// keep it when it was configured locally
+	if cfg.DA.Backend == consts.Generic {
+		DAData = &cfg.DA
+	}
+
 	var baseDenom string
 	if raResponse.Rollapp.GenesisInfo.NativeDenom != nil &&
 		raResponse.Rollapp.GenesisInfo.NativeDenom.Base != "" {
@@ -324,6 +339,16 @@ func IsDaConfigNewFormat(drsVersion string, evmType string) bool {
 	return false
 }
 
+// SupportsRemoteConsensusSigner returns whether rollapps of drsVersion sign
+// blocks with a remote consensus key signer
+func SupportsRemoteConsensusSigner(drsVersion string) (bool, error) {
+	v, err := strconv.Atoi(drsVersion)
+	if err != nil {
+		return false, fmt.Errorf("invalid drs version %q: %w", drsVersion, err)
+	}
+	return v >= MinDRSRemoteConsensusSigner, nil
+}
+
 func IsDAConfigMigrationRequired(oldDRS string, newDRS string, evmType string) bool {
 	if oldDRS >= minEvmDRSNewDAConfig && "evm" == evmType {
 		return false
@@ -401,7 +426,7 @@ type RollappDaemonParams struct {
 func getRollappParamsFromNode(rpcEndpoint, chainID string) (*RollappDaemonParams, error) {
 	rpc := strings.TrimSpace(rpcEndpoint)
 	if rpc == "" {
-		rpc = consts.DefaultRollappRPC
+		rpc = roller.LocalRollappRPC()
 	}
 	args := []string{"q", "rollappparams", "params", "--node", rpc, "-o", "json"}
 	if chainID != "" {
@@ -0,0 +1,226 @@
+package roller
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+
+	naoinatoml "github.com/naoina/toml"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+)
+
+const (
+	// DefaultProfile is the profile that lives directly in the roller home
+	DefaultProfile = "default"
+
+	// profilePortStep is the distance between the port ranges of two profiles
+	profilePortStep = 100
+)
+
+var profileNameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,31}$`)
+
+// Profile is a named rollapp that lives in its own directory under the
+// roller home, with its own config, keys, services and ports
+type Profile struct {
+	PortOffset int `toml:"port_offset"`
+}
+
+// Contexts is the list of profiles of a roller home and the one currently in use
+type Contexts struct {
+	Current  string             `toml:"current"`
+	Profiles map[string]Profile `toml:"profiles"`
+}
+
+var active = struct {
+	name    string
+	profile Profile
+}{name: DefaultProfile}
+
+func GetContextsPath(baseHome string) string {
+	return filepath.Join(baseHome, consts.ContextsFileName)
+}
+
+// LoadContexts reads the contexts file of a roller home, a missing file is
+// the same as having only the default profile
+func LoadContexts(baseHome string) (Contexts, error) {
+	c := Contexts{Profiles: map[string]Profile{}}
+
+	b, err := os.ReadFile(GetContextsPath(baseHome))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return c, nil
+		}
+		return c, err
+	}
+
+	if err := naoinatoml.Unmarshal(b, &c); err != nil {
+		return c, err
+	}
+	if c.Profiles == nil {
+		c.Profiles = map[string]Profile{}
+	}
+
+	return c, nil
+}
+
+func WriteContexts(baseHome string, c Contexts) error {
+	b, err := naoinatoml.Marshal(c)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(GetContextsPath(baseHome), b, 0o644)
+}
+
+// ProfileNames returns the sorted names of all profiles including the default one
+func (c Contexts) ProfileNames() []string {
+	names := []string{DefaultProfile}
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	slices.Sort(names[1:])
+	return names
+}
+
+// CurrentProfile returns the profile in use when no --rollapp flag is provided
+func (c Contexts) CurrentProfile() string {
+	if c.Current == "" {
+		return DefaultProfile
+	}
+	return c.Current
+}
+
+func ValidateProfileName(name string) error {
+	if name == DefaultProfile {
+		return fmt.Errorf("'%s' is reserved for the profile in the roller home", DefaultProfile)
+	}
+	if !profileNameRegex.MatchString(name) {
+		return fmt.Errorf(
+			"invalid profile name %s: use up to 32 lowercase letters, digits and dashes",
+			name,
+		)
+	}
+	return nil
+}
+
+// ProfileHome returns the roller home of a profile
+func ProfileHome(baseHome, name string) string {
+	if name == "" || name == DefaultProfile {
+		return baseHome
+	}
+	return filepath.Join(baseHome, consts.ProfilesDirName, name)
+}
+
+// SplitProfileHome returns the roller home and profile name a home directory
+// belongs to
+func SplitProfileHome(home string) (string, string) {
+	home = filepath.Clean(home)
+	parent := filepath.Dir(home)
+	if filepath.Base(parent) == consts.ProfilesDirName {
+		return filepath.Dir(parent), filepath.Base(home)
+	}
+	return home, DefaultProfile
+}
+
+// CreateProfile registers a new profile and creates its directory. Every
+// profile gets its own port offset so that rollapps can run side by side
+func CreateProfile(baseHome, name string) (Profile, error) {
+	if err := ValidateProfileName(name); err != nil {
+		return Profile{}, err
+	}
+
+	c, err := LoadContexts(baseHome)
+	if err != nil {
+		return Profile{}, err
+	}
+	if _, ok := c.Profiles[name]; ok {
+		return Profile{}, fmt.Errorf("profile %s already exists", name)
+	}
+
+	offset := profilePortStep
+	for _, p := range c.Profiles {
+		if p.PortOffset >= offset {
+			offset = p.PortOffset + profilePortStep
+		}
+	}
+
+	p := Profile{PortOffset: offset}
+	if err := os.MkdirAll(ProfileHome(baseHome, name), 0o755); err != nil {
+		return Profile{}, err
+	}
+
+	c.Profiles[name] = p
+	return p, WriteContexts(baseHome, c)
+}
+
+// ResolveProfile returns the home directory of a profile and activates it for
+// the current process. An empty name resolves to the current context
+func ResolveProfile(baseHome, name string) (string, error) {
+	c, err := LoadContexts(baseHome)
+	if err != nil {
+		return "", fmt.Errorf("failed to load %s: %w", consts.ContextsFileName, err)
+	}
+
+	if name == "" {
+		name = c.CurrentProfile()
+	}
+
+	var p Profile
+	if name != DefaultProfile {
+		var ok bool
+		p, ok = c.Profiles[name]
+		if !ok {
+			return "", fmt.Errorf(
+				"profile %s does not exist, create it with 'roller context create %s'",
+				name,
+				name,
+			)
+		}
+	}
+
+	SetActiveProfile(name, p)
+	return ProfileHome(baseHome, name), nil
+}
+
+// SetActiveProfile sets the profile used to namespace services and ports
+func SetActiveProfile(name string, p Profile) {
+	active.name = name
+	active.profile = p
+}
+
+func ActiveProfileName() string {
+	return active.name
+}
+
+// ServiceName returns the system service name of a roller service for the
+// active profile, services of the default profile keep their plain names
+func ServiceName(svc string) string {
+	if active.name == DefaultProfile || active.name == "" {
+		return svc
+	}
+
+	if ext := filepath.Ext(svc); ext == ".service" {
+		return ServiceName(svc[:len(svc)-len(ext)]) + ext
+	}
+	return fmt.Sprintf("%s-%s", svc, active.name)
+}
+
+// ProfilePort shifts one of the default ports into the range of the active profile
+func ProfilePort(port int) int {
+	return port + active.profile.PortOffset
+}
+
+// LocalEndpoint returns the local http endpoint of a default port for the
+// active profile
+func LocalEndpoint(port int) string {
+	return fmt.Sprintf("http://localhost:%d", ProfilePort(port))
+}
+
+// LocalRollappRPC returns the RPC endpoint of the rollapp running on this machine
+func LocalRollappRPC() string {
+	return LocalEndpoint(consts.DefaultPorts.RollappRPC)
+}
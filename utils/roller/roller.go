@@ -10,6 +10,7 @@ import (
 
 	"github.com/dymensionxyz/roller/cmd/consts"
 	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/plan"
 	"github.com/dymensionxyz/roller/version"
 )
 
@@ -51,7 +52,7 @@ func CreateConfigFileIfNotPresent(home string) (bool, error) {
 func LoadConfig(root string) (RollappConfig, error) {
 	var rc RollappConfig
 	p := filepath.Join(root, consts.RollerConfigFileName)
-	tomlBytes, err := os.ReadFile(p)
+	tomlBytes, err := plan.ReadFile(p)
 	if err != nil {
 		return rc, err
 	}
@@ -76,12 +77,12 @@ func WriteConfig(rlpCfg RollappConfig) error {
 	}
 	configPath := filepath.Join(rlpCfg.Home, consts.RollerConfigFileName)
 	// nolint:gofumpt,errcheck
-	return os.WriteFile(configPath, tomlBytes, 0o644)
+	return plan.WriteFile(configPath, tomlBytes, 0o644)
 }
 
 func LoadHubData(root string) (consts.HubData, error) {
 	var config RollappConfig
-	tomlBytes, err := os.ReadFile(filepath.Join(root, consts.RollerConfigFileName))
+	tomlBytes, err := plan.ReadFile(filepath.Join(root, consts.RollerConfigFileName))
 	if err != nil {
 		return config.HubData, err
 	}
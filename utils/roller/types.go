@@ -1,14 +1,20 @@
 package roller
 
-import "github.com/dymensionxyz/roller/cmd/consts"
+import (
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/versions"
+)
 
-var SupportedDas = []consts.DAType{consts.Celestia, consts.Avail, consts.LoadNetwork, consts.Bnb, consts.Aptos, consts.Sui, consts.Walrus, consts.Ethereum, consts.Kaspa, consts.Solana, consts.Local}
+var SupportedDas = []consts.DAType{consts.Celestia, consts.Avail, consts.LoadNetwork, consts.Bnb, consts.Aptos, consts.Sui, consts.Walrus, consts.Ethereum, consts.Kaspa, consts.Solana, consts.Generic, consts.Local}
 
 type RollappConfig struct {
 	// new roller.toml
 	Home           string                         `toml:"home"`
 	RollerVersion  string                         `toml:"roller_version"`
 	KeyringBackend consts.SupportedKeyringBackend `toml:"keyring_backend"`
+	// KeyringPassphraseCommand prints the passphrase of the os and file
+	// keyrings, it replaces the passphrase files of the roller home
+	KeyringPassphraseCommand string `toml:"keyring_passphrase_command"`
 
 	NodeType string `toml:"node_type"`
 
@@ -30,10 +36,173 @@ type RollappConfig struct {
 	HubData     consts.HubData    `toml:"HubData"`
 	DA          consts.DaData     `toml:"DA"`
 	HealthAgent HealthAgentConfig `toml:"HealthAgent"`
+
+	ClientWatchdog ClientWatchdogConfig `toml:"ClientWatchdog"`
+	Notify         NotifyConfig         `toml:"Notify"`
+	Funding        FundingConfig        `toml:"Funding"`
+	Versions       versions.Config      `toml:"Versions"`
+	Signer         SignerConfig         `toml:"Signer"`
 }
 
 type HealthAgentConfig struct {
 	Enabled             bool   `toml:"enabled"`
 	WaitBeforeUnhealthy string `toml:"wait_before_unhealthy"`
 	HealthCheckInterval string `toml:"health_check_interval"`
+
+	// Checks is the list of checks run by the health agent. When empty, the
+	// agent falls back to the built-in DA light client and DA submission checks
+	Checks []HealthCheckConfig `toml:"checks"`
+}
+
+// ClientWatchdogConfig configures the watchdog run by the relayer that tracks
+// the trusting period expiry of the hub and rollapp IBC light clients.
+// Thresholds are either a share of the trusting period left, e.g. "25%", or a
+// duration left, e.g. "48h"
+type ClientWatchdogConfig struct {
+	Disabled bool   `toml:"disabled"`
+	Interval string `toml:"interval"`
+	// AlertThresholds raise an alert once per client update when crossed
+	AlertThresholds []string `toml:"alert_thresholds"`
+	// UpdateThreshold is the time left below which the clients are updated
+	UpdateThreshold string `toml:"update_threshold"`
+}
+
+// HealthCheckConfig describes a single health agent check, configured as a
+// [[HealthAgent.checks]] entry in roller.toml
+type HealthCheckConfig struct {
+	Name string `toml:"name"`
+	// Type is one of http, metric, block_height or process
+	Type string `toml:"type"`
+	// Interval is the time between two consecutive runs of the check
+	Interval string `toml:"interval"`
+	// FailureWindow is how long the check has to keep failing before the
+	// remediation action is triggered
+	FailureWindow string `toml:"failure_window"`
+	// Remediation is one of none, restart or da_failover
+	Remediation string `toml:"remediation"`
+	// Services are the services restarted by the restart remediation
+	Services []string `toml:"services"`
+
+	// Endpoint is the probed URL for the http, metric and block_height checks
+	Endpoint string `toml:"endpoint"`
+	// Metric, Operator and Threshold are used by the metric check, e.g.
+	// rollapp_consecutive_failed_da_submissions gt 10
+	Metric    string  `toml:"metric"`
+	Operator  string  `toml:"operator"`
+	Threshold float64 `toml:"threshold"`
+	// Process is the pattern matched against running processes by the
+	// process check
+	Process string `toml:"process"`
+}
+
+// NotifyConfig routes the alerts raised by roller, e.g. by the health agent
+// or the relayer client watchdog, to notification channels
+type NotifyConfig struct {
+	Channels []NotifyChannelConfig `toml:"channels"`
+}
+
+// NotifyChannelConfig describes a single notification channel, configured as
+// a [[Notify.channels]] entry in roller.toml. Secrets can reference
+// environment variables, e.g. "${TELEGRAM_BOT_TOKEN}"
+type NotifyChannelConfig struct {
+	Name string `toml:"name"`
+	// Type is one of webhook, telegram, discord, pagerduty, smtp or file
+	Type string `toml:"type"`
+	// MinSeverity is the lowest severity sent to the channel, one of info,
+	// warning or critical. Defaults to warning
+	MinSeverity string `toml:"min_severity"`
+	// Sources restricts the channel to alerts of the given sources, e.g.
+	// health, balance, relayer or eibc. All sources are sent when empty
+	Sources []string `toml:"sources"`
+
+	// URL is the endpoint of the webhook and discord channels
+	URL     string            `toml:"url"`
+	Headers map[string]string `toml:"headers"`
+	// BotToken and ChatID are used by the telegram channel
+	BotToken string `toml:"bot_token"`
+	ChatID   string `toml:"chat_id"`
+	// RoutingKey is the integration key of the pagerduty channel
+	RoutingKey string `toml:"routing_key"`
+	// SMTPHost, SMTPPort, Username, Password, From and To are used by the
+	// smtp channel
+	SMTPHost string   `toml:"smtp_host"`
+	SMTPPort int      `toml:"smtp_port"`
+	Username string   `toml:"username"`
+	Password string   `toml:"password"`
+	From     string   `toml:"from"`
+	To       []string `toml:"to"`
+	// Path is the file the file channel appends alerts to, one JSON per line
+	Path string `toml:"path"`
+}
+
+// SignerConfig moves the signing of the sequencer keys out of the local
+// keyrings. Headers can reference environment variables, e.g.
+// "Bearer ${SIGNER_TOKEN}"
+type SignerConfig struct {
+	// OperatorURL is the signing service holding the hub operator key of the
+	// sequencer, OperatorAddress is the hub address of that key
+	OperatorURL     string            `toml:"operator_url"`
+	OperatorAddress string            `toml:"operator_address"`
+	Headers         map[string]string `toml:"headers"`
+	// ConsensusLaddr is the address the rollapp node listens on for a
+	// tmkms-style signer of the consensus key, e.g. tcp://0.0.0.0:26659
+	ConsensusLaddr string `toml:"consensus_laddr"`
+	// ConsensusPubKey is the public key of that consensus key in the format of
+	// `dymint show-sequencer`, it is registered on the hub instead of the key
+	// of the rollapp home
+	ConsensusPubKey string `toml:"consensus_pubkey"`
+}
+
+// HasRemoteOperator returns whether the hub operator key is held by a remote
+// signer
+func (s SignerConfig) HasRemoteOperator() bool {
+	return s.OperatorURL != ""
+}
+
+// FundingConfig configures the runway forecast of the operational wallets,
+// i.e. sequencer, relayer-hub, relayer-rollapp, da and eibc, and their
+// optional top up from treasury keys
+type FundingConfig struct {
+	// AutoTopUp tops up the wallets from the funding agent that runs along
+	// with the rollapp
+	AutoTopUp bool   `toml:"auto_top_up"`
+	Interval  string `toml:"interval"`
+	// AlertRunway raises a balance alert when the runway of a wallet drops
+	// below it. Defaults to 72h
+	AlertRunway string                  `toml:"alert_runway"`
+	Wallets     []FundingWalletConfig   `toml:"wallets"`
+	Treasuries  []FundingTreasuryConfig `toml:"treasuries"`
+}
+
+// IsConfigured returns whether the [Funding] section of roller.toml is set,
+// the funding agent then forecasts the runways and raises balance alerts
+func (c FundingConfig) IsConfigured() bool {
+	return c.AutoTopUp || c.Interval != "" || c.AlertRunway != "" || len(c.Wallets) > 0 || len(c.Treasuries) > 0
+}
+
+// FundingWalletConfig sets when and by how much a wallet is topped up.
+// Amounts are coins in base denom, e.g. "5000000000000000000adym"
+type FundingWalletConfig struct {
+	Name string `toml:"name"`
+	// MinBalance and MinRunway trigger a top up when crossed
+	MinBalance string `toml:"min_balance"`
+	MinRunway  string `toml:"min_runway"`
+	// TargetBalance and TargetRunway are what a top up aims for, the
+	// largest of the two is used
+	TargetBalance string `toml:"target_balance"`
+	TargetRunway  string `toml:"target_runway"`
+	// MaxTopUp caps a single top up
+	MaxTopUp string `toml:"max_top_up"`
+}
+
+// FundingTreasuryConfig is the key that funds the wallets of a chain, one of
+// hub, rollapp or da
+type FundingTreasuryConfig struct {
+	Chain          string `toml:"chain"`
+	Key            string `toml:"key"`
+	KeyringBackend string `toml:"keyring_backend"`
+	KeyringDir     string `toml:"keyring_dir"`
+	// DailyBudget caps the amount sent by the treasury over 24 hours. Without
+	// it, every wallet is topped up at most once in 24 hours
+	DailyBudget string `toml:"daily_budget"`
 }
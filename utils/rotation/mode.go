@@ -0,0 +1,115 @@
+package rotation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pterm/pterm"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/cmd/services/load"
+	datalayer "github.com/dymensionxyz/roller/data_layer"
+	"github.com/dymensionxyz/roller/utils/config/tomlconfig"
+	"github.com/dymensionxyz/roller/utils/keys"
+	"github.com/dymensionxyz/roller/utils/rollapp"
+	"github.com/dymensionxyz/roller/utils/roller"
+	sequencerutils "github.com/dymensionxyz/roller/utils/sequencer"
+	servicemanager "github.com/dymensionxyz/roller/utils/service_manager"
+)
+
+// SwitchMode runs the local node as nodeType: the roller and dymint
+// configuration are updated the way `rollapp setup` does for that node type
+// and the rollapp services are reloaded when they are installed on the host
+func SwitchMode(rollerData roller.RollappConfig, nodeType string) error {
+	err := tomlconfig.UpdateFieldInFile(
+		roller.GetConfigPath(rollerData.Home),
+		"node_type",
+		nodeType,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update node type: %w", err)
+	}
+	rollerData.NodeType = nodeType
+
+	dymintConfigPath := sequencerutils.GetDymintFilePath(rollerData.Home)
+	err = tomlconfig.UpdateFieldInFile(
+		dymintConfigPath,
+		"p2p_advertising_enabled",
+		fmt.Sprintf("%t", nodeType == consts.NodeType.FullNode),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update dymint config: %w", err)
+	}
+
+	if rollerData.DA.Backend != consts.Local {
+		damanager, err := datalayer.NewDAManager(
+			rollerData.DA.Backend,
+			rollerData.Home,
+			rollerData.KeyringBackend,
+			nodeType,
+		)
+		if err != nil {
+			return err
+		}
+
+		daConfig, err := getDaConfig(damanager.DataLayer, nodeType, rollerData)
+		if err != nil {
+			return err
+		}
+		if daConfig != nil {
+			err = tomlconfig.UpdateFieldInFile(dymintConfigPath, "da_config", daConfig)
+			if err != nil {
+				return fmt.Errorf("failed to update dymint config: %w", err)
+			}
+		}
+
+		if nodeType == consts.NodeType.Sequencer {
+			insufficientBalances, err := damanager.CheckDABalance()
+			if err != nil {
+				pterm.Warning.Println("failed to check DA balance: ", err)
+			}
+			_ = keys.PrintInsufficientBalancesIfAny(insufficientBalances)
+		}
+	}
+
+	services := consts.RollappSystemdServices
+	if rollerData.DA.Backend == consts.Celestia {
+		services = consts.RollappWithCelesSystemdServices
+	}
+
+	loaded, err := servicemanager.IsServiceLoaded(services[0])
+	if err != nil || !loaded {
+		return err
+	}
+
+	err = load.LoadServices(services, rollerData)
+	if err != nil {
+		return fmt.Errorf("failed to update services: %w", err)
+	}
+
+	err = servicemanager.RestartSystemServices(services, rollerData.Home)
+	if err != nil {
+		return fmt.Errorf("failed to restart services: %w", err)
+	}
+
+	return nil
+}
+
+// getDaConfig returns the DA config of the node type in the format of the
+// rollapp DRS, nil when the DA layer has none
+func getDaConfig(dataLayer datalayer.DataLayer, nodeType string, rollerData roller.RollappConfig) (any, error) {
+	daConfig := dataLayer.GetSequencerDAConfig(nodeType)
+	if daConfig == "" {
+		return nil, nil
+	}
+
+	drsVersion, err := rollapp.GetDrsVersionFromChain(rollerData.RollappID, rollerData.HubData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get drs version from rollapp: %w", err)
+	}
+
+	if rollapp.IsDaConfigNewFormat(drsVersion, strings.ToLower(string(rollerData.RollappVMType))) {
+		return []string{daConfig}, nil
+	}
+	return daConfig, nil
+}
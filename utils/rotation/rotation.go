@@ -0,0 +1,180 @@
+// Package rotation hands the proposer role of a rollapp over from one
+// sequencer to another
+package rotation
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/bash"
+	"github.com/dymensionxyz/roller/utils/keys"
+	"github.com/dymensionxyz/roller/utils/rollapp"
+	"github.com/dymensionxyz/roller/utils/roller"
+	sequencerutils "github.com/dymensionxyz/roller/utils/sequencer"
+	"github.com/dymensionxyz/roller/utils/signer"
+)
+
+// Sides of the handover
+const (
+	RoleOutgoing = "outgoing"
+	RoleIncoming = "incoming"
+)
+
+const bondedStatus = "OPERATING_STATUS_BONDED"
+
+type NextProposerResponse struct {
+	NextProposerAddr   string `json:"nextProposerAddr"`
+	RotationInProgress bool   `json:"rotationInProgress"`
+}
+
+// GetNextProposer returns the sequencer the hub will rotate to once the notice
+// period of the current proposer ends
+func GetNextProposer(raID string, hd consts.HubData) (*NextProposerResponse, error) {
+	cmd := exec.Command(
+		consts.Executables.Dymension,
+		"q", "sequencer", "next-proposer",
+		raID, "-o", "json", "--node", hd.RpcUrl, "--chain-id", hd.ID,
+	)
+
+	out, err := bash.ExecCommandWithStdout(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp NextProposerResponse
+	if err := json.Unmarshal(out.Bytes(), &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetSequencer returns the sequencer of the rollapp registered under addr
+func GetSequencer(raID string, hd consts.HubData, addr string) (*sequencerutils.Info, error) {
+	seqs, err := sequencerutils.RegisteredRollappSequencersOnHub(raID, hd)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range seqs.Sequencers {
+		if s.Address == addr {
+			return &s, nil
+		}
+	}
+	return nil, fmt.Errorf("%s is not registered as a sequencer of %s", addr, raID)
+}
+
+// CheckSuccessor returns the successor when it can take over as proposer:
+// registered for the rollapp, bonded with at least the minimum bond, not
+// jailed and opted in
+func CheckSuccessor(raID string, hd consts.HubData, addr string) (*sequencerutils.Info, error) {
+	s, err := GetSequencer(raID, hd, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	var problems []string
+	if s.Status != bondedStatus {
+		problems = append(problems, fmt.Sprintf("status is %s", s.Status))
+	}
+	if s.Jailed {
+		problems = append(problems, "jailed")
+	}
+	if !s.OptedIn {
+		problems = append(problems, "not opted in")
+	}
+
+	minBond, err := sequencerutils.GetMinSequencerBondInBaseDenom(raID, hd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve the minimum sequencer bond: %w", err)
+	}
+	if s.Tokens.AmountOf(minBond.Denom).LT(minBond.Amount) {
+		problems = append(
+			problems,
+			fmt.Sprintf("bond of %s is below the minimum of %s", s.Tokens, minBond),
+		)
+	}
+
+	if len(problems) > 0 {
+		return s, fmt.Errorf("%s can't become the proposer: %s", addr, strings.Join(problems, ", "))
+	}
+	return s, nil
+}
+
+// InNoticePeriod returns whether the sequencer already started its notice
+// period, i.e. it unbonded while being the proposer
+func InNoticePeriod(s sequencerutils.Info) bool {
+	return !s.NoticePeriodTime.IsZero()
+}
+
+// Unbond unbonds the local sequencer. When it is the proposer, this starts
+// its notice period, after which the hub rotates to the next proposer
+func Unbond(rollerData roller.RollappConfig) (string, error) {
+	return sequencerTx(rollerData, "unbond")
+}
+
+// OptIn makes the local sequencer eligible to be selected as proposer
+func OptIn(rollerData roller.RollappConfig) (string, error) {
+	return sequencerTx(rollerData, "opt-in", "true")
+}
+
+func sequencerTx(rollerData roller.RollappConfig, args ...string) (string, error) {
+	args = append([]string{"tx", "sequencer"}, args...)
+	args = append(
+		args,
+		"--keyring-backend", string(rollerData.KeyringBackend),
+		"--from", consts.KeysIds.HubSequencer,
+		"--keyring-dir", filepath.Join(rollerData.Home, consts.ConfigDirName.HubKeys),
+		"--fees", fmt.Sprintf("%d%s", consts.DefaultTxFee, consts.Denoms.Hub),
+		"--node", rollerData.HubData.RpcUrl,
+		"--chain-id", rollerData.HubData.ID,
+		"-y",
+	)
+
+	if rollerData.Signer.HasRemoteOperator() {
+		return signer.OperatorTx(rollerData, args)
+	}
+
+	out, err := keys.RunCmdBasedOnKeyringBackend(
+		rollerData.Home,
+		consts.Executables.Dymension,
+		args,
+		rollerData.KeyringBackend,
+	)
+	if err != nil {
+		return "", err
+	}
+	return bash.ExtractTxHash(out.String())
+}
+
+// WaitForProposer polls the proposer of the rollapp until done accepts it or
+// the timeout elapses. A zero timeout waits forever
+func WaitForProposer(
+	raID string,
+	hd consts.HubData,
+	interval, timeout time.Duration,
+	done func(proposer string) bool,
+) (string, error) {
+	start := time.Now()
+	for {
+		proposer, err := rollapp.GetCurrentProposer(raID, hd)
+		if err == nil && done(proposer) {
+			return proposer, nil
+		}
+
+		if timeout > 0 && time.Since(start) > timeout {
+			if err != nil {
+				return "", fmt.Errorf("timed out waiting for the proposer to change: %w", err)
+			}
+			return proposer, fmt.Errorf(
+				"timed out waiting for the proposer to change, the proposer is still %s",
+				proposer,
+			)
+		}
+		time.Sleep(interval)
+	}
+}
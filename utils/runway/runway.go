@@ -0,0 +1,148 @@
+// Package runway estimates how long the balance of an account lasts, based on
+// balance samples recorded over time
+package runway
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// minSampleInterval avoids recording samples that are too close to each
+	// other to measure the spending rate
+	minSampleInterval = 10 * time.Minute
+	// window is the period the spending rate is measured over
+	window     = 7 * 24 * time.Hour
+	maxSamples = 1000
+	// minElapsed is the shortest period a forecast is made for
+	minElapsed = time.Hour
+)
+
+// Sample is the balance of an account at a point in time, in base denom
+type Sample struct {
+	Time   time.Time `json:"time"`
+	Amount float64   `json:"amount"`
+}
+
+// Forecast is the estimated runway of a balance. When Known is false there are
+// not enough samples yet or the balance did not decrease
+type Forecast struct {
+	Balance    float64       `json:"balance" yaml:"balance"`
+	BurnPerDay float64       `json:"burn_per_day" yaml:"burn_per_day"`
+	Known      bool          `json:"known" yaml:"known"`
+	Runway     time.Duration `json:"-" yaml:"-"`
+	Days       float64       `json:"days,omitempty" yaml:"days,omitempty"`
+}
+
+func (f Forecast) String() string {
+	if !f.Known {
+		return "unknown, not enough balance history yet"
+	}
+	return (f.Runway.Round(time.Hour)).String()
+}
+
+func Load(path string) ([]Sample, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var samples []Sample
+	if err := json.Unmarshal(b, &samples); err != nil {
+		return nil, err
+	}
+	return samples, nil
+}
+
+// Preview returns the recorded samples with a sample of the current balance,
+// without writing it to the samples file
+func Preview(path string, amount float64) ([]Sample, error) {
+	samples, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	return append(samples, Sample{Time: time.Now().UTC(), Amount: amount}), nil
+}
+
+// Record adds a sample of the current balance to the samples file and returns
+// all the recorded samples
+func Record(path string, amount float64) ([]Sample, error) {
+	samples, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if len(samples) > 0 && now.Sub(samples[len(samples)-1].Time) < minSampleInterval {
+		// keep the previous sample so that the interval keeps growing, but
+		// forecast with the latest balance
+		return append(samples, Sample{Time: now, Amount: amount}), nil
+	}
+
+	samples = append(samples, Sample{Time: now, Amount: amount})
+	if len(samples) > maxSamples {
+		samples = samples[len(samples)-maxSamples:]
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	b, err := json.Marshal(samples)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, b, 0o644); err != nil {
+		return nil, err
+	}
+
+	return samples, nil
+}
+
+// Estimate forecasts the runway of the latest balance from the spending over
+// the last week. Top ups are not counted as negative spending
+func Estimate(samples []Sample) Forecast {
+	if len(samples) == 0 {
+		return Forecast{}
+	}
+
+	last := samples[len(samples)-1]
+	f := Forecast{Balance: last.Amount}
+
+	var spent float64
+	var first *Sample
+	for i := range samples {
+		s := samples[i]
+		if last.Time.Sub(s.Time) > window {
+			continue
+		}
+		if first != nil {
+			prev := samples[i-1]
+			if s.Amount < prev.Amount {
+				spent += prev.Amount - s.Amount
+			}
+		} else {
+			first = &samples[i]
+		}
+	}
+
+	if first == nil {
+		return f
+	}
+	elapsed := last.Time.Sub(first.Time)
+	if elapsed < minElapsed || spent <= 0 {
+		return f
+	}
+
+	f.BurnPerDay = spent / elapsed.Hours() * 24
+	f.Days = f.Balance / f.BurnPerDay
+	f.Runway = time.Duration(f.Days * float64(24*time.Hour))
+	f.Known = true
+
+	return f
+}
@@ -43,18 +43,7 @@ func CheckBalance(rollappConfig roller.RollappConfig) error {
 		return err
 	}
 
-	opsAmnt, _ := cosmossdkmath.NewIntFromString(consts.MinOperationalAmount)
-
-	desiredBond := cosmossdktypes.NewCoin(
-		consts.Denoms.Hub,
-		opsAmnt,
-	)
-
-	necessaryBalance := desiredBond.Amount
-
-	necessaryBalance = necessaryBalance.Add(
-		cosmossdkmath.NewInt(consts.DefaultTxFee),
-	)
+	necessaryBalance := getNecessaryBalance()
 
 	blnc, _ := denom.BaseDenomToDenom(*balance, 18)
 	oneDym, _ := cosmossdkmath.NewIntFromString("1000000000000000000")
@@ -89,3 +78,40 @@ func CheckBalance(rollappConfig roller.RollappConfig) error {
 	}
 	return nil
 }
+
+// getNecessaryBalance returns the balance the sequencer address needs to
+// operate, the minimal operational amount plus the fee of a transaction
+func getNecessaryBalance() cosmossdkmath.Int {
+	opsAmnt, _ := cosmossdkmath.NewIntFromString(consts.MinOperationalAmount)
+
+	return opsAmnt.Add(cosmossdkmath.NewInt(consts.DefaultTxFee))
+}
+
+// GetInsufficientBalances returns the sequencer address when its balance is
+// below the necessary balance, without prompting the user to fund it
+func GetInsufficientBalances(rollappConfig roller.RollappConfig) ([]keys.NotFundedAddressData, error) {
+	seqData, err := GetSequencerData(rollappConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	necessaryBalance := getNecessaryBalance()
+
+	var insufficientBalances []keys.NotFundedAddressData
+	for _, seq := range seqData {
+		if seq.Balance.Amount.GTE(necessaryBalance) {
+			continue
+		}
+
+		insufficientBalances = append(insufficientBalances, keys.NotFundedAddressData{
+			KeyName:         consts.KeysIds.HubSequencer,
+			Address:         seq.Address,
+			CurrentBalance:  seq.Balance.Amount.BigInt(),
+			RequiredBalance: necessaryBalance.BigInt(),
+			Denom:           consts.Denoms.Hub,
+			Network:         rollappConfig.HubData.ID,
+		})
+	}
+
+	return insufficientBalances, nil
+}
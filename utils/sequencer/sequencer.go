@@ -13,6 +13,7 @@ import (
 	"strings"
 
 	cosmossdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
 	dymrollapptypes "github.com/dymensionxyz/dymension/v3/x/rollapp/types"
 	"github.com/pterm/pterm"
 
@@ -20,10 +21,10 @@ import (
 	"github.com/dymensionxyz/roller/cmd/tx/tx_utils"
 	"github.com/dymensionxyz/roller/utils/bash"
 	"github.com/dymensionxyz/roller/utils/denom"
-	"github.com/dymensionxyz/roller/utils/filesystem"
 	"github.com/dymensionxyz/roller/utils/keys"
 	"github.com/dymensionxyz/roller/utils/rollapp"
 	"github.com/dymensionxyz/roller/utils/roller"
+	"github.com/dymensionxyz/roller/utils/signer"
 	"github.com/dymensionxyz/roller/utils/tx"
 )
 
@@ -111,14 +112,12 @@ func Register(raCfg roller.RollappConfig, desiredBond cosmossdktypes.Coin) error
 		return err
 	}
 
-	pswFileName, err := filesystem.GetOsKeyringPswFileName(consts.Executables.Dymension)
-	if err != nil {
-		return err
-	}
-	fp := filepath.Join(raCfg.Home, string(pswFileName))
-	psw, err := filesystem.ReadFromFile(fp)
-	if err != nil {
-		return err
+	var psw string
+	if !raCfg.Signer.HasRemoteOperator() {
+		psw, err = keys.ReadKeyringPassphrase(raCfg.Home, consts.Executables.Dymension)
+		if err != nil {
+			return err
+		}
 	}
 
 	newHeader := pterm.HeaderPrinter{
@@ -139,6 +138,14 @@ func Register(raCfg roller.RollappConfig, desiredBond cosmossdktypes.Coin) error
 		return errors.New("user declined to bond the sequencer")
 	}
 
+	if raCfg.Signer.HasRemoteOperator() {
+		txHash, err := signer.OperatorTx(raCfg, args)
+		if err != nil {
+			return err
+		}
+		return tx.MonitorTransaction(raCfg.HubData.WsUrl, txHash)
+	}
+
 	automaticPrompts := map[string]string{
 		"Enter keyring passphrase":    psw,
 		"Re-enter keyring passphrase": psw,
@@ -444,7 +451,7 @@ func getShowSequencersCmd(raID string) *exec.Cmd {
 	return exec.Command(
 		consts.Executables.RollappEVM,
 		"q", "sequencers", "sequencers",
-		"-o", "json", "--node", consts.DefaultRollappRPC, "--chain-id", raID,
+		"-o", "json", "--node", roller.LocalRollappRPC(), "--chain-id", raID,
 	)
 }
 
@@ -639,31 +646,40 @@ func UpdateWhitelistedRelayers(
 		"--fees", fmt.Sprintf("%d%s", consts.DefaultTxFee, consts.Denoms.Hub),
 	}
 
-	psw, err := filesystem.ReadOsKeyringPswFile(home, consts.Executables.Dymension)
-	if err != nil {
-		return err
-	}
+	var txHash string
+	rollerData, err := roller.LoadConfig(home)
+	if err == nil && rollerData.Signer.HasRemoteOperator() {
+		txHash, err = signer.OperatorTx(rollerData, args)
+		if err != nil {
+			return err
+		}
+	} else {
+		psw, err := keys.ReadKeyringPassphrase(home, consts.Executables.Dymension)
+		if err != nil {
+			return err
+		}
 
-	automaticPrompts := map[string]string{
-		"Enter keyring passphrase": psw,
-	}
-	manualPromptResponses := map[string]string{
-		"signatures": "this transaction is going to update the whitelisted relayers. do you want to continue?",
-	}
+		automaticPrompts := map[string]string{
+			"Enter keyring passphrase": psw,
+		}
+		manualPromptResponses := map[string]string{
+			"signatures": "this transaction is going to update the whitelisted relayers. do you want to continue?",
+		}
 
-	txOutput, err := bash.ExecuteCommandWithPromptHandlerFiltered(
-		consts.Executables.Dymension,
-		args,
-		automaticPrompts,
-		manualPromptResponses,
-	)
-	if err != nil {
-		return err
-	}
+		txOutput, err := bash.ExecuteCommandWithPromptHandlerFiltered(
+			consts.Executables.Dymension,
+			args,
+			automaticPrompts,
+			manualPromptResponses,
+		)
+		if err != nil {
+			return err
+		}
 
-	txHash, err := bash.ExtractTxHash(txOutput.String())
-	if err != nil {
-		return err
+		txHash, err = bash.ExtractTxHash(txOutput.String())
+		if err != nil {
+			return err
+		}
 	}
 
 	err = tx.MonitorTransaction(hd.WsUrl, txHash)
@@ -675,6 +691,11 @@ func UpdateWhitelistedRelayers(
 }
 
 func GetSequencerOperatorAddress(home string, kb string) (string, error) {
+	rollerData, err := roller.LoadConfig(home)
+	if err == nil && rollerData.Signer.HasRemoteOperator() {
+		return remoteOperatorValAddress(rollerData)
+	}
+
 	rollappConfigDirPath := filepath.Join(home, consts.ConfigDirName.HubKeys)
 	args := []string{
 		"keys",
@@ -688,7 +709,7 @@ func GetSequencerOperatorAddress(home string, kb string) (string, error) {
 		"--bech",
 		"val",
 	}
-	psw, err := filesystem.ReadOsKeyringPswFile(home, consts.Executables.Dymension)
+	psw, err := keys.ReadKeyringPassphrase(home, consts.Executables.Dymension)
 	if err != nil {
 		return "", err
 	}
@@ -712,6 +733,16 @@ func GetSequencerOperatorAddress(home string, kb string) (string, error) {
 	return a, nil
 }
 
+// remoteOperatorValAddress returns the rollapp validator address of the
+// operator key held by the remote signer
+func remoteOperatorValAddress(rollerData roller.RollappConfig) (string, error) {
+	_, bz, err := bech32.DecodeAndConvert(rollerData.Signer.OperatorAddress)
+	if err != nil {
+		return "", fmt.Errorf("invalid operator_address: %w", err)
+	}
+	return bech32.ConvertAndEncode(rollerData.Bech32Prefix+"valoper", bz)
+}
+
 type RaWhitelisterRelayersResponse struct {
 	Relayers []string `json:"relayers"`
 }
@@ -40,6 +40,8 @@ type Info struct {
 	WhitelistedRelayers []string `protobuf:"bytes,13,rep,name=whitelisted_relayers,json=whitelistedRelayers,proto3"                json:"whitelisted_relayers,omitempty"`
 	// opted in defines whether the sequencer can be selected as proposer
 	OptedIn bool `protobuf:"varint,14,opt,name=opted_in,proto3"                                                    json:"opted_in,omitempty"`
+	// notice_period_time defines the time when the sequencer will finish its notice period if started
+	NoticePeriodTime time.Time `protobuf:"bytes,15,opt,name=notice_period_time,json=noticePeriodTime,proto3,stdtime" json:"notice_period_time"`
 }
 
 type Metadata struct {
@@ -108,4 +110,5 @@ type SequencerParamsResponse struct {
 
 type SequencerParams struct {
 	LivenessSlashMinAbsolute cosmossdktypes.Coin `json:"liveness_slash_min_absolute"`
+	NoticePeriod             string              `json:"notice_period"`
 }
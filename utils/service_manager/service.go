@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
@@ -148,7 +149,10 @@ func StartSystemServices(services []string) error {
 	return nil
 }
 
+// StartSystemdService starts a roller service. Like the other service helpers
+// it namespaces the service name for the active profile
 func StartSystemdService(serviceName string) error {
+	serviceName = roller.ServiceName(serviceName)
 	cmd := exec.Command("sudo", "systemctl", "start", serviceName)
 
 	err := bash.ExecCmd(cmd)
@@ -160,14 +164,15 @@ func StartSystemdService(serviceName string) error {
 }
 
 func StartLaunchctlService(serviceName string) error {
+	serviceName = roller.ServiceName(serviceName)
 	svcFilaPath := fmt.Sprintf("/Library/LaunchDaemons/xyz.dymension.roller.%s.plist", serviceName)
 
-	err := exec.Command("sudo", "launchctl", "unload", "-w", svcFilaPath).Run()
+	err := bash.ExecCmd(exec.Command("sudo", "launchctl", "unload", "-w", svcFilaPath))
 	if err != nil {
 		return err
 	}
 
-	err = exec.Command("sudo", "launchctl", "load", "-w", svcFilaPath).Run()
+	err = bash.ExecCmd(exec.Command("sudo", "launchctl", "load", "-w", svcFilaPath))
 	if err != nil {
 		return err
 	}
@@ -176,6 +181,7 @@ func StartLaunchctlService(serviceName string) error {
 }
 
 func RestartSystemdService(serviceName string) error {
+	serviceName = roller.ServiceName(serviceName)
 	cmd := exec.Command("sudo", "systemctl", "restart", serviceName)
 
 	// not ideal, shouldn't run sudo commands from within roller
@@ -188,6 +194,7 @@ func RestartSystemdService(serviceName string) error {
 }
 
 func RestartLaunchctlService(serviceName string) error {
+	serviceName = roller.ServiceName(serviceName)
 	svcFilaPath := fmt.Sprintf("/Library/LaunchDaemons/xyz.dymension.roller.%s.plist", serviceName)
 	dCmd := exec.Command("sudo", "launchctl", "unload", "-w", svcFilaPath)
 	err := bash.ExecCmd(dCmd)
@@ -205,6 +212,7 @@ func RestartLaunchctlService(serviceName string) error {
 }
 
 func StopSystemdService(serviceName string) error {
+	serviceName = roller.ServiceName(serviceName)
 	svcFilePath := filepath.Join("/etc/systemd/system/", fmt.Sprintf("%s.service", serviceName))
 	ok, err := filesystem.DoesFileExist(svcFilePath)
 	if err != nil {
@@ -224,6 +232,7 @@ func StopSystemdService(serviceName string) error {
 }
 
 func StopLaunchdService(serviceName string) error {
+	serviceName = roller.ServiceName(serviceName)
 	svcFilePath := fmt.Sprintf("/Library/LaunchDaemons/xyz.dymension.roller.%s.plist", serviceName)
 
 	ok, err := filesystem.DoesFileExist(svcFilePath)
@@ -307,3 +316,40 @@ func RestartSystemServices(services []string, home string) error {
 	}
 	return nil
 }
+
+// IsServiceLoaded returns whether the service definition of a roller service
+// exists on the host
+func IsServiceLoaded(serviceName string) (bool, error) {
+	serviceName = roller.ServiceName(serviceName)
+	var svcFilePath string
+	switch runtime.GOOS {
+	case "linux":
+		svcFilePath = filepath.Join("/etc/systemd/system/", fmt.Sprintf("%s.service", serviceName))
+	case "darwin":
+		svcFilePath = fmt.Sprintf("/Library/LaunchDaemons/xyz.dymension.roller.%s.plist", serviceName)
+	default:
+		return false, fmt.Errorf("unsupported platform: %s", runtime.GOOS)
+	}
+
+	_, err := os.Stat(svcFilePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// IsServiceActive returns whether a roller service is currently running
+func IsServiceActive(serviceName string) bool {
+	serviceName = roller.ServiceName(serviceName)
+	switch runtime.GOOS {
+	case "linux":
+		out, err := exec.Command("systemctl", "is-active", serviceName).Output()
+		return err == nil && strings.TrimSpace(string(out)) == "active"
+	case "darwin":
+		err := exec.Command("launchctl", "list", fmt.Sprintf("xyz.dymension.roller.%s", serviceName)).Run()
+		return err == nil
+	default:
+		return false
+	}
+}
@@ -0,0 +1,258 @@
+// Package signer hands the signing of the hub transactions of the sequencer
+// operator key to the remote signing service of the [Signer] section of
+// roller.toml
+package signer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/bash"
+	"github.com/dymensionxyz/roller/utils/plan"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+// requestTimeout leaves room for signing services that wait for an approval
+const requestTimeout = 5 * time.Minute
+
+// SignRequest is posted to the operator url, Tx is the unsigned transaction
+// in the JSON format of `dymd tx sign`
+type SignRequest struct {
+	ChainID       string          `json:"chain_id"`
+	AccountNumber string          `json:"account_number"`
+	Sequence      string          `json:"sequence"`
+	Address       string          `json:"address"`
+	Tx            json.RawMessage `json:"tx"`
+}
+
+// SignResponse is the reply of the signing service, Tx is the signed
+// transaction
+type SignResponse struct {
+	Tx json.RawMessage `json:"tx"`
+}
+
+// localKeyFlags are the flags selecting a key of the local keyring, they are
+// dropped from the transactions signed remotely
+var localKeyFlags = map[string]bool{
+	"--from":            true,
+	"--keyring-backend": true,
+	"--keyring-dir":     true,
+	"--home":            true,
+}
+
+// OperatorTx generates the dymd transaction of args, e.g. tx sequencer unbond,
+// has the remote operator key sign it and broadcasts it. It returns the tx
+// hash, the caller monitors the transaction
+func OperatorTx(rollerData roller.RollappConfig, args []string) (string, error) {
+	s := rollerData.Signer
+	hd := rollerData.HubData
+	if s.OperatorAddress == "" {
+		return "", fmt.Errorf("operator_address is required in the Signer section of roller.toml")
+	}
+
+	if plan.Enabled() {
+		plan.Record(
+			plan.KindCommand,
+			"%s %s signed by %s for %s and broadcast",
+			consts.Executables.Dymension,
+			strings.Join(remoteArgs(args), " "),
+			s.OperatorURL,
+			s.OperatorAddress,
+		)
+		return "", nil
+	}
+
+	genArgs := append(remoteArgs(args), "--from", s.OperatorAddress, "--generate-only")
+	unsigned, err := bash.ExecCommandWithStdout(exec.Command(consts.Executables.Dymension, genArgs...))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate the transaction: %w", err)
+	}
+
+	accNum, seq, err := account(s.OperatorAddress, hd)
+	if err != nil {
+		return "", err
+	}
+
+	signed, err := Sign(s, SignRequest{
+		ChainID:       hd.ID,
+		AccountNumber: accNum,
+		Sequence:      seq,
+		Address:       s.OperatorAddress,
+		Tx:            bytes.TrimSpace(unsigned.Bytes()),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return broadcast(signed, hd)
+}
+
+// Sign posts the request to the operator url and returns the signed
+// transaction
+func Sign(s roller.SignerConfig, req SignRequest) ([]byte, error) {
+	b, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequest(http.MethodPost, s.OperatorURL, bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	for k, v := range s.Headers {
+		httpReq.Header.Set(k, os.ExpandEnv(v))
+	}
+
+	client := &http.Client{Timeout: requestTimeout}
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach the remote signer: %w", err)
+	}
+	// nolint: errcheck
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf(
+			"remote signer refused the transaction: %s: %s",
+			resp.Status,
+			strings.TrimSpace(string(body)),
+		)
+	}
+
+	var signed SignResponse
+	if err := json.Unmarshal(body, &signed); err != nil {
+		return nil, fmt.Errorf("failed to parse the remote signer response: %w", err)
+	}
+	if len(signed.Tx) == 0 || string(signed.Tx) == "null" {
+		return nil, fmt.Errorf("remote signer returned no transaction")
+	}
+	return signed.Tx, nil
+}
+
+// remoteArgs drops the local key flags and the confirmation skip from args
+func remoteArgs(args []string) []string {
+	out := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		name, _, hasValue := strings.Cut(a, "=")
+		switch {
+		case a == "-y" || a == "--yes":
+		case localKeyFlags[name]:
+			if !hasValue {
+				i++
+			}
+		default:
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// account returns the account number and sequence of addr
+func account(addr string, hd consts.HubData) (string, string, error) {
+	out, err := bash.ExecCommandWithStdout(exec.Command(
+		consts.Executables.Dymension,
+		"q", "auth", "account", addr,
+		"--node", hd.RpcUrl,
+		"--chain-id", hd.ID,
+		"-o", "json",
+	))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to query the operator account: %w", err)
+	}
+
+	var v any
+	if err := json.Unmarshal(out.Bytes(), &v); err != nil {
+		return "", "", fmt.Errorf("failed to parse the operator account: %w", err)
+	}
+	// the fields are nested differently by the account types, e.g. the base
+	// account of an eth account
+	accNum, seq := findField(v, "account_number"), findField(v, "sequence")
+	if accNum == "" {
+		return "", "", fmt.Errorf("no account number found for %s", addr)
+	}
+	if seq == "" {
+		seq = "0"
+	}
+	return accNum, seq, nil
+}
+
+func findField(v any, name string) string {
+	switch t := v.(type) {
+	case map[string]any:
+		if f, ok := t[name]; ok {
+			switch f := f.(type) {
+			case string:
+				return f
+			case float64:
+				return fmt.Sprintf("%.0f", f)
+			}
+		}
+		for _, child := range t {
+			if f := findField(child, name); f != "" {
+				return f
+			}
+		}
+	case []any:
+		for _, child := range t {
+			if f := findField(child, name); f != "" {
+				return f
+			}
+		}
+	}
+	return ""
+}
+
+func broadcast(signed []byte, hd consts.HubData) (string, error) {
+	f, err := os.CreateTemp("", "roller-signed-tx-*.json")
+	if err != nil {
+		return "", err
+	}
+	// nolint: errcheck
+	defer os.Remove(f.Name())
+	if _, err := f.Write(signed); err != nil {
+		// nolint: errcheck
+		f.Close()
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+
+	out, err := bash.ExecCommandWithStdout(exec.Command(
+		consts.Executables.Dymension,
+		"tx", "broadcast", f.Name(),
+		"--node", hd.RpcUrl,
+		"--chain-id", hd.ID,
+		"-o", "json",
+	))
+	if err != nil {
+		return "", fmt.Errorf("failed to broadcast: %w", err)
+	}
+
+	var resp struct {
+		TxHash string `json:"txhash"`
+		Code   int    `json:"code"`
+		RawLog string `json:"raw_log"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &resp); err != nil {
+		return "", fmt.Errorf("failed to parse broadcast response: %w", err)
+	}
+	if resp.Code != 0 {
+		return resp.TxHash, fmt.Errorf("transaction failed with code %d: %s", resp.Code, resp.RawLog)
+	}
+	return resp.TxHash, nil
+}
@@ -0,0 +1,354 @@
+package statenode
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client/grpc/tmservice"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+)
+
+const (
+	// CorePort is the gRPC port the DA light client connects to on a state node
+	CorePort = "9090"
+
+	scoresFileName  = "state_nodes.json"
+	historyFileName = "state_node_history.jsonl"
+
+	probeTimeout = 5 * time.Second
+	// ewmaAlpha is the weight of the latest probe in the rolling averages
+	ewmaAlpha = 0.3
+)
+
+// Probe is the outcome of a single probe of a state node
+type Probe struct {
+	Node    string
+	Latency time.Duration
+	Height  int64
+	Err     error
+}
+
+// Score is the rolling health record of a state node
+type Score struct {
+	Node         string    `json:"node" yaml:"node"`
+	Score        float64   `json:"score" yaml:"score"`
+	Healthy      bool      `json:"healthy" yaml:"healthy"`
+	LatencyMs    float64   `json:"latency_ms" yaml:"latency_ms"`
+	Height       int64     `json:"height" yaml:"height"`
+	HeightLag    int64     `json:"height_lag" yaml:"height_lag"`
+	ErrorRate    float64   `json:"error_rate" yaml:"error_rate"`
+	Probes       int       `json:"probes" yaml:"probes"`
+	Failures     int       `json:"failures" yaml:"failures"`
+	LastError    string    `json:"last_error,omitempty" yaml:"last_error,omitempty"`
+	LastProbedAt time.Time `json:"last_probed_at" yaml:"last_probed_at"`
+}
+
+// Event is a state node switch recorded in the history file
+type Event struct {
+	Time   time.Time `json:"time" yaml:"time"`
+	From   string    `json:"from" yaml:"from"`
+	To     string    `json:"to" yaml:"to"`
+	Reason string    `json:"reason" yaml:"reason"`
+	Score  float64   `json:"score" yaml:"score"`
+}
+
+// Candidates returns the configured state nodes including the current one,
+// without duplicates
+func Candidates(da consts.DaData) []string {
+	var nodes []string
+	seen := map[string]bool{}
+	for _, n := range append([]string{da.CurrentStateNode}, da.StateNodes...) {
+		if n == "" || seen[n] {
+			continue
+		}
+		seen[n] = true
+		nodes = append(nodes, n)
+	}
+	return nodes
+}
+
+// ProbeAll probes all nodes concurrently
+func ProbeAll(nodes []string) []Probe {
+	probes := make([]Probe, len(nodes))
+
+	var wg sync.WaitGroup
+	for i, n := range nodes {
+		wg.Add(1)
+		go func(i int, n string) {
+			defer wg.Done()
+			probes[i] = probeCore(n)
+		}(i, n)
+	}
+	wg.Wait()
+
+	return probes
+}
+
+// probeCore fetches the latest block of a state node over its core gRPC port
+func probeCore(node string) Probe {
+	p := Probe{Node: node}
+
+	ctx, cancel := context.WithTimeout(context.Background(), probeTimeout)
+	defer cancel()
+
+	start := time.Now()
+	conn, err := grpc.NewClient(
+		net.JoinHostPort(node, CorePort),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(
+			grpc.ForceCodec(codec.NewProtoCodec(codectypes.NewInterfaceRegistry()).GRPCCodec()),
+		),
+	)
+	if err != nil {
+		p.Err = err
+		return p
+	}
+	// nolint: errcheck
+	defer conn.Close()
+
+	resp, err := tmservice.NewServiceClient(conn).GetLatestBlock(
+		ctx,
+		&tmservice.GetLatestBlockRequest{},
+	)
+	p.Latency = time.Since(start)
+	if err != nil {
+		p.Err = err
+		return p
+	}
+	if resp.GetBlock() == nil {
+		p.Err = errors.New("empty block in response")
+		return p
+	}
+
+	p.Height = resp.GetBlock().Header.Height
+	return p
+}
+
+// Update folds a round of probes into the scores. Nodes that are no longer
+// probed are dropped
+func Update(prev map[string]Score, probes []Probe) map[string]Score {
+	var maxHeight int64
+	for _, p := range probes {
+		if p.Err == nil && p.Height > maxHeight {
+			maxHeight = p.Height
+		}
+	}
+
+	scores := make(map[string]Score, len(probes))
+	for _, p := range probes {
+		s, ok := prev[p.Node]
+		if !ok {
+			s = Score{Node: p.Node}
+		}
+
+		failed := 0.0
+		if p.Err != nil {
+			failed = 1
+		}
+		if s.Probes == 0 {
+			s.ErrorRate = failed
+		} else {
+			s.ErrorRate = ewma(s.ErrorRate, failed)
+		}
+
+		s.Probes++
+		s.LastProbedAt = time.Now().UTC()
+		if p.Err != nil {
+			s.Failures++
+			s.Healthy = false
+			s.LastError = p.Err.Error()
+		} else {
+			latency := float64(p.Latency.Milliseconds())
+			if s.LatencyMs == 0 {
+				s.LatencyMs = latency
+			} else {
+				s.LatencyMs = ewma(s.LatencyMs, latency)
+			}
+			s.Height = p.Height
+			s.HeightLag = maxHeight - p.Height
+			s.Healthy = true
+			s.LastError = ""
+		}
+
+		s.Score = score(s)
+		scores[p.Node] = s
+	}
+
+	return scores
+}
+
+// score rates a node from 0 to 100. A node that failed its latest probe scores
+// 0, otherwise the error rate, the head height lag behind the best node and the
+// latency lower the score
+func score(s Score) float64 {
+	if !s.Healthy {
+		return 0
+	}
+
+	v := 100*(1-s.ErrorRate) -
+		math.Min(float64(s.HeightLag)*2, 40) -
+		math.Min(s.LatencyMs/50, 20)
+
+	return math.Max(math.Round(v*100)/100, 0)
+}
+
+func ewma(prev, v float64) float64 {
+	return ewmaAlpha*v + (1-ewmaAlpha)*prev
+}
+
+// Best returns the healthy node with the highest score, excluding the given
+// nodes
+func Best(scores map[string]Score, exclude ...string) (Score, bool) {
+	var best Score
+	var found bool
+	for _, s := range Sorted(scores) {
+		if !s.Healthy || slices.Contains(exclude, s.Node) {
+			continue
+		}
+		if !found || s.Score > best.Score {
+			best = s
+			found = true
+		}
+	}
+	return best, found
+}
+
+// Sorted returns the scores ordered from the best to the worst node
+func Sorted(scores map[string]Score) []Score {
+	out := make([]Score, 0, len(scores))
+	for _, s := range scores {
+		out = append(out, s)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Score != out[j].Score {
+			return out[i].Score > out[j].Score
+		}
+		return out[i].Node < out[j].Node
+	})
+	return out
+}
+
+func getDir(home string) string {
+	return filepath.Join(home, consts.ConfigDirName.DALightNode)
+}
+
+func GetScoresFilePath(home string) string {
+	return filepath.Join(getDir(home), scoresFileName)
+}
+
+func GetHistoryFilePath(home string) string {
+	return filepath.Join(getDir(home), historyFileName)
+}
+
+// LoadScores reads the persisted scores, a missing file means no node was
+// probed yet
+func LoadScores(home string) (map[string]Score, error) {
+	scores := map[string]Score{}
+
+	b, err := os.ReadFile(GetScoresFilePath(home))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return scores, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(b, &scores); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", scoresFileName, err)
+	}
+	return scores, nil
+}
+
+func WriteScores(home string, scores map[string]Score) error {
+	if err := os.MkdirAll(getDir(home), 0o755); err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(scores, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(GetScoresFilePath(home), b, 0o644)
+}
+
+// Refresh probes the candidate nodes of the DA config and persists their
+// updated scores
+func Refresh(home string, da consts.DaData) (map[string]Score, error) {
+	prev, err := LoadScores(home)
+	if err != nil {
+		return nil, err
+	}
+
+	scores := Update(prev, ProbeAll(Candidates(da)))
+	if err := WriteScores(home, scores); err != nil {
+		return nil, err
+	}
+
+	return scores, nil
+}
+
+// RecordEvent appends a state node switch to the history file
+func RecordEvent(home string, e Event) error {
+	if err := os.MkdirAll(getDir(home), 0o755); err != nil {
+		return err
+	}
+
+	if e.Time.IsZero() {
+		e.Time = time.Now().UTC()
+	}
+
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(GetHistoryFilePath(home), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	// nolint: errcheck
+	defer f.Close()
+
+	_, err = f.Write(append(b, '\n'))
+	return err
+}
+
+// LoadHistory returns the recorded state node switches, oldest first
+func LoadHistory(home string) ([]Event, error) {
+	b, err := os.ReadFile(GetHistoryFilePath(home))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var events []Event
+	dec := json.NewDecoder(bytes.NewReader(b))
+	for dec.More() {
+		var e Event
+		if err := dec.Decode(&e); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", historyFileName, err)
+		}
+		events = append(events, e)
+	}
+
+	return events, nil
+}
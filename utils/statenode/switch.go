@@ -0,0 +1,50 @@
+package statenode
+
+import (
+	"fmt"
+
+	"github.com/dymensionxyz/roller/cmd/services/load"
+	"github.com/dymensionxyz/roller/utils/config/tomlconfig"
+	"github.com/dymensionxyz/roller/utils/roller"
+	servicemanager "github.com/dymensionxyz/roller/utils/service_manager"
+)
+
+// Switch makes the DA light client use another state node. The change is
+// recorded in the history file and the light client service is reloaded when
+// it is installed on the host
+func Switch(rollerData roller.RollappConfig, to, reason string, score float64) error {
+	from := rollerData.DA.CurrentStateNode
+
+	err := tomlconfig.UpdateFieldInFile(
+		roller.GetConfigPath(rollerData.Home),
+		"DA.current_state_node",
+		to,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update state node: %w", err)
+	}
+	rollerData.DA.CurrentStateNode = to
+
+	err = RecordEvent(rollerData.Home, Event{From: from, To: to, Reason: reason, Score: score})
+	if err != nil {
+		return fmt.Errorf("failed to record state node switch: %w", err)
+	}
+
+	services := []string{"da-light-client"}
+	loaded, err := servicemanager.IsServiceLoaded(services[0])
+	if err != nil || !loaded {
+		return err
+	}
+
+	err = load.LoadServices(services, rollerData)
+	if err != nil {
+		return fmt.Errorf("failed to update services: %w", err)
+	}
+
+	err = servicemanager.RestartSystemServices(services, rollerData.Home)
+	if err != nil {
+		return fmt.Errorf("failed to restart services: %w", err)
+	}
+
+	return nil
+}
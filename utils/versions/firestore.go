@@ -0,0 +1,51 @@
+package versions
+
+import (
+	"github.com/dymensionxyz/roller/utils/firebase"
+)
+
+// Firestore resolves versions from the drs-metadata Firestore project
+type Firestore struct{}
+
+func (Firestore) Name() string {
+	return SourceFirestore
+}
+
+func (Firestore) DependencyVersions(env string) (*BinaryVersionInfo, error) {
+	bvi, err := firebase.GetDependencyVersions(env)
+	if err != nil {
+		return nil, err
+	}
+	return &BinaryVersionInfo{
+		EibcClient:   bvi.EibcClient,
+		Relayer:      bvi.Relayer,
+		Dymd:         bvi.Dymd,
+		CelestiaNode: bvi.CelestiaNode,
+		CelestiaApp:  bvi.CelestiaApp,
+	}, nil
+}
+
+func (Firestore) DrsVersionCommit(drsVersion, env string) (*DrsVersionInfo, error) {
+	info, err := firebase.GetLatestDrsVersionCommit(drsVersion, env)
+	if err != nil {
+		return nil, err
+	}
+	return &DrsVersionInfo{
+		Commit:     info.Commit,
+		EvmCommit:  info.EvmCommit,
+		WasmCommit: info.WasmCommit,
+	}, nil
+}
+
+func (Firestore) OracleVersions() (*OracleVersionInfo, error) {
+	obvi, err := firebase.GetOracleBinaryVersions()
+	if err != nil {
+		return nil, err
+	}
+	return &OracleVersionInfo{
+		PriceEvmOracle:      obvi.PriceEvmOracle,
+		PriceWasmOracle:     obvi.PriceWasmOracle,
+		RngEvmOracle:        obvi.RngEvmOracle,
+		RngEvmRandomService: obvi.RngEvmRandomService,
+	}, nil
+}
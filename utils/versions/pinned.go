@@ -0,0 +1,90 @@
+package versions
+
+// pinnedSource overrides the versions resolved by a source with the values
+// pinned in roller.toml
+type pinnedSource struct {
+	base   Source
+	pinned Set
+}
+
+// Pin returns a source resolving the pinned values, the others are resolved by
+// base
+func Pin(base Source, pinned Set) Source {
+	return &pinnedSource{base: base, pinned: pinned}
+}
+
+func (p *pinnedSource) Name() string {
+	return p.base.Name() + " with values pinned in roller.toml"
+}
+
+func (p *pinnedSource) DependencyVersions(env string) (*BinaryVersionInfo, error) {
+	t := p.pinned.Tools
+
+	bvi, err := p.base.DependencyVersions(env)
+	if err != nil {
+		if !complete(t.EibcClient, t.Relayer, t.Dymd, t.CelestiaNode, t.CelestiaApp) {
+			return nil, err
+		}
+		bvi = &BinaryVersionInfo{}
+	}
+
+	override(&bvi.EibcClient, t.EibcClient)
+	override(&bvi.Relayer, t.Relayer)
+	override(&bvi.Dymd, t.Dymd)
+	override(&bvi.CelestiaNode, t.CelestiaNode)
+	override(&bvi.CelestiaApp, t.CelestiaApp)
+	return bvi, nil
+}
+
+func (p *pinnedSource) DrsVersionCommit(drsVersion, env string) (*DrsVersionInfo, error) {
+	pinned, ok := p.pinned.Drs[drsVersion]
+
+	info, err := p.base.DrsVersionCommit(drsVersion, env)
+	if err != nil {
+		if !ok {
+			return nil, err
+		}
+		info = &DrsVersionInfo{}
+	}
+	if !ok {
+		return info, nil
+	}
+
+	override(&info.Commit, pinned.Commit)
+	override(&info.EvmCommit, pinned.EvmCommit)
+	override(&info.WasmCommit, pinned.WasmCommit)
+	return info, nil
+}
+
+func (p *pinnedSource) OracleVersions() (*OracleVersionInfo, error) {
+	o := p.pinned.Oracles
+
+	obvi, err := p.base.OracleVersions()
+	if err != nil {
+		if !complete(o.PriceEvmOracle, o.PriceWasmOracle, o.RngEvmOracle, o.RngEvmRandomService) {
+			return nil, err
+		}
+		obvi = &OracleVersionInfo{}
+	}
+
+	override(&obvi.PriceEvmOracle, o.PriceEvmOracle)
+	override(&obvi.PriceWasmOracle, o.PriceWasmOracle)
+	override(&obvi.RngEvmOracle, o.RngEvmOracle)
+	override(&obvi.RngEvmRandomService, o.RngEvmRandomService)
+	return obvi, nil
+}
+
+func override(v *string, pinned string) {
+	if pinned != "" {
+		*v = pinned
+	}
+}
+
+func complete(values ...string) bool {
+	for _, v := range values {
+		if v == "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,162 @@
+package versions
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	naoinatoml "github.com/naoina/toml"
+)
+
+// Set is a static set of versions, it is the format of the version manifest
+// and of the values pinned in roller.toml. A manifest describes a single hub
+// environment, the env of the lookups is ignored
+type Set struct {
+	Tools BinaryVersionInfo `json:"tools" toml:"tools"`
+	// Drs is keyed by DRS version
+	Drs     map[string]DrsVersionInfo `json:"drs" toml:"drs"`
+	Oracles OracleVersionInfo         `json:"oracles" toml:"oracles"`
+}
+
+func (s Set) IsEmpty() bool {
+	return s.Tools == BinaryVersionInfo{} && len(s.Drs) == 0 && s.Oracles == OracleVersionInfo{}
+}
+
+// Static resolves versions from a Set
+type Static struct {
+	name string
+	set  Set
+}
+
+func NewStatic(name string, set Set) *Static {
+	return &Static{name: name, set: set}
+}
+
+func (s *Static) Name() string {
+	return s.name
+}
+
+func (s *Static) DependencyVersions(_ string) (*BinaryVersionInfo, error) {
+	if s.set.Tools == (BinaryVersionInfo{}) {
+		return nil, fmt.Errorf("no tool versions are set in %s", s.name)
+	}
+	bvi := s.set.Tools
+	return &bvi, nil
+}
+
+func (s *Static) DrsVersionCommit(drsVersion, _ string) (*DrsVersionInfo, error) {
+	info, ok := s.set.Drs[drsVersion]
+	if !ok {
+		return nil, fmt.Errorf("no commit is set for drs version %s in %s", drsVersion, s.name)
+	}
+	return &info, nil
+}
+
+func (s *Static) OracleVersions() (*OracleVersionInfo, error) {
+	if s.set.Oracles == (OracleVersionInfo{}) {
+		return nil, fmt.Errorf("no oracle versions are set in %s", s.name)
+	}
+	obvi := s.set.Oracles
+	return &obvi, nil
+}
+
+// Manifest resolves versions from a JSON or TOML manifest at a url or path,
+// the manifest is read on the first lookup
+type Manifest struct {
+	location string
+
+	once   sync.Once
+	static *Static
+	err    error
+}
+
+func NewManifest(location string) *Manifest {
+	return &Manifest{location: location}
+}
+
+func (m *Manifest) Name() string {
+	return m.location
+}
+
+func (m *Manifest) load() (*Static, error) {
+	m.once.Do(func() {
+		set, err := LoadManifest(m.location)
+		if err != nil {
+			m.err = fmt.Errorf("failed to load version manifest %s: %w", m.location, err)
+			return
+		}
+		m.static = NewStatic(m.location, set)
+	})
+	return m.static, m.err
+}
+
+func (m *Manifest) DependencyVersions(env string) (*BinaryVersionInfo, error) {
+	s, err := m.load()
+	if err != nil {
+		return nil, err
+	}
+	return s.DependencyVersions(env)
+}
+
+func (m *Manifest) DrsVersionCommit(drsVersion, env string) (*DrsVersionInfo, error) {
+	s, err := m.load()
+	if err != nil {
+		return nil, err
+	}
+	return s.DrsVersionCommit(drsVersion, env)
+}
+
+func (m *Manifest) OracleVersions() (*OracleVersionInfo, error) {
+	s, err := m.load()
+	if err != nil {
+		return nil, err
+	}
+	return s.OracleVersions()
+}
+
+// LoadManifest reads the manifest at an http(s) url, a file:// url or a path.
+// Manifests ending in .toml are parsed as TOML, any other as JSON
+func LoadManifest(location string) (Set, error) {
+	var set Set
+
+	var b []byte
+	var err error
+	switch {
+	case strings.HasPrefix(location, "http://"), strings.HasPrefix(location, "https://"):
+		b, err = fetch(location)
+	default:
+		b, err = os.ReadFile(strings.TrimPrefix(location, "file://"))
+	}
+	if err != nil {
+		return set, err
+	}
+
+	p, _, _ := strings.Cut(location, "?")
+	if strings.HasSuffix(p, ".toml") {
+		err = naoinatoml.Unmarshal(b, &set)
+	} else {
+		err = json.Unmarshal(b, &set)
+	}
+	if err != nil {
+		return set, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	return set, nil
+}
+
+func fetch(url string) ([]byte, error) {
+	resp, err := http.Get(url) // nolint: gosec
+	if err != nil {
+		return nil, err
+	}
+	// nolint: errcheck
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("bad status: %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
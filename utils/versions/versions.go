@@ -0,0 +1,163 @@
+// Package versions resolves the versions of the binaries roller installs and
+// the rollapp commits of each DRS version. They are resolved from the
+// drs-metadata Firestore project by default, from a static manifest, or from
+// values pinned in roller.toml
+package versions
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/dymensionxyz/roller/config"
+)
+
+const (
+	SourceFirestore = "firestore"
+	SourceManifest  = "manifest"
+	SourcePinned    = "pinned"
+)
+
+// BinaryVersionInfo is the release of each tool installed alongside the rollapp
+type BinaryVersionInfo struct {
+	EibcClient   string `json:"eibc_client,omitempty" toml:"eibc_client"`
+	Relayer      string `json:"relayer,omitempty" toml:"relayer"`
+	Dymd         string `json:"dymd,omitempty" toml:"dymd"`
+	CelestiaNode string `json:"celestia_node,omitempty" toml:"celestia_node"`
+	CelestiaApp  string `json:"celestia_app,omitempty" toml:"celestia_app"`
+}
+
+// DrsVersionInfo is the rollapp commit of a DRS version for each VM type
+type DrsVersionInfo struct {
+	Commit     string `json:"commit,omitempty" toml:"commit"`
+	EvmCommit  string `json:"evm_commit,omitempty" toml:"evm_commit"`
+	WasmCommit string `json:"wasm_commit,omitempty" toml:"wasm_commit"`
+}
+
+// OracleVersionInfo is the release of each oracle client
+type OracleVersionInfo struct {
+	PriceEvmOracle      string `json:"price_evm,omitempty" toml:"price_evm"`
+	PriceWasmOracle     string `json:"price_wasm,omitempty" toml:"price_wasm"`
+	RngEvmOracle        string `json:"rng_evm,omitempty" toml:"rng_evm"`
+	RngEvmRandomService string `json:"rng_evm_random_service,omitempty" toml:"rng_evm_random_service"`
+}
+
+// Source resolves versions for a hub environment
+type Source interface {
+	Name() string
+	DependencyVersions(env string) (*BinaryVersionInfo, error)
+	DrsVersionCommit(drsVersion, env string) (*DrsVersionInfo, error)
+	OracleVersions() (*OracleVersionInfo, error)
+}
+
+// Config is the [Versions] section of roller.toml
+type Config struct {
+	// Source is firestore (the default), manifest or pinned
+	Source string `toml:"source"`
+	// Manifest is the url or path of the manifest read by the manifest source
+	Manifest string `toml:"manifest"`
+	// Pinned values take precedence over the ones of the source, the pinned
+	// source only resolves these
+	Pinned Set `toml:"pinned"`
+}
+
+var (
+	mu     sync.Mutex
+	active Source
+)
+
+// Configure sets the source of the following lookups from the roller.toml
+// section, ROLLER_VERSION_SOURCE and ROLLER_VERSIONS_MANIFEST take precedence
+func Configure(cfg Config) error {
+	s, err := New(cfg)
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	active = s
+	return nil
+}
+
+// New returns the source selected by cfg and the environment
+func New(cfg Config) (Source, error) {
+	if s := config.Config.VersionSource; s != "" {
+		cfg.Source = s
+	}
+	if m := config.Config.VersionsManifest; m != "" {
+		cfg.Manifest = m
+		if cfg.Source == "" {
+			cfg.Source = SourceManifest
+		}
+	}
+
+	var base Source
+	switch cfg.Source {
+	case "", SourceFirestore:
+		base = Firestore{}
+	case SourceManifest:
+		if cfg.Manifest == "" {
+			return nil, fmt.Errorf("the manifest version source requires a manifest url or path")
+		}
+		base = NewManifest(cfg.Manifest)
+	case SourcePinned:
+		return NewStatic("roller.toml", cfg.Pinned), nil
+	default:
+		return nil, fmt.Errorf(
+			"unknown version source %q, expected %s, %s or %s",
+			cfg.Source,
+			SourceFirestore,
+			SourceManifest,
+			SourcePinned,
+		)
+	}
+
+	if cfg.Pinned.IsEmpty() {
+		return base, nil
+	}
+	return Pin(base, cfg.Pinned), nil
+}
+
+// Active returns the configured source, the environment selects it when
+// Configure was not called
+func Active() (Source, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if active == nil {
+		s, err := New(Config{})
+		if err != nil {
+			return nil, err
+		}
+		active = s
+	}
+	return active, nil
+}
+
+// GetDependencyVersions returns the tool versions of env from the active source
+func GetDependencyVersions(env string) (*BinaryVersionInfo, error) {
+	s, err := Active()
+	if err != nil {
+		return nil, err
+	}
+	return s.DependencyVersions(env)
+}
+
+// GetLatestDrsVersionCommit returns the rollapp commits of the latest revision
+// of drsVersion from the active source
+func GetLatestDrsVersionCommit(drsVersion, env string) (*DrsVersionInfo, error) {
+	s, err := Active()
+	if err != nil {
+		return nil, err
+	}
+	return s.DrsVersionCommit(drsVersion, env)
+}
+
+// GetOracleVersions returns the oracle client versions from the active source
+func GetOracleVersions() (*OracleVersionInfo, error) {
+	s, err := Active()
+	if err != nil {
+		return nil, err
+	}
+	return s.OracleVersions()
+}
@@ -0,0 +1,328 @@
+package versions
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dymensionxyz/roller/config"
+)
+
+const jsonManifest = `{
+  "tools": {"eibc_client": "v1.0.0", "relayer": "v0.4.0", "dymd": "v3.2.0"},
+  "drs": {"9": {"evm_commit": "abc", "wasm_commit": "def"}},
+  "oracles": {"price_evm": "v0.1.0"}
+}`
+
+const tomlManifest = `
+[tools]
+eibc_client = "v1.0.0"
+relayer = "v0.4.0"
+dymd = "v3.2.0"
+
+[drs.9]
+evm_commit = "abc"
+wasm_commit = "def"
+
+[oracles]
+price_evm = "v0.1.0"
+`
+
+// setEnv sets an environment variable for the test and reloads the roller
+// environment config
+func setEnv(t *testing.T, key, value string) {
+	t.Helper()
+	t.Cleanup(func() {
+		config.Config = config.EnvConfig{}
+		// nolint: errcheck
+		config.Load()
+	})
+	t.Setenv(key, value)
+	if err := config.Load(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadManifest(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		p := filepath.Join(dir, name)
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+	jsonPath := write("versions.json", jsonManifest)
+	tomlPath := write("versions.toml", tomlManifest)
+	invalidPath := write("invalid.json", "{")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/versions.json":
+			fmt.Fprint(w, jsonManifest)
+		case "/versions.toml":
+			fmt.Fprint(w, tomlManifest)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name     string
+		location string
+		wantErr  string
+	}{
+		{name: "json path", location: jsonPath},
+		{name: "toml path", location: tomlPath},
+		{name: "file url", location: "file://" + tomlPath},
+		{name: "json url", location: srv.URL + "/versions.json"},
+		{name: "toml url with a query", location: srv.URL + "/versions.toml?ref=main"},
+		{name: "missing url", location: srv.URL + "/missing.json", wantErr: "bad status"},
+		{name: "missing path", location: filepath.Join(dir, "missing.json"), wantErr: "no such file"},
+		{name: "invalid", location: invalidPath, wantErr: "failed to parse manifest"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set, err := LoadManifest(tt.location)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadManifest(%q) error = %v, want %q", tt.location, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadManifest(%q) unexpected error: %v", tt.location, err)
+			}
+
+			want := BinaryVersionInfo{EibcClient: "v1.0.0", Relayer: "v0.4.0", Dymd: "v3.2.0"}
+			if set.Tools != want {
+				t.Fatalf("tools = %+v, want %+v", set.Tools, want)
+			}
+			if set.Drs["9"] != (DrsVersionInfo{EvmCommit: "abc", WasmCommit: "def"}) {
+				t.Fatalf("drs = %+v", set.Drs)
+			}
+			if set.Oracles != (OracleVersionInfo{PriceEvmOracle: "v0.1.0"}) {
+				t.Fatalf("oracles = %+v", set.Oracles)
+			}
+		})
+	}
+}
+
+// failingSource fails every lookup, as an unreachable Firestore
+type failingSource struct{}
+
+var errUnreachable = errors.New("unreachable")
+
+func (failingSource) Name() string { return "failing" }
+
+func (failingSource) DependencyVersions(string) (*BinaryVersionInfo, error) {
+	return nil, errUnreachable
+}
+
+func (failingSource) DrsVersionCommit(string, string) (*DrsVersionInfo, error) {
+	return nil, errUnreachable
+}
+
+func (failingSource) OracleVersions() (*OracleVersionInfo, error) {
+	return nil, errUnreachable
+}
+
+func TestPinDependencyVersions(t *testing.T) {
+	base := NewStatic("base", Set{
+		Tools: BinaryVersionInfo{
+			EibcClient:   "v1.0.0",
+			Relayer:      "v0.4.0",
+			Dymd:         "v3.2.0",
+			CelestiaNode: "v0.20.0",
+			CelestiaApp:  "v2.1.2",
+		},
+	})
+	all := BinaryVersionInfo{
+		EibcClient:   "v9.0.0",
+		Relayer:      "v9.0.0",
+		Dymd:         "v9.0.0",
+		CelestiaNode: "v9.0.0",
+		CelestiaApp:  "v9.0.0",
+	}
+
+	tests := []struct {
+		name    string
+		base    Source
+		pinned  BinaryVersionInfo
+		want    BinaryVersionInfo
+		wantErr bool
+	}{
+		{
+			name:   "pinned value overrides the source",
+			base:   base,
+			pinned: BinaryVersionInfo{Dymd: "v3.3.0"},
+			want: BinaryVersionInfo{
+				EibcClient:   "v1.0.0",
+				Relayer:      "v0.4.0",
+				Dymd:         "v3.3.0",
+				CelestiaNode: "v0.20.0",
+				CelestiaApp:  "v2.1.2",
+			},
+		},
+		{name: "all values pinned without a source", base: failingSource{}, pinned: all, want: all},
+		{name: "some values pinned without a source", base: failingSource{}, pinned: BinaryVersionInfo{Dymd: "v3.3.0"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Pin(tt.base, Set{Tools: tt.pinned}).DependencyVersions("mainnet")
+			if tt.wantErr {
+				if !errors.Is(err, errUnreachable) {
+					t.Fatalf("error = %v, want the source error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *got != tt.want {
+				t.Fatalf("got %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPinDrsVersionCommit(t *testing.T) {
+	base := NewStatic("base", Set{Drs: map[string]DrsVersionInfo{
+		"8": {EvmCommit: "evm8", WasmCommit: "wasm8"},
+	}})
+
+	tests := []struct {
+		name    string
+		base    Source
+		drs     string
+		pinned  map[string]DrsVersionInfo
+		want    DrsVersionInfo
+		wantErr bool
+	}{
+		{name: "not pinned", base: base, drs: "8", want: DrsVersionInfo{EvmCommit: "evm8", WasmCommit: "wasm8"}},
+		{
+			name:   "pinned over the source",
+			base:   base,
+			drs:    "8",
+			pinned: map[string]DrsVersionInfo{"8": {EvmCommit: "pinned"}},
+			want:   DrsVersionInfo{EvmCommit: "pinned", WasmCommit: "wasm8"},
+		},
+		{
+			name:   "pinned without a source",
+			base:   failingSource{},
+			drs:    "8",
+			pinned: map[string]DrsVersionInfo{"8": {EvmCommit: "pinned"}},
+			want:   DrsVersionInfo{EvmCommit: "pinned"},
+		},
+		{
+			name:    "other version pinned without a source",
+			base:    failingSource{},
+			drs:     "9",
+			pinned:  map[string]DrsVersionInfo{"8": {EvmCommit: "pinned"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Pin(tt.base, Set{Drs: tt.pinned}).DrsVersionCommit(tt.drs, "mainnet")
+			if tt.wantErr {
+				if !errors.Is(err, errUnreachable) {
+					t.Fatalf("error = %v, want the source error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *got != tt.want {
+				t.Fatalf("got %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	manifest := filepath.Join(t.TempDir(), "versions.json")
+	if err := os.WriteFile(manifest, []byte(jsonManifest), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	pinned := Set{Tools: BinaryVersionInfo{Dymd: "v3.3.0"}}
+
+	tests := []struct {
+		name     string
+		cfg      Config
+		env      map[string]string
+		wantName string
+		wantErr  string
+	}{
+		{name: "firestore by default", wantName: SourceFirestore},
+		{name: "firestore", cfg: Config{Source: SourceFirestore}, wantName: SourceFirestore},
+		{name: "manifest", cfg: Config{Source: SourceManifest, Manifest: manifest}, wantName: manifest},
+		{
+			name:    "manifest without a location",
+			cfg:     Config{Source: SourceManifest},
+			wantErr: "requires a manifest url or path",
+		},
+		{name: "pinned", cfg: Config{Source: SourcePinned, Pinned: pinned}, wantName: "roller.toml"},
+		{
+			name:     "firestore with pinned values",
+			cfg:      Config{Pinned: pinned},
+			wantName: SourceFirestore + " with values pinned in roller.toml",
+		},
+		{name: "unknown", cfg: Config{Source: "git"}, wantErr: `unknown version source "git"`},
+		{
+			name:     "env source overrides the config",
+			cfg:      Config{Source: SourceManifest, Manifest: manifest},
+			env:      map[string]string{"ROLLER_VERSION_SOURCE": SourceFirestore},
+			wantName: SourceFirestore,
+		},
+		{
+			name:     "env manifest selects the manifest source",
+			env:      map[string]string{"ROLLER_VERSIONS_MANIFEST": manifest},
+			wantName: manifest,
+		},
+		{
+			name:     "env manifest overrides the config manifest",
+			cfg:      Config{Source: SourceManifest, Manifest: "https://example.com/versions.json"},
+			env:      map[string]string{"ROLLER_VERSIONS_MANIFEST": manifest},
+			wantName: manifest,
+		},
+		{
+			name:     "env manifest keeps an explicit source",
+			cfg:      Config{Source: SourcePinned, Pinned: pinned},
+			env:      map[string]string{"ROLLER_VERSIONS_MANIFEST": manifest},
+			wantName: "roller.toml",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				setEnv(t, k, v)
+			}
+
+			s, err := New(tt.cfg)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("New(%+v) error = %v, want %q", tt.cfg, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("New(%+v) unexpected error: %v", tt.cfg, err)
+			}
+			if s.Name() != tt.wantName {
+				t.Fatalf("New(%+v) selected %q, want %q", tt.cfg, s.Name(), tt.wantName)
+			}
+		})
+	}
+}